@@ -1,13 +1,21 @@
 package artifacts
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-
+	"path/filepath"
+	"runtime"
 	"slices"
+	"strings"
+	"sync"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/databricks/cli/libs/log"
 	"golang.org/x/exp/maps"
 )
 
@@ -16,6 +24,15 @@ import (
 type all struct {
 	name string
 	fn   func(name string) (bundle.Mutator, error)
+
+	// If set, the mutators for different artifacts may run concurrently.
+	// Such mutators must only modify the configuration of their own artifact.
+	concurrent bool
+
+	// If set, the mutators for artifacts that resolve to the same path
+	// don't run concurrently. Builds in the same directory share their
+	// output directory and may remove each other's outputs.
+	exclusivePaths bool
 }
 
 func (m *all) Name() string {
@@ -23,6 +40,7 @@ func (m *all) Name() string {
 }
 
 func (m *all) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var names []string
 	var out []bundle.Mutator
 
 	// Iterate with stable ordering.
@@ -35,9 +53,106 @@ func (m *all) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 			return diag.FromErr(err)
 		}
 		if m != nil {
+			names = append(names, name)
 			out = append(out, m)
 		}
 	}
 
-	return bundle.Apply(ctx, b, bundle.Seq(out...))
+	if !m.concurrent {
+		return bundle.Apply(ctx, b, bundle.Seq(out...))
+	}
+
+	// Mutators that are not allowed to run concurrently share a lock.
+	locks := make([]*sync.Mutex, len(names))
+	paths := make(map[string]*sync.Mutex)
+	for i, name := range names {
+		key := name
+		if m.exclusivePaths {
+			key = artifactPath(b, b.Config.Artifacts[name])
+		}
+		if _, ok := paths[key]; !ok {
+			paths[key] = &sync.Mutex{}
+		}
+		locks[i] = paths[key]
+	}
+
+	return applyConcurrently(ctx, b, names, out, locks, parallelism(b))
+}
+
+// artifactPath returns the absolute path of the directory the artifact is built in.
+func artifactPath(b *bundle.Bundle, artifact *config.Artifact) string {
+	p := artifact.Path
+	if p == "" {
+		return b.RootPath
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(filepath.Dir(artifact.ConfigFilePath), p)
+	}
+	return filepath.Clean(p)
+}
+
+// parallelism returns the maximum number of artifacts to process concurrently.
+func parallelism(b *bundle.Bundle) int {
+	if p := b.Config.Bundle.Deployment.Parallelism; p > 0 {
+		return p
+	}
+	return runtime.NumCPU()
+}
+
+type concurrentResult struct {
+	done  chan struct{}
+	logs  bytes.Buffer
+	diags diag.Diagnostics
+}
+
+// applyConcurrently applies the mutators with at most [parallelism] running at the same time.
+// Mutators that share a lock in [locks] don't run at the same time.
+//
+// The output of every mutator is buffered and logged with the artifact name as prefix
+// once the mutators for all preceding artifacts have completed. This keeps the output
+// in the same order as it would be if the mutators ran sequentially.
+func applyConcurrently(ctx context.Context, b *bundle.Bundle, names []string, mutators []bundle.Mutator, locks []*sync.Mutex, parallelism int) diag.Diagnostics {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	results := make([]*concurrentResult, len(mutators))
+	for i := range mutators {
+		r := &concurrentResult{done: make(chan struct{})}
+		results[i] = r
+
+		go func(m bundle.Mutator, lock *sync.Mutex) {
+			defer close(r.done)
+
+			// The lock is acquired first so that waiting for it doesn't take up a slot.
+			lock.Lock()
+			defer lock.Unlock()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			logger := cmdio.NewLogger(flags.ModeAppend)
+			logger.Writer = &r.logs
+			ctx := cmdio.NewContext(ctx, logger)
+			ctx = log.NewContext(ctx, log.GetLogger(ctx).With("mutator", m.Name()))
+
+			// The mutator is applied directly instead of through [bundle.Apply] because
+			// the latter converts the configuration of the whole bundle on entry and exit.
+			r.diags = m.Apply(ctx, b)
+		}(mutators[i], locks[i])
+	}
+
+	var diags diag.Diagnostics
+	for i, r := range results {
+		<-r.done
+		for _, line := range strings.Split(strings.TrimRight(r.logs.String(), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			cmdio.LogString(ctx, fmt.Sprintf("[%s] %s", names[i], line))
+		}
+		diags = diags.Extend(r.diags)
+	}
+
+	return diags
 }
@@ -0,0 +1,178 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testArtifactMutator struct {
+	name  string
+	delay time.Duration
+
+	running *atomic.Int32
+	max     *atomic.Int32
+}
+
+func (m *testArtifactMutator) Name() string {
+	return fmt.Sprintf("test(%s)", m.name)
+}
+
+func (m *testArtifactMutator) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	n := m.running.Add(1)
+	defer m.running.Add(-1)
+	for {
+		max := m.max.Load()
+		if n <= max || m.max.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	cmdio.LogString(ctx, fmt.Sprintf("Building %s...", m.name))
+	time.Sleep(m.delay)
+	cmdio.LogString(ctx, "Done")
+
+	if m.name == "b" {
+		return diag.Errorf("build of %s failed", m.name)
+	}
+	return nil
+}
+
+func TestAllConcurrent(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Deployment: config.Deployment{
+					Parallelism: 2,
+				},
+			},
+			Artifacts: config.Artifacts{
+				"a": {},
+				"b": {},
+				"c": {},
+				"d": {},
+			},
+		},
+	}
+
+	// Artifacts that come first take longest so that they complete last.
+	delays := map[string]time.Duration{
+		"a": 40 * time.Millisecond,
+		"b": 30 * time.Millisecond,
+		"c": 20 * time.Millisecond,
+		"d": 10 * time.Millisecond,
+	}
+
+	var running, max atomic.Int32
+	m := &all{
+		name: "Test",
+		fn: func(name string) (bundle.Mutator, error) {
+			return &testArtifactMutator{name: name, delay: delays[name], running: &running, max: &max}, nil
+		},
+		concurrent: true,
+	}
+
+	var out bytes.Buffer
+	logger := cmdio.NewLogger(flags.ModeAppend)
+	logger.Writer = &out
+	ctx := cmdio.NewContext(context.Background(), logger)
+
+	diags := bundle.Apply(ctx, b, m)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "build of b failed", diags[0].Summary)
+
+	// Output is ordered by artifact name regardless of completion order.
+	assert.Equal(t, `[a] Building a...
+[a] Done
+[b] Building b...
+[b] Done
+[c] Building c...
+[c] Done
+[d] Building d...
+[d] Done
+`, out.String())
+
+	assert.Equal(t, int32(2), max.Load())
+}
+
+func TestAllSequentialWithParallelismOne(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Deployment: config.Deployment{
+					Parallelism: 1,
+				},
+			},
+			Artifacts: config.Artifacts{
+				"a": {},
+				"c": {},
+			},
+		},
+	}
+
+	var running, max atomic.Int32
+	m := &all{
+		name: "Test",
+		fn: func(name string) (bundle.Mutator, error) {
+			return &testArtifactMutator{name: name, running: &running, max: &max}, nil
+		},
+		concurrent: true,
+	}
+
+	var out bytes.Buffer
+	logger := cmdio.NewLogger(flags.ModeAppend)
+	logger.Writer = &out
+	ctx := cmdio.NewContext(context.Background(), logger)
+
+	diags := bundle.Apply(ctx, b, m)
+	require.NoError(t, diags.Error())
+
+	// Output is prefixed in the same way when artifacts are processed one at a time.
+	assert.Equal(t, "[a] Building a...\n[a] Done\n[c] Building c...\n[c] Done\n", out.String())
+	assert.Equal(t, int32(1), max.Load())
+}
+
+func TestAllExclusivePaths(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: "/bundle",
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Deployment: config.Deployment{
+					Parallelism: 4,
+				},
+			},
+			Artifacts: config.Artifacts{
+				// Both default to the bundle root.
+				"a": {},
+				"b": {Path: "/bundle/"},
+			},
+		},
+	}
+
+	var running, max atomic.Int32
+	m := &all{
+		name: "Test",
+		fn: func(name string) (bundle.Mutator, error) {
+			return &testArtifactMutator{name: name, delay: 10 * time.Millisecond, running: &running, max: &max}, nil
+		},
+		concurrent:     true,
+		exclusivePaths: true,
+	}
+
+	ctx := cmdio.NewContext(context.Background(), cmdio.NewLogger(flags.ModeAppend))
+	bundle.Apply(ctx, b, m)
+
+	// Artifacts in the same directory are processed one at a time.
+	assert.Equal(t, int32(1), max.Load())
+}
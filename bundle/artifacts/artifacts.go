@@ -8,10 +8,14 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/artifacts/jar"
 	"github.com/databricks/cli/bundle/artifacts/whl"
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/databricks/cli/bundle/libraries"
 	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/filer"
@@ -22,6 +26,7 @@ type mutatorFactory = func(name string) bundle.Mutator
 
 var buildMutators map[config.ArtifactType]mutatorFactory = map[config.ArtifactType]mutatorFactory{
 	config.ArtifactPythonWheel: whl.Build,
+	config.ArtifactJar:         jar.Build,
 }
 
 var uploadMutators map[config.ArtifactType]mutatorFactory = map[config.ArtifactType]mutatorFactory{}
@@ -102,12 +107,13 @@ func (m *basicUpload) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnost
 		return diag.FromErr(err)
 	}
 
-	client, err := filer.NewWorkspaceFilesClient(b.WorkspaceClient(), uploadPath)
+	client, err := getUploadFiler(b, uploadPath)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = uploadArtifact(ctx, b, artifact, uploadPath, client)
+	previous := loadArtifactStates(ctx, b)[m.name]
+	err = uploadArtifact(ctx, b, artifact, uploadPath, client, previous)
 	if err != nil {
 		return diag.Errorf("upload for %s failed, error: %v", m.name, err)
 	}
@@ -115,56 +121,98 @@ func (m *basicUpload) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnost
 	return nil
 }
 
-func uploadArtifact(ctx context.Context, b *bundle.Bundle, a *config.Artifact, uploadPath string, client filer.Filer) error {
+func uploadArtifact(ctx context.Context, b *bundle.Bundle, a *config.Artifact, uploadPath string, client filer.Filer, previous deploy.ArtifactState) error {
 	for i := range a.Files {
 		f := &a.Files[i]
 
-		filename := filepath.Base(f.Source)
-		cmdio.LogString(ctx, fmt.Sprintf("Uploading %s...", filename))
-
-		err := uploadArtifactFile(ctx, f.Source, client)
+		hash, err := hashFile(f.Source)
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to read %s: %w", f.Source, errors.Unwrap(err))
 		}
 
-		log.Infof(ctx, "Upload succeeded")
-		f.RemotePath = path.Join(uploadPath, filepath.Base(f.Source))
-
-		// TODO: confirm if we still need to update the remote path to start with /Workspace
-		wsfsBase := "/Workspace"
-		remotePath := path.Join(wsfsBase, f.RemotePath)
-
-		for _, job := range b.Config.Resources.Jobs {
-			for i := range job.Tasks {
-				task := &job.Tasks[i]
-				for j := range task.Libraries {
-					lib := &task.Libraries[j]
-					if lib.Whl != "" && isArtifactMatchLibrary(f, lib.Whl, b) {
-						lib.Whl = remotePath
-					}
-					if lib.Jar != "" && isArtifactMatchLibrary(f, lib.Jar, b) {
-						lib.Jar = remotePath
-					}
-				}
+		// Upload the patched copy of the file if there is one.
+		local := f.Source
+		if f.Patched != "" {
+			local = f.Patched
+		}
+
+		filename := filepath.Base(local)
+		f.RemotePath = path.Join(uploadPath, filename)
+		f.Hash = hash
+
+		// Skip the upload if the same contents were uploaded by the previous deployment.
+		if isUploaded(ctx, client, previous, f) {
+			cmdio.LogString(ctx, fmt.Sprintf("Skipping upload of %s (unchanged)", filename))
+		} else {
+			cmdio.LogString(ctx, fmt.Sprintf("Uploading %s...", filename))
+
+			err = uploadArtifactFile(ctx, local, client)
+			if err != nil {
+				return err
 			}
 
-			for i := range job.Environments {
-				env := &job.Environments[i]
-				if env.Spec == nil {
-					continue
+			log.Infof(ctx, "Upload succeeded")
+		}
+
+		rewriteLibraryReferences(b, f, libraryPath(f.RemotePath))
+	}
+
+	return nil
+}
+
+// rewriteMu serializes updates to job configuration by concurrent uploads.
+var rewriteMu sync.Mutex
+
+// rewriteLibraryReferences replaces local references to the artifact file
+// in job tasks and environments with the remote path it was uploaded to.
+func rewriteLibraryReferences(b *bundle.Bundle, f *config.ArtifactFile, remotePath string) {
+	rewriteMu.Lock()
+	defer rewriteMu.Unlock()
+
+	for _, job := range b.Config.Resources.Jobs {
+		for i := range job.Tasks {
+			task := &job.Tasks[i]
+			for j := range task.Libraries {
+				lib := &task.Libraries[j]
+				if lib.Whl != "" && isArtifactMatchLibrary(f, lib.Whl, b) {
+					lib.Whl = remotePath
+				}
+				if lib.Jar != "" && isArtifactMatchLibrary(f, lib.Jar, b) {
+					lib.Jar = remotePath
 				}
+			}
+			if task.SparkJarTask != nil && task.SparkJarTask.JarUri != "" && isArtifactMatchLibrary(f, task.SparkJarTask.JarUri, b) {
+				task.SparkJarTask.JarUri = remotePath
+			}
+		}
+
+		for i := range job.Environments {
+			env := &job.Environments[i]
+			if env.Spec == nil {
+				continue
+			}
 
-				for j := range env.Spec.Dependencies {
-					lib := env.Spec.Dependencies[j]
-					if isArtifactMatchLibrary(f, lib, b) {
-						env.Spec.Dependencies[j] = remotePath
-					}
+			for j := range env.Spec.Dependencies {
+				lib := env.Spec.Dependencies[j]
+				if isArtifactMatchLibrary(f, lib, b) {
+					env.Spec.Dependencies[j] = remotePath
 				}
 			}
 		}
 	}
+}
 
-	return nil
+// isUploaded returns true if the previous deployment uploaded a file with
+// the same contents to the same remote path and that file still exists.
+func isUploaded(ctx context.Context, client filer.Filer, previous deploy.ArtifactState, f *config.ArtifactFile) bool {
+	for _, p := range previous.Files {
+		if p.RemotePath != f.RemotePath || p.Hash != f.Hash {
+			continue
+		}
+		_, err := client.Stat(ctx, path.Base(f.RemotePath))
+		return err == nil
+	}
+	return false
 }
 
 func isArtifactMatchLibrary(f *config.ArtifactFile, libPath string, b *bundle.Bundle) bool {
@@ -203,6 +251,28 @@ func uploadArtifactFile(ctx context.Context, file string, client filer.Filer) er
 	return nil
 }
 
+// libraryPath returns the path that tasks use to refer to a file that was uploaded to the specified path.
+func libraryPath(remotePath string) string {
+	// Files in volumes are referred to by their path as is.
+	if libraries.IsVolumesPath(remotePath) {
+		return remotePath
+	}
+
+	// TODO: confirm if we still need to update the remote path to start with /Workspace
+	wsfsBase := "/Workspace"
+	return path.Join(wsfsBase, remotePath)
+}
+
+// getUploadFiler returns a filer for the upload path.
+// Artifacts are uploaded to Unity Catalog volumes through the Files API
+// and to the workspace through the workspace files API.
+func getUploadFiler(b *bundle.Bundle, uploadPath string) (filer.Filer, error) {
+	if libraries.IsVolumesPath(uploadPath) {
+		return filer.NewFilesClient(b.WorkspaceClient(), uploadPath)
+	}
+	return filer.NewWorkspaceFilesClient(b.WorkspaceClient(), uploadPath)
+}
+
 func getUploadBasePath(b *bundle.Bundle) (string, error) {
 	artifactPath := b.Config.Workspace.ArtifactPath
 	if artifactPath == "" {
@@ -8,6 +8,7 @@ import (
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/deploy"
 	mockfiler "github.com/databricks/cli/internal/mocks/libs/filer"
 	"github.com/databricks/cli/internal/testutil"
 	"github.com/databricks/cli/libs/filer"
@@ -80,7 +81,7 @@ func TestArtifactUpload(t *testing.T) {
 		filer.CreateParentDirectories,
 	).Return(nil)
 
-	err := uploadArtifact(context.Background(), b, artifact, "/foo/bar/artifacts", mockFiler)
+	err := uploadArtifact(context.Background(), b, artifact, "/foo/bar/artifacts", mockFiler, deploy.ArtifactState{})
 	require.NoError(t, err)
 
 	// Test that libraries path is updated
@@ -89,3 +90,184 @@ func TestArtifactUpload(t *testing.T) {
 	require.Equal(t, "/Workspace/foo/bar/artifacts/source.whl", b.Config.Resources.Jobs["job"].JobSettings.Environments[0].Spec.Dependencies[0])
 	require.Equal(t, "/Workspace/Users/foo@bar.com/mywheel.whl", b.Config.Resources.Jobs["job"].JobSettings.Environments[0].Spec.Dependencies[1])
 }
+
+func TestArtifactUploadJar(t *testing.T) {
+	tmpDir := t.TempDir()
+	jarLocalPath := testutil.Touch(t, tmpDir, "target", "app.jar")
+
+	b := &bundle.Bundle{
+		RootPath: tmpDir,
+		Config: config.Root{
+			Workspace: config.Workspace{
+				ArtifactPath: "/foo/bar/artifacts",
+			},
+			Artifacts: config.Artifacts{
+				"jar": {
+					Type: config.ArtifactJar,
+					Files: []config.ArtifactFile{
+						{Source: jarLocalPath},
+					},
+				},
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job": {
+						JobSettings: &jobs.JobSettings{
+							Tasks: []jobs.Task{
+								{
+									SparkJarTask: &jobs.SparkJarTask{
+										JarUri:        filepath.Join("target", "app.jar"),
+										MainClassName: "com.example.Main",
+									},
+									Libraries: []compute.Library{
+										{
+											Jar: filepath.Join("target", "*.jar"),
+										},
+										{
+											Jar: "dbfs:/mnt/other.jar",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	artifact := b.Config.Artifacts["jar"]
+	mockFiler := mockfiler.NewMockFiler(t)
+	mockFiler.EXPECT().Write(
+		mock.Anything,
+		filepath.Join("app.jar"),
+		mock.AnythingOfType("*bytes.Reader"),
+		filer.OverwriteIfExists,
+		filer.CreateParentDirectories,
+	).Return(nil)
+
+	err := uploadArtifact(context.Background(), b, artifact, "/foo/bar/artifacts", mockFiler, deploy.ArtifactState{})
+	require.NoError(t, err)
+
+	// Test that the task and libraries paths are updated
+	task := b.Config.Resources.Jobs["job"].JobSettings.Tasks[0]
+	require.Equal(t, "/Workspace/foo/bar/artifacts/app.jar", task.SparkJarTask.JarUri)
+	require.Equal(t, "/Workspace/foo/bar/artifacts/app.jar", task.Libraries[0].Jar)
+	require.Equal(t, "dbfs:/mnt/other.jar", task.Libraries[1].Jar)
+}
+
+func TestArtifactUploadPatched(t *testing.T) {
+	tmpDir := t.TempDir()
+	whlLocalPath := testutil.Touch(t, tmpDir, "dist", "source-0.1.0-py3-none-any.whl")
+	whlPatchedPath := testutil.Touch(t, tmpDir, "patched", "source-0.1.0+20240101-py3-none-any.whl")
+
+	b := &bundle.Bundle{
+		RootPath: tmpDir,
+		Config: config.Root{
+			Artifacts: config.Artifacts{
+				"whl": {
+					Type:           config.ArtifactPythonWheel,
+					DynamicVersion: true,
+					Files: []config.ArtifactFile{
+						{Source: whlLocalPath, Patched: whlPatchedPath},
+					},
+				},
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job": {
+						JobSettings: &jobs.JobSettings{
+							Tasks: []jobs.Task{
+								{
+									Libraries: []compute.Library{
+										{
+											Whl: filepath.Join("dist", "*.whl"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	artifact := b.Config.Artifacts["whl"]
+	mockFiler := mockfiler.NewMockFiler(t)
+	mockFiler.EXPECT().Write(
+		mock.Anything,
+		"source-0.1.0+20240101-py3-none-any.whl",
+		mock.AnythingOfType("*bytes.Reader"),
+		filer.OverwriteIfExists,
+		filer.CreateParentDirectories,
+	).Return(nil)
+
+	err := uploadArtifact(context.Background(), b, artifact, "/foo/bar/artifacts", mockFiler, deploy.ArtifactState{})
+	require.NoError(t, err)
+
+	// Test that references to the original wheel point to the patched copy
+	require.Equal(t, "/Workspace/foo/bar/artifacts/source-0.1.0+20240101-py3-none-any.whl", b.Config.Resources.Jobs["job"].JobSettings.Tasks[0].Libraries[0].Whl)
+}
+
+func TestArtifactUploadVolume(t *testing.T) {
+	tmpDir := t.TempDir()
+	whlLocalPath := testutil.Touch(t, tmpDir, "whl", "source.whl")
+
+	b := &bundle.Bundle{
+		RootPath: tmpDir,
+		Config: config.Root{
+			Artifacts: config.Artifacts{
+				"whl": {
+					Type: config.ArtifactPythonWheel,
+					Files: []config.ArtifactFile{
+						{Source: whlLocalPath},
+					},
+				},
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job": {
+						JobSettings: &jobs.JobSettings{
+							Tasks: []jobs.Task{
+								{
+									Libraries: []compute.Library{
+										{
+											Whl: filepath.Join("whl", "*.whl"),
+										},
+									},
+								},
+							},
+							Environments: []jobs.JobEnvironment{
+								{
+									Spec: &compute.Environment{
+										Dependencies: []string{
+											filepath.Join("whl", "source.whl"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	artifact := b.Config.Artifacts["whl"]
+	mockFiler := mockfiler.NewMockFiler(t)
+	mockFiler.EXPECT().Write(
+		mock.Anything,
+		"source.whl",
+		mock.AnythingOfType("*bytes.Reader"),
+		filer.OverwriteIfExists,
+		filer.CreateParentDirectories,
+	).Return(nil)
+
+	err := uploadArtifact(context.Background(), b, artifact, "/Volumes/main/default/libraries/.internal", mockFiler, deploy.ArtifactState{})
+	require.NoError(t, err)
+
+	// Test that libraries in volumes are not prefixed with /Workspace
+	require.Equal(t, "/Volumes/main/default/libraries/.internal/source.whl", b.Config.Resources.Jobs["job"].JobSettings.Tasks[0].Libraries[0].Whl)
+	require.Equal(t, "/Volumes/main/default/libraries/.internal/source.whl", b.Config.Resources.Jobs["job"].JobSettings.Environments[0].Spec.Dependencies[0])
+}
@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/artifacts/whl"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
 )
 
 func BuildAll() bundle.Mutator {
 	return &all{
-		name: "Build",
-		fn:   buildArtifactByName,
+		name:           "Build",
+		fn:             buildArtifactByName,
+		concurrent:     true,
+		exclusivePaths: true,
 	}
 }
 
@@ -35,6 +39,21 @@ func (m *build) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 		return diag.Errorf("artifact doesn't exist: %s", m.name)
 	}
 
+	diags := m.build(ctx, b, artifact)
+	if diags.HasError() || !artifact.DynamicVersion {
+		return diags
+	}
+
+	// Patch the version of the built wheels so that clusters install them
+	// again even if the version in the package metadata is unchanged.
+	if artifact.Type != config.ArtifactPythonWheel {
+		return diags.Extend(diag.Errorf("dynamic_version is only supported for artifacts of type %s: %s", config.ArtifactPythonWheel, m.name))
+	}
+
+	return diags.Extend(whl.PatchVersions(ctx, b, m.name, time.Now()))
+}
+
+func (m *build) build(ctx context.Context, b *bundle.Bundle, artifact *config.Artifact) diag.Diagnostics {
 	// Check if source paths are absolute, if not, make them absolute
 	for k := range artifact.Files {
 		f := &artifact.Files[k]
@@ -85,5 +104,17 @@ func (m *build) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 		artifact.Path = filepath.Join(dirPath, artifact.Path)
 	}
 
-	return bundle.Apply(ctx, b, getBuildMutator(artifact.Type, m.name))
+	// Skip the build if its inputs have not changed since the last deployment.
+	if state, ok := loadArtifactStates(ctx, b)[m.name]; ok && buildFromCache(ctx, b, m.name, artifact, state) {
+		return nil
+	}
+
+	// The build mutator is applied directly because artifacts can be built concurrently.
+	diags := getBuildMutator(artifact.Type, m.name).Apply(ctx, b)
+	if diags.HasError() {
+		return diags
+	}
+
+	recordSourceHash(ctx, b, m.name, artifact)
+	return diags
 }
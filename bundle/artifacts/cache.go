@@ -0,0 +1,148 @@
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/git"
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/vfs"
+)
+
+// loadArtifactStates returns the artifact states recorded by the previous deployment.
+// The build cache is best effort, so failures to load the state are logged and ignored.
+func loadArtifactStates(ctx context.Context, b *bundle.Bundle) map[string]deploy.ArtifactState {
+	// The deployment state is stored per target.
+	if b.Config.Bundle.Target == "" {
+		return nil
+	}
+
+	state, err := deploy.LoadState(ctx, b)
+	if err != nil {
+		log.Debugf(ctx, "Unable to load deployment state, not using build cache: %s", err)
+		return nil
+	}
+	return state.Artifacts
+}
+
+// buildFromCache restores the files of the artifact from a previous build
+// if its source hash matches and all files still exist with the same contents.
+// It returns false if the artifact must be built.
+func buildFromCache(ctx context.Context, b *bundle.Bundle, name string, artifact *config.Artifact, state deploy.ArtifactState) bool {
+	hash, err := sourceHash(artifact, outputFiles(b, state))
+	if err != nil {
+		log.Debugf(ctx, "Unable to compute source hash of %s: %s", name, err)
+		return false
+	}
+	if hash != state.SourceHash || len(state.Files) == 0 {
+		return false
+	}
+
+	var files []config.ArtifactFile
+	for _, f := range state.Files {
+		source := filepath.Join(b.RootPath, filepath.FromSlash(f.LocalPath))
+		h, err := hashFile(source)
+		if err != nil || h != f.Hash {
+			return false
+		}
+		files = append(files, config.ArtifactFile{Source: source})
+	}
+
+	cmdio.LogString(ctx, fmt.Sprintf("Skipping build of %s (unchanged)", name))
+	artifact.Files = files
+	artifact.SourceHash = hash
+	return true
+}
+
+// recordSourceHash stores the source hash of an artifact that was just built.
+// The files that the build produced are excluded from the hash
+// so that the next deployment computes the same hash if nothing changed.
+func recordSourceHash(ctx context.Context, b *bundle.Bundle, name string, artifact *config.Artifact) {
+	var files []string
+	for _, f := range artifact.Files {
+		files = append(files, f.Source)
+	}
+
+	hash, err := sourceHash(artifact, files)
+	if err != nil {
+		log.Debugf(ctx, "Unable to compute source hash of %s: %s", name, err)
+		return
+	}
+	artifact.SourceHash = hash
+}
+
+// outputFiles returns the files that a previous build produced.
+func outputFiles(b *bundle.Bundle, state deploy.ArtifactState) []string {
+	var files []string
+	for _, f := range state.Files {
+		files = append(files, filepath.Join(b.RootPath, filepath.FromSlash(f.LocalPath)))
+	}
+	return files
+}
+
+// sourceHash returns a hash of the artifact's type, build command and source files.
+// Source files are the files in the artifact path that are not ignored by .gitignore,
+// excluding the bundle cache directory and the specified output files.
+// Output files are excluded individually, because builds may write them
+// next to the sources, for example in the artifact path itself.
+func sourceHash(artifact *config.Artifact, exclude []string) (string, error) {
+	fs, err := git.NewFileSet(vfs.MustNew(artifact.Path))
+	if err != nil {
+		return "", err
+	}
+
+	files, err := fs.All()
+	if err != nil {
+		return "", err
+	}
+
+	var paths []string
+	for _, f := range files {
+		if strings.HasPrefix(f.Relative, ".databricks/") {
+			continue
+		}
+		abs := filepath.Join(artifact.Path, filepath.FromSlash(f.Relative))
+		if slices.Contains(exclude, abs) {
+			continue
+		}
+		paths = append(paths, f.Relative)
+	}
+	slices.Sort(paths)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "type %s\nbuild %s\n", artifact.Type, artifact.BuildCommand)
+	for _, p := range paths {
+		fh, err := hashFile(filepath.Join(artifact.Path, filepath.FromSlash(p)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s %s\n", fh, p)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
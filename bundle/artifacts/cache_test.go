@@ -0,0 +1,158 @@
+package artifacts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy"
+	mockfiler "github.com/databricks/cli/internal/mocks/libs/filer"
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestSourceHash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".gitignore"), "ignored/\n")
+	writeFile(t, filepath.Join(dir, "src", "main.py"), "print(1)")
+	writeFile(t, filepath.Join(dir, "ignored", "file"), "a")
+	writeFile(t, filepath.Join(dir, "dist", "out.whl"), "a")
+
+	artifact := &config.Artifact{
+		Type:         config.ArtifactPythonWheel,
+		Path:         dir,
+		BuildCommand: "python -m build",
+	}
+
+	hash := func(exclude ...string) string {
+		h, err := sourceHash(artifact, exclude)
+		require.NoError(t, err)
+		return h
+	}
+
+	out := filepath.Join(dir, "dist", "out.whl")
+	base := hash(out)
+
+	// Ignored files and excluded files do not affect the hash.
+	writeFile(t, filepath.Join(dir, "ignored", "file"), "b")
+	writeFile(t, out, "b")
+	assert.Equal(t, base, hash(out))
+	assert.NotEqual(t, base, hash())
+
+	// Source files and the build command do.
+	writeFile(t, filepath.Join(dir, "src", "main.py"), "print(2)")
+	changed := hash(out)
+	assert.NotEqual(t, base, changed)
+
+	artifact.BuildCommand = "python -m build --wheel"
+	assert.NotEqual(t, changed, hash(out))
+}
+
+func TestSourceHashOutputInArtifactPath(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "Main.java"), "class Main {}")
+	writeFile(t, filepath.Join(dir, "app.jar"), "a")
+
+	artifact := &config.Artifact{
+		Type:         config.ArtifactJar,
+		Path:         dir,
+		BuildCommand: "./build.sh",
+	}
+
+	hash := func() string {
+		h, err := sourceHash(artifact, []string{filepath.Join(dir, "app.jar")})
+		require.NoError(t, err)
+		return h
+	}
+
+	// The output doesn't affect the hash, but the sources next to it do.
+	base := hash()
+	writeFile(t, filepath.Join(dir, "app.jar"), "b")
+	assert.Equal(t, base, hash())
+	writeFile(t, filepath.Join(dir, "Main.java"), "class Main { int x; }")
+	assert.NotEqual(t, base, hash())
+}
+
+func TestBuildFromCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "src", "main.py"), "print(1)")
+	writeFile(t, filepath.Join(dir, "dist", "out.whl"), "wheel")
+
+	b := &bundle.Bundle{RootPath: dir}
+	artifact := &config.Artifact{
+		Type:         config.ArtifactPythonWheel,
+		Path:         dir,
+		BuildCommand: "python -m build",
+		Files: []config.ArtifactFile{
+			{Source: filepath.Join(dir, "dist", "out.whl")},
+		},
+	}
+
+	// Record the state as if the artifact was just built and uploaded.
+	recordSourceHash(context.Background(), b, "whl", artifact)
+	fileHash, err := hashFile(filepath.Join(dir, "dist", "out.whl"))
+	require.NoError(t, err)
+	state := deploy.ArtifactState{
+		SourceHash: artifact.SourceHash,
+		Files: []deploy.ArtifactFileState{
+			{LocalPath: "dist/out.whl", Hash: fileHash},
+		},
+	}
+
+	// The build is skipped and the files are restored.
+	artifact = &config.Artifact{
+		Type:         config.ArtifactPythonWheel,
+		Path:         dir,
+		BuildCommand: "python -m build",
+	}
+	require.True(t, buildFromCache(context.Background(), b, "whl", artifact, state))
+	assert.Equal(t, []config.ArtifactFile{{Source: filepath.Join(dir, "dist", "out.whl")}}, artifact.Files)
+	assert.Equal(t, state.SourceHash, artifact.SourceHash)
+
+	// The build is not skipped if the output was modified.
+	artifact.Files = nil
+	writeFile(t, filepath.Join(dir, "dist", "out.whl"), "modified")
+	assert.False(t, buildFromCache(context.Background(), b, "whl", artifact, state))
+
+	// The build is not skipped if a source file was modified.
+	writeFile(t, filepath.Join(dir, "dist", "out.whl"), "wheel")
+	writeFile(t, filepath.Join(dir, "src", "main.py"), "print(2)")
+	assert.False(t, buildFromCache(context.Background(), b, "whl", artifact, state))
+}
+
+func TestArtifactUploadSkipsUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	source := testutil.Touch(t, dir, "source.whl")
+	hash, err := hashFile(source)
+	require.NoError(t, err)
+
+	b := &bundle.Bundle{RootPath: dir}
+	artifact := &config.Artifact{
+		Type:  config.ArtifactPythonWheel,
+		Files: []config.ArtifactFile{{Source: source}},
+	}
+
+	mockFiler := mockfiler.NewMockFiler(t)
+	mockFiler.EXPECT().Stat(mock.Anything, "source.whl").Return(nil, nil)
+
+	previous := deploy.ArtifactState{
+		Files: []deploy.ArtifactFileState{
+			{LocalPath: "source.whl", RemotePath: "/foo/bar/artifacts/source.whl", Hash: hash},
+		},
+	}
+	err = uploadArtifact(context.Background(), b, artifact, "/foo/bar/artifacts", mockFiler, previous)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/foo/bar/artifacts/source.whl", artifact.Files[0].RemotePath)
+	assert.Equal(t, hash, artifact.Files[0].Hash)
+}
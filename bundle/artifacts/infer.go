@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/artifacts/jar"
 	"github.com/databricks/cli/bundle/artifacts/whl"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
@@ -12,6 +13,7 @@ import (
 
 var inferMutators map[config.ArtifactType]mutatorFactory = map[config.ArtifactType]mutatorFactory{
 	config.ArtifactPythonWheel: whl.InferBuildCommand,
+	config.ArtifactJar:         jar.InferBuildCommand,
 }
 
 func getInferMutator(t config.ArtifactType, name string) bundle.Mutator {
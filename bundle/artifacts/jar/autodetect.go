@@ -0,0 +1,58 @@
+package jar
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/libraries"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/log"
+)
+
+type detectPkg struct {
+}
+
+func DetectPackage() bundle.Mutator {
+	return &detectPkg{}
+}
+
+func (m *detectPkg) Name() string {
+	return "artifacts.jar.AutoDetect"
+}
+
+func (m *detectPkg) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	jarTasks := libraries.FindAllJarTasksWithLocalLibraries(b)
+	if len(jarTasks) == 0 {
+		log.Infof(ctx, "No local JAR tasks in databricks.yml config, skipping auto detect")
+		return nil
+	}
+	log.Infof(ctx, "Detecting JAR project...")
+
+	// checking if there is a build.sbt, pom.xml or build.gradle in the bundle root
+	tool, ok := detectBuildTool(b.RootPath)
+	if !ok {
+		log.Infof(ctx, "No JAR project found at bundle root folder")
+		return nil
+	}
+
+	log.Infof(ctx, fmt.Sprintf("Found JAR project at %s", b.RootPath))
+	name := tool.projectName(b.RootPath)
+
+	if b.Config.Artifacts == nil {
+		b.Config.Artifacts = make(map[string]*config.Artifact)
+	}
+
+	pkgPath, err := filepath.Abs(b.RootPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	b.Config.Artifacts[name] = &config.Artifact{
+		Path: pkgPath,
+		Type: config.ArtifactJar,
+	}
+
+	return nil
+}
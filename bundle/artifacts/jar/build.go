@@ -0,0 +1,144 @@
+package jar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/log"
+)
+
+type build struct {
+	name string
+}
+
+func Build(name string) bundle.Mutator {
+	return &build{
+		name: name,
+	}
+}
+
+func (m *build) Name() string {
+	return fmt.Sprintf("artifacts.jar.Build(%s)", m.name)
+}
+
+func (m *build) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	artifact, ok := b.Config.Artifacts[m.name]
+	if !ok {
+		return diag.Errorf("artifact doesn't exist: %s", m.name)
+	}
+
+	cmdio.LogString(ctx, fmt.Sprintf("Building %s...", m.name))
+
+	dir := artifact.Path
+	tool, detected := detectBuildTool(dir)
+
+	// Remove JARs from previous builds so that only the current build is uploaded.
+	// Without a known build tool, JARs in the project may have been placed there by hand,
+	// so only the files that were recorded as the output of the previous deployment are removed.
+	var stale []string
+	if detected {
+		stale = tool.findOutputs(dir)
+	} else {
+		stale = previousOutputs(ctx, b, m.name)
+	}
+	for _, jar := range stale {
+		err := os.Remove(jar)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return diag.Errorf("unable to remove JAR from a previous build of %s: %v", m.name, err)
+		}
+	}
+
+	// Without a known build tool, the output is a JAR in one of the known output locations
+	// that didn't exist or was modified by the build.
+	before := make(map[string]os.FileInfo)
+	if !detected {
+		for _, jar := range findAllOutputs(dir) {
+			if info, err := os.Stat(jar); err == nil {
+				before[jar] = info
+			}
+		}
+	}
+
+	out, err := artifact.Build(ctx)
+	if err != nil {
+		return diag.Errorf("build failed %s, error: %v, output: %s", m.name, err, out)
+	}
+	log.Infof(ctx, "Build succeeded")
+
+	var jars []string
+	if detected {
+		jars = tool.findOutputs(dir)
+	} else {
+		for _, jar := range findAllOutputs(dir) {
+			prev, ok := before[jar]
+			info, err := os.Stat(jar)
+			if err != nil {
+				continue
+			}
+			if ok && prev.ModTime().Equal(info.ModTime()) && prev.Size() == info.Size() {
+				continue
+			}
+			jars = append(jars, jar)
+		}
+	}
+	if len(jars) == 0 {
+		return diag.Errorf("cannot find built JAR in %s for package %s", dir, m.name)
+	}
+
+	// A build is expected to produce a single JAR with all dependencies included.
+	if len(jars) > 1 {
+		return diag.Errorf("build of %s produced multiple JARs, expected a single JAR with all dependencies: %s", m.name, strings.Join(jars, ", "))
+	}
+
+	artifact.Files = append(artifact.Files, config.ArtifactFile{
+		Source: jars[0],
+	})
+
+	return nil
+}
+
+// findAllOutputs returns the JAR files in the output locations of all known build tools.
+func findAllOutputs(dir string) []string {
+	var out []string
+	for _, tool := range buildTools {
+		for _, jar := range tool.findOutputs(dir) {
+			if !slices.Contains(out, jar) {
+				out = append(out, jar)
+			}
+		}
+	}
+	return out
+}
+
+// previousOutputs returns the paths of the files that the previous deployment
+// recorded as the output of the artifact. Failures to load the deployment state
+// are logged and ignored, because removing these files is best effort.
+func previousOutputs(ctx context.Context, b *bundle.Bundle, name string) []string {
+	// The deployment state is stored per target.
+	if b.Config.Bundle.Target == "" {
+		return nil
+	}
+
+	state, err := deploy.LoadState(ctx, b)
+	if err != nil {
+		log.Debugf(ctx, "Unable to load deployment state, not removing previous build of %s: %s", name, err)
+		return nil
+	}
+
+	var out []string
+	for _, f := range state.Artifacts[name].Files {
+		out = append(out, filepath.Join(b.RootPath, filepath.FromSlash(f.LocalPath)))
+	}
+	return out
+}
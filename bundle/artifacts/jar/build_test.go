@@ -0,0 +1,97 @@
+package jar
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMultipleJars(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("build command uses a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "build.sbt"), []byte(`name := "app"`), 0644))
+
+	b := &bundle.Bundle{
+		RootPath: dir,
+		Config: config.Root{
+			Artifacts: config.Artifacts{
+				"app": &config.Artifact{
+					Type:         config.ArtifactJar,
+					Path:         dir,
+					BuildCommand: "mkdir -p target && touch target/a.jar target/b.jar",
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, Build("app"))
+	assert.ErrorContains(t, diags.Error(), "build of app produced multiple JARs")
+}
+
+func TestBuildWithoutBuildToolKeepsOtherJars(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("build command uses a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	vendored := filepath.Join(dir, "target", "vendored.jar")
+	require.NoError(t, os.MkdirAll(filepath.Dir(vendored), 0755))
+	require.NoError(t, os.WriteFile(vendored, []byte("vendored"), 0644))
+
+	// The previous deployment recorded the output of the previous build.
+	previous := filepath.Join(dir, "build", "libs", "old.jar")
+	require.NoError(t, os.MkdirAll(filepath.Dir(previous), 0755))
+	require.NoError(t, os.WriteFile(previous, []byte("old"), 0644))
+
+	b := &bundle.Bundle{
+		RootPath: dir,
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Target: "dev",
+			},
+			Artifacts: config.Artifacts{
+				"app": &config.Artifact{
+					Type:         config.ArtifactJar,
+					Path:         dir,
+					BuildCommand: "mkdir -p build/libs && echo new > build/libs/app.jar",
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	cacheDir, err := b.CacheDir(ctx)
+	require.NoError(t, err)
+	state, err := json.Marshal(deploy.DeploymentState{
+		Version: deploy.DeploymentStateVersion,
+		Artifacts: map[string]deploy.ArtifactState{
+			"app": {Files: []deploy.ArtifactFileState{{LocalPath: "build/libs/old.jar"}}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, deploy.DeploymentStateFileName), state, 0644))
+
+	diags := bundle.Apply(ctx, b, Build("app"))
+	require.NoError(t, diags.Error())
+
+	// Only the file recorded by the previous deployment is removed.
+	assert.FileExists(t, vendored)
+	assert.NoFileExists(t, previous)
+
+	// Only the JAR written by the build is its output.
+	files := b.Config.Artifacts["app"].Files
+	require.Len(t, files, 1)
+	assert.Equal(t, filepath.Join(dir, "build", "libs", "app.jar"), files[0].Source)
+}
@@ -0,0 +1,68 @@
+package jar
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/libraries"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/log"
+)
+
+type fromLibraries struct{}
+
+func DefineArtifactsFromLibraries() bundle.Mutator {
+	return &fromLibraries{}
+}
+
+func (m *fromLibraries) Name() string {
+	return "artifacts.jar.DefineArtifactsFromLibraries"
+}
+
+func (*fromLibraries) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	for _, artifact := range b.Config.Artifacts {
+		if artifact.Type == config.ArtifactJar {
+			log.Debugf(ctx, "Skipping defining JAR artifacts from libraries because a JAR artifact is already defined")
+			return nil
+		}
+	}
+
+	tasks := libraries.FindAllJarTasksWithLocalLibraries(b)
+	for _, task := range tasks {
+		for _, lib := range task.Libraries {
+			if lib.Jar != "" && libraries.IsLocalPath(lib.Jar) {
+				matchAndAdd(ctx, lib.Jar, b)
+			}
+		}
+		if libraries.IsLocalPathJarUri(task.SparkJarTask.JarUri) {
+			matchAndAdd(ctx, task.SparkJarTask.JarUri, b)
+		}
+	}
+
+	return nil
+}
+
+func matchAndAdd(ctx context.Context, lib string, b *bundle.Bundle) {
+	matches, err := filepath.Glob(filepath.Join(b.RootPath, lib))
+	// File referenced from libraries section does not exists, skipping
+	if err != nil {
+		return
+	}
+
+	for _, match := range matches {
+		name := filepath.Base(match)
+		if b.Config.Artifacts == nil {
+			b.Config.Artifacts = make(map[string]*config.Artifact)
+		}
+
+		log.Debugf(ctx, "Adding an artifact block for %s", match)
+		b.Config.Artifacts[name] = &config.Artifact{
+			Files: []config.ArtifactFile{
+				{Source: match},
+			},
+			Type: config.ArtifactJar,
+		}
+	}
+}
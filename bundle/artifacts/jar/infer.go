@@ -0,0 +1,40 @@
+package jar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/libs/diag"
+)
+
+type infer struct {
+	name string
+}
+
+func (m *infer) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	artifact := b.Config.Artifacts[m.name]
+
+	dir := artifact.Path
+	if dir == "" {
+		dir = b.RootPath
+	}
+
+	tool, ok := detectBuildTool(dir)
+	if !ok {
+		return diag.Errorf("unable to infer build command for %s: no build.sbt, pom.xml or build.gradle found in %s", m.name, dir)
+	}
+
+	artifact.BuildCommand = tool.buildCommand(dir)
+	return nil
+}
+
+func (m *infer) Name() string {
+	return fmt.Sprintf("artifacts.jar.Infer(%s)", m.name)
+}
+
+func InferBuildCommand(name string) bundle.Mutator {
+	return &infer{
+		name: name,
+	}
+}
@@ -0,0 +1,157 @@
+package jar
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// buildTool describes how a JAR is built by one of the supported build tools.
+type buildTool struct {
+	// Name of the file that identifies a project built with this tool.
+	file string
+
+	// Command that builds a JAR with all dependencies included.
+	//
+	// The command relies on a plugin that is configured in the project:
+	// sbt-assembly for sbt, the Shadow plugin for Gradle, and the shade or
+	// assembly plugin bound to the package phase for Maven.
+	command string
+
+	// Glob patterns (relative to the project root) that match the built JAR files.
+	outputs []string
+
+	// Function to extract the project name from the contents of the project file.
+	name func(raw []byte) (string, bool)
+}
+
+var buildTools = []buildTool{
+	{
+		file:    "build.sbt",
+		command: "sbt assembly",
+		outputs: []string{"target/scala-*/*.jar", "target/*.jar"},
+		name:    matchName(regexp.MustCompile(`(?m)^\s*name\s*:=\s*"([^"]+)"`)),
+	},
+	{
+		file:    "pom.xml",
+		command: "mvn package -DskipTests",
+		outputs: []string{"target/*.jar"},
+		name:    pomName,
+	},
+	{
+		file:    "build.gradle",
+		command: "gradle shadowJar -x test",
+		outputs: []string{"build/libs/*.jar"},
+		name:    matchName(regexp.MustCompile(`(?m)^\s*rootProject\.name\s*=\s*['"]([^'"]+)['"]`)),
+	},
+	{
+		file:    "build.gradle.kts",
+		command: "gradle shadowJar -x test",
+		outputs: []string{"build/libs/*.jar"},
+		name:    matchName(regexp.MustCompile(`(?m)^\s*rootProject\.name\s*=\s*"([^"]+)"`)),
+	},
+}
+
+// matchName returns a function that extracts the project name with a regular expression.
+func matchName(re *regexp.Regexp) func([]byte) (string, bool) {
+	return func(raw []byte) (string, bool) {
+		matches := re.FindSubmatch(raw)
+		if len(matches) == 0 {
+			return "", false
+		}
+		return string(matches[1]), true
+	}
+}
+
+// pomName returns the artifact ID of the project in a POM.
+// Only the top-level element is used; the artifact IDs of the parent
+// project and of dependencies are nested in other elements.
+func pomName(raw []byte) (string, bool) {
+	var pom struct {
+		ArtifactId string `xml:"artifactId"`
+	}
+	err := xml.Unmarshal(raw, &pom)
+	if err != nil || pom.ArtifactId == "" {
+		return "", false
+	}
+	return strings.TrimSpace(pom.ArtifactId), true
+}
+
+// detectBuildTool returns the build tool for the project at the specified path.
+func detectBuildTool(dir string) (buildTool, bool) {
+	for _, tool := range buildTools {
+		_, err := os.Stat(filepath.Join(dir, tool.file))
+		if err == nil {
+			return tool, true
+		}
+	}
+	return buildTool{}, false
+}
+
+// buildCommand returns the command to build the project.
+// Wrapper scripts that are checked into the project take precedence over
+// globally installed tools so that the project's version of the tool is used.
+func (t buildTool) buildCommand(dir string) string {
+	switch t.file {
+	case "pom.xml":
+		if _, err := os.Stat(filepath.Join(dir, "mvnw")); err == nil {
+			return "./mvnw package -DskipTests"
+		}
+	case "build.gradle", "build.gradle.kts":
+		if _, err := os.Stat(filepath.Join(dir, "gradlew")); err == nil {
+			return "./gradlew shadowJar -x test"
+		}
+	}
+	return t.command
+}
+
+// projectName returns the name of the project at the specified path.
+// Gradle keeps the project name in settings.gradle, so both files are checked.
+func (t buildTool) projectName(dir string) string {
+	for _, file := range []string{t.file, "settings.gradle", "settings.gradle.kts"} {
+		raw, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		if name, ok := t.name(raw); ok {
+			return name
+		}
+	}
+	return filepath.Base(dir)
+}
+
+// findOutputs returns the JAR files produced by a build of the project.
+// Source, Javadoc and test JARs as well as JARs that were replaced
+// by a shaded JAR are not included. If the build produced a JAR with
+// dependencies next to the JAR without them, only the former is included.
+func (t buildTool) findOutputs(dir string) []string {
+	var out []string
+	var fat []string
+	for _, pattern := range t.outputs {
+		matches, _ := filepath.Glob(filepath.Join(dir, filepath.FromSlash(pattern)))
+		for _, match := range matches {
+			if isAuxiliaryJar(filepath.Base(match)) {
+				continue
+			}
+			if fatJar.MatchString(filepath.Base(match)) {
+				fat = append(fat, match)
+			}
+			out = append(out, match)
+		}
+	}
+	if len(fat) > 0 {
+		return fat
+	}
+	return out
+}
+
+var auxiliaryJar = regexp.MustCompile(`(-sources|-javadoc|-tests|-plain)\.jar$|^original-`)
+
+// Suffixes of JARs with dependencies produced by the Maven assembly plugin and the Gradle Shadow plugin.
+var fatJar = regexp.MustCompile(`(-jar-with-dependencies|-all)\.jar$`)
+
+func isAuxiliaryJar(name string) bool {
+	return auxiliaryJar.MatchString(name)
+}
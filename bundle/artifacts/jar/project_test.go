@@ -0,0 +1,73 @@
+package jar
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectBuildTool(t *testing.T) {
+	for _, tc := range []struct {
+		dir     string
+		name    string
+		command string
+	}{
+		{"./testdata/sbt", "my-sbt-app", "sbt assembly"},
+		{"./testdata/maven", "my-maven-app", "./mvnw package -DskipTests"},
+		{"./testdata/gradle", "my-gradle-app", "gradle shadowJar -x test"},
+	} {
+		t.Run(tc.dir, func(t *testing.T) {
+			tool, ok := detectBuildTool(tc.dir)
+			require.True(t, ok)
+			assert.Equal(t, tc.name, tool.projectName(tc.dir))
+			assert.Equal(t, tc.command, tool.buildCommand(tc.dir))
+		})
+	}
+}
+
+func TestDetectBuildToolNotFound(t *testing.T) {
+	_, ok := detectBuildTool(t.TempDir())
+	assert.False(t, ok)
+}
+
+func TestFindOutputs(t *testing.T) {
+	dir := t.TempDir()
+	testutil.Touch(t, filepath.Join(dir, "target"), "app-0.1.0.jar")
+	testutil.Touch(t, filepath.Join(dir, "target"), "original-app-0.1.0.jar")
+	testutil.Touch(t, filepath.Join(dir, "target"), "app-0.1.0-sources.jar")
+	testutil.Touch(t, filepath.Join(dir, "target"), "app-0.1.0-javadoc.jar")
+
+	tool, ok := detectBuildTool("./testdata/maven")
+	require.True(t, ok)
+	assert.Equal(t, []string{filepath.Join(dir, "target", "app-0.1.0.jar")}, tool.findOutputs(dir))
+}
+
+func TestFindOutputsPrefersJarWithDependencies(t *testing.T) {
+	dir := t.TempDir()
+	testutil.Touch(t, filepath.Join(dir, "target"), "app-0.1.0.jar")
+	testutil.Touch(t, filepath.Join(dir, "target"), "app-0.1.0-jar-with-dependencies.jar")
+
+	tool, ok := detectBuildTool("./testdata/maven")
+	require.True(t, ok)
+	assert.Equal(t, []string{filepath.Join(dir, "target", "app-0.1.0-jar-with-dependencies.jar")}, tool.findOutputs(dir))
+}
+
+func TestPomNameSkipsParent(t *testing.T) {
+	name, ok := pomName([]byte(`<project>
+<parent>
+<groupId>com.example</groupId>
+<artifactId>parent-app</artifactId>
+</parent>
+<artifactId>child-app</artifactId>
+<dependencies>
+<dependency>
+<artifactId>dep</artifactId>
+</dependency>
+</dependencies>
+</project>`))
+	require.True(t, ok)
+	assert.Equal(t, "child-app", name)
+}
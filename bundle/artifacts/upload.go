@@ -2,17 +2,23 @@ package artifacts
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/libraries"
 	"github.com/databricks/cli/libs/diag"
-	"github.com/databricks/databricks-sdk-go/service/workspace"
+	"github.com/databricks/cli/libs/filer"
 )
 
 func UploadAll() bundle.Mutator {
 	return &all{
-		name: "Upload",
-		fn:   uploadArtifactByName,
+		name:       "Upload",
+		fn:         uploadArtifactByName,
+		concurrent: true,
 	}
 }
 
@@ -42,7 +48,8 @@ func (m *upload) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 		return diag.Errorf("artifact source is not configured: %s", m.name)
 	}
 
-	return bundle.Apply(ctx, b, getUploadMutator(artifact.Type, m.name))
+	// The upload mutator is applied directly because artifacts can be uploaded concurrently.
+	return getUploadMutator(artifact.Type, m.name).Apply(ctx, b)
 }
 
 type cleanUp struct{}
@@ -57,15 +64,56 @@ func (m *cleanUp) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics
 		return diag.FromErr(err)
 	}
 
-	b.WorkspaceClient().Workspace.Delete(ctx, workspace.Delete{
-		Path:      uploadPath,
-		Recursive: true,
-	})
+	// Volumes may not exist for bundles that don't define artifacts.
+	if libraries.IsVolumesPath(uploadPath) && len(b.Config.Artifacts) == 0 {
+		return nil
+	}
+
+	client, err := getUploadFiler(b, uploadPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Files that are part of the current set of artifacts are retained
+	// so that unchanged artifacts don't need to be uploaded again.
+	keep := make(map[string]bool)
+	for _, artifact := range b.Config.Artifacts {
+		for _, f := range artifact.Files {
+			local := f.Source
+			if f.Patched != "" {
+				local = f.Patched
+			}
+			keep[filepath.Base(local)] = true
+		}
+	}
+
+	// Stale artifacts that cannot be removed are reported as warnings
+	// because they don't affect the current deployment.
+	var diags diag.Diagnostics
+	entries, err := client.ReadDir(ctx, ".")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		diags = diags.Append(diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("unable to list %s to remove stale artifacts: %v", uploadPath, err),
+		})
+	}
+	for _, entry := range entries {
+		if keep[entry.Name()] {
+			continue
+		}
+		err = client.Delete(ctx, entry.Name(), filer.DeleteRecursively)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			diags = diags.Append(diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("unable to remove stale artifact %s: %v", path.Join(uploadPath, entry.Name()), err),
+			})
+		}
+	}
 
-	err = b.WorkspaceClient().Workspace.MkdirsByPath(ctx, uploadPath)
+	err = client.Mkdir(ctx, ".")
 	if err != nil {
-		return diag.Errorf("unable to create directory for %s: %v", uploadPath, err)
+		return diags.Extend(diag.Errorf("unable to create directory for %s: %v", uploadPath, err))
 	}
 
-	return nil
+	return diags
 }
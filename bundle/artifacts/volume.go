@@ -0,0 +1,101 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/libraries"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+)
+
+type validateArtifactPath struct{}
+
+// ValidateArtifactPath checks that artifacts can be uploaded to the artifact path
+// if it refers to a Unity Catalog volume. The volume must exist and the deploying
+// identity must be allowed to write to it.
+func ValidateArtifactPath() bundle.Mutator {
+	return &validateArtifactPath{}
+}
+
+func (m *validateArtifactPath) Name() string {
+	return "artifacts.ValidateArtifactPath"
+}
+
+func (m *validateArtifactPath) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	artifactPath := b.Config.Workspace.ArtifactPath
+	if !libraries.IsVolumesPath(artifactPath) || len(b.Config.Artifacts) == 0 {
+		return nil
+	}
+
+	p := dyn.MustPathFromString("workspace.artifact_path")
+	newDiag := func(severity diag.Severity, summary, detail string) diag.Diagnostics {
+		return diag.Diagnostics{{
+			Severity: severity,
+			Summary:  summary,
+			Detail:   detail,
+			Location: b.Config.GetLocation(p.String()),
+			Path:     p,
+		}}
+	}
+
+	name, err := libraries.VolumeFullName(artifactPath)
+	if err != nil {
+		return newDiag(diag.Error, fmt.Sprintf("invalid artifact_path: %v", err), "")
+	}
+
+	w := b.WorkspaceClient()
+	volume, err := w.Volumes.ReadByName(ctx, name)
+	if errors.Is(err, apierr.ErrNotFound) {
+		return newDiag(diag.Error,
+			fmt.Sprintf("volume %s does not exist", name),
+			fmt.Sprintf("Create the volume before deploying or change artifact_path. Artifacts are uploaded to %s.", artifactPath),
+		)
+	}
+	if errors.Is(err, apierr.ErrPermissionDenied) {
+		return newDiag(diag.Error,
+			fmt.Sprintf("cannot access volume %s: %v", name, err),
+			"The deploying identity needs the USE CATALOG, USE SCHEMA and WRITE VOLUME privileges to upload artifacts to the volume.",
+		)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	principal := ""
+	if b.Config.Workspace.CurrentUser != nil {
+		principal = b.Config.Workspace.CurrentUser.UserName
+	}
+	if principal == "" || volume.Owner == principal {
+		return nil
+	}
+
+	permissions, err := w.Grants.GetEffective(ctx, catalog.GetEffectiveRequest{
+		SecurableType: catalog.SecurableTypeVolume,
+		FullName:      name,
+		Principal:     principal,
+	})
+	if err != nil {
+		// Effective permissions are not visible to every user who can write to a volume.
+		return nil
+	}
+
+	for _, assignment := range permissions.PrivilegeAssignments {
+		for _, privilege := range assignment.Privileges {
+			if privilege.Privilege == catalog.PrivilegeWriteVolume || privilege.Privilege == catalog.PrivilegeAllPrivileges {
+				return nil
+			}
+		}
+	}
+
+	// Privileges granted to groups that the principal is a member of are not included
+	// in its effective permissions, so the upload may still succeed.
+	return newDiag(diag.Warning,
+		fmt.Sprintf("%s may not be able to write to volume %s", principal, name),
+		"The WRITE VOLUME privilege on the volume is required to upload artifacts. It is not granted to the deploying identity directly; the deployment fails if it isn't granted to one of its groups either.",
+	)
+}
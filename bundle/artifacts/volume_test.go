@@ -0,0 +1,126 @@
+package artifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func volumeBundle(artifactPath string) *bundle.Bundle {
+	return &bundle.Bundle{
+		Config: config.Root{
+			Workspace: config.Workspace{
+				ArtifactPath: artifactPath,
+				CurrentUser: &config.User{
+					User: &iam.User{
+						UserName: "jane@doe.com",
+					},
+				},
+			},
+			Artifacts: config.Artifacts{
+				"whl": {
+					Type: config.ArtifactPythonWheel,
+				},
+			},
+		},
+	}
+}
+
+func TestValidateArtifactPathWorkspace(t *testing.T) {
+	b := volumeBundle("/Workspace/Users/jane@doe.com/artifacts")
+	diags := bundle.Apply(context.Background(), b, ValidateArtifactPath())
+	assert.Empty(t, diags)
+}
+
+func TestValidateArtifactPathInvalid(t *testing.T) {
+	b := volumeBundle("/Volumes/main/default")
+	diags := bundle.Apply(context.Background(), b, ValidateArtifactPath())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Contains(t, diags[0].Summary, "invalid artifact_path")
+	assert.Equal(t, "workspace.artifact_path", diags[0].Path.String())
+}
+
+func TestValidateArtifactPathVolumeNotFound(t *testing.T) {
+	b := volumeBundle("/Volumes/main/default/libraries/artifacts")
+	m := mocks.NewMockWorkspaceClient(t)
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	m.GetMockVolumesAPI().EXPECT().ReadByName(mock.Anything, "main.default.libraries").Return(nil, apierr.ErrResourceDoesNotExist)
+
+	diags := bundle.Apply(context.Background(), b, ValidateArtifactPath())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "volume main.default.libraries does not exist", diags[0].Summary)
+}
+
+func TestValidateArtifactPathOwner(t *testing.T) {
+	b := volumeBundle("/Volumes/main/default/libraries/artifacts")
+	m := mocks.NewMockWorkspaceClient(t)
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	m.GetMockVolumesAPI().EXPECT().ReadByName(mock.Anything, "main.default.libraries").Return(&catalog.VolumeInfo{
+		Owner: "jane@doe.com",
+	}, nil)
+
+	diags := bundle.Apply(context.Background(), b, ValidateArtifactPath())
+	assert.Empty(t, diags)
+}
+
+func TestValidateArtifactPathPrivileges(t *testing.T) {
+	for _, tc := range []struct {
+		privileges []catalog.EffectivePrivilege
+		warning    bool
+	}{
+		{
+			privileges: []catalog.EffectivePrivilege{
+				{Privilege: catalog.PrivilegeReadVolume},
+				{Privilege: catalog.PrivilegeWriteVolume, InheritedFromName: "main.default", InheritedFromType: catalog.SecurableTypeSchema},
+			},
+		},
+		{
+			privileges: []catalog.EffectivePrivilege{
+				{Privilege: catalog.PrivilegeAllPrivileges},
+			},
+		},
+		{
+			privileges: []catalog.EffectivePrivilege{
+				{Privilege: catalog.PrivilegeReadVolume},
+			},
+			warning: true,
+		},
+	} {
+		b := volumeBundle("/Volumes/main/default/libraries/artifacts")
+		m := mocks.NewMockWorkspaceClient(t)
+		b.SetWorkpaceClient(m.WorkspaceClient)
+		m.GetMockVolumesAPI().EXPECT().ReadByName(mock.Anything, "main.default.libraries").Return(&catalog.VolumeInfo{
+			Owner: "john@doe.com",
+		}, nil)
+		m.GetMockGrantsAPI().EXPECT().GetEffective(mock.Anything, catalog.GetEffectiveRequest{
+			SecurableType: catalog.SecurableTypeVolume,
+			FullName:      "main.default.libraries",
+			Principal:     "jane@doe.com",
+		}).Return(&catalog.EffectivePermissionsList{
+			PrivilegeAssignments: []catalog.EffectivePrivilegeAssignment{
+				{Principal: "jane@doe.com", Privileges: tc.privileges},
+			},
+		}, nil)
+
+		diags := bundle.Apply(context.Background(), b, ValidateArtifactPath())
+		if !tc.warning {
+			assert.Empty(t, diags)
+			continue
+		}
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "jane@doe.com may not be able to write to volume main.default.libraries", diags[0].Summary)
+	}
+}
@@ -34,16 +34,14 @@ func (m *detectPkg) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostic
 	}
 	log.Infof(ctx, "Detecting Python wheel project...")
 
-	// checking if there is setup.py in the bundle root
-	setupPy := filepath.Join(b.RootPath, "setup.py")
-	_, err := os.Stat(setupPy)
-	if err != nil {
+	// checking if there is pyproject.toml or setup.py in the bundle root
+	module, ok := detectModuleName(b.RootPath)
+	if !ok {
 		log.Infof(ctx, "No Python wheel project found at bundle root folder")
 		return nil
 	}
 
 	log.Infof(ctx, fmt.Sprintf("Found Python wheel project at %s", b.RootPath))
-	module := extractModuleName(setupPy)
 
 	if b.Config.Artifacts == nil {
 		b.Config.Artifacts = make(map[string]*config.Artifact)
@@ -61,6 +59,27 @@ func (m *detectPkg) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostic
 	return nil
 }
 
+// detectModuleName returns the name of the Python project at the specified path.
+// The name in pyproject.toml takes precedence over the name in setup.py.
+func detectModuleName(dir string) (string, bool) {
+	project, err := readPyproject(filepath.Join(dir, "pyproject.toml"))
+	if err == nil && project.Name != "" {
+		return project.Name, true
+	}
+
+	setupPy := filepath.Join(dir, "setup.py")
+	_, serr := os.Stat(setupPy)
+	if serr == nil {
+		return extractModuleName(setupPy), true
+	}
+
+	// A pyproject.toml without a name still describes a Python project.
+	if err == nil {
+		return randomName(), true
+	}
+	return "", false
+}
+
 func extractModuleName(setupPy string) string {
 	bytes, err := os.ReadFile(setupPy)
 	if err != nil {
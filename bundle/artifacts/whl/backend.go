@@ -0,0 +1,236 @@
+package whl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/process"
+	"github.com/databricks/cli/libs/python"
+	"golang.org/x/mod/semver"
+)
+
+// backend describes how wheels are built for projects that use a particular build backend.
+type backend struct {
+	// Name of the build backend for use in messages.
+	name string
+
+	// Executable that builds the wheel. If empty, the wheel is built with the Python interpreter.
+	tool string
+
+	// Arguments to pass to the tool or the Python interpreter to build a wheel into the dist folder.
+	args string
+
+	// Where to find installation instructions if the tool is missing.
+	docs string
+}
+
+var (
+	backendUv = backend{
+		name: "uv",
+		tool: "uv",
+		args: "build --wheel",
+		docs: "https://docs.astral.sh/uv/getting-started/installation/",
+	}
+	backendPoetry = backend{
+		name: "poetry-core",
+		tool: "poetry",
+		args: "build -f wheel",
+		docs: "https://python-poetry.org/docs/#installation",
+	}
+	backendHatch = backend{
+		name: "hatchling",
+		tool: "hatch",
+		args: "build -t wheel",
+		docs: "https://hatch.pypa.io/latest/install/",
+	}
+	// Projects that use setuptools or any other PEP 517 build backend
+	// are built with the standard build frontend.
+	backendBuild = backend{
+		name: "setuptools",
+		args: "-m build --wheel",
+		docs: "https://build.pypa.io/en/stable/installation.html",
+	}
+	// Projects without a pyproject.toml are built with their setup.py.
+	backendSetupPy = backend{
+		name: "setuptools",
+		args: "setup.py bdist_wheel",
+	}
+)
+
+// detectBackend returns the backend for the Python project at the specified path.
+func detectBackend(dir string) (backend, error) {
+	project, err := readPyproject(filepath.Join(dir, "pyproject.toml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return backendSetupPy, nil
+	}
+	if err != nil {
+		return backend{}, err
+	}
+
+	// Projects managed by uv can be built by uv regardless of their build backend.
+	_, err = os.Stat(filepath.Join(dir, "uv.lock"))
+	if project.BuildBackend == "uv_build" || project.HasToolUv || err == nil {
+		return backendUv, nil
+	}
+
+	switch {
+	case strings.HasPrefix(project.BuildBackend, "poetry.core."), strings.HasPrefix(project.BuildBackend, "poetry.masonry."):
+		return backendPoetry, nil
+	case project.BuildBackend == "hatchling.build":
+		return backendHatch, nil
+	}
+
+	// Keep building projects that include a setup.py the way they were built before
+	// so that they don't require the build package to be installed.
+	_, err = os.Stat(filepath.Join(dir, "setup.py"))
+	if err == nil && (project.BuildBackend == "" || strings.HasPrefix(project.BuildBackend, "setuptools.")) {
+		return backendSetupPy, nil
+	}
+
+	b := backendBuild
+	if project.BuildBackend != "" && !strings.HasPrefix(project.BuildBackend, "setuptools.") {
+		b.name = project.BuildBackend
+	}
+	return b, nil
+}
+
+// errToolNotFound is returned if the tool required by a backend is not installed.
+type errToolNotFound struct {
+	backend backend
+	dir     string
+}
+
+func (e errToolNotFound) Error() string {
+	return fmt.Sprintf("%s is not installed", e.backend.tool)
+}
+
+func (e errToolNotFound) Detail() string {
+	return fmt.Sprintf(
+		"The Python project at %s uses the %s build backend, which requires %s to build wheels.\n"+
+			"Install %s (see %s) or set the build command of the artifact explicitly.",
+		e.dir, e.backend.name, e.backend.tool, e.backend.tool, e.backend.docs,
+	)
+}
+
+// errModuleNotFound is returned if the Python package required by a backend is not installed.
+type errModuleNotFound struct {
+	backend backend
+	python  string
+}
+
+func (e errModuleNotFound) Error() string {
+	return fmt.Sprintf("the Python package build is not installed for %s", e.python)
+}
+
+func (e errModuleNotFound) Detail() string {
+	return fmt.Sprintf(
+		"Projects that use the %s build backend are built with the build package.\n"+
+			"Install it with \"%s\" -m pip install build (see %s) or set the build command of the artifact explicitly.",
+		e.backend.name, e.python, e.backend.docs,
+	)
+}
+
+// buildCommand returns the command that builds a wheel with the backend for the project at the specified path.
+//
+// Tools and interpreters from a virtual environment in the project take precedence
+// over the ones on the PATH so that the project's own versions are used.
+func (b backend) buildCommand(ctx context.Context, dir string) (string, error) {
+	venv, err := python.DetectVirtualEnvPath(dir)
+	if err != nil {
+		venv = ""
+	}
+
+	if b.tool != "" {
+		tool, err := findTool(venv, b.tool)
+		if err != nil {
+			return "", errToolNotFound{backend: b, dir: dir}
+		}
+		return fmt.Sprintf("%s %s", tool, b.args), nil
+	}
+
+	py, err := findInterpreter(ctx, venv)
+	if err != nil {
+		return "", err
+	}
+
+	if b.args == backendBuild.args {
+		_, err = process.Background(ctx, []string{py, "-c", "import build"})
+		if err != nil {
+			return "", errModuleNotFound{backend: b, python: py}
+		}
+	}
+
+	return fmt.Sprintf(`"%s" %s`, py, b.args), nil
+}
+
+// venvExecutable returns the path of an executable in a virtual environment.
+func venvExecutable(venv, name string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(venv, "Scripts", name+".exe")
+	}
+	return filepath.Join(venv, "bin", name)
+}
+
+// findTool returns the command to run the tool, preferring the one in the virtual environment.
+func findTool(venv, name string) (string, error) {
+	if venv != "" {
+		path := venvExecutable(venv, name)
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Sprintf(`"%s"`, path), nil
+		}
+	}
+	_, err := exec.LookPath(name)
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// findInterpreter returns the path of the Python interpreter to build wheels with.
+// It prefers the interpreter of the virtual environment. Otherwise, it picks the
+// python3 on the PATH if it is Python 3.8+, and the oldest Python 3.8+ interpreter
+// that is installed on the system if it isn't. If no interpreter can be detected,
+// it falls back to [python.DetectExecutable].
+func findInterpreter(ctx context.Context, venv string) (string, error) {
+	if venv != "" {
+		for _, name := range []string{"python3", "python"} {
+			path := venvExecutable(venv, name)
+			if _, err := os.Stat(path); err == nil {
+				log.Debugf(ctx, "Using Python interpreter from virtual environment at %s", venv)
+				return path, nil
+			}
+		}
+	}
+
+	all, err := python.DetectInterpreters(ctx)
+	if err != nil {
+		log.Debugf(ctx, "Unable to detect Python interpreters: %s", err)
+		return python.DetectExecutable(ctx)
+	}
+	interpreter, err := all.AtLeast("3.8")
+	if err != nil {
+		return "", err
+	}
+
+	// Interpreters are detected by their resolved paths.
+	if path, err := exec.LookPath("python3"); err == nil {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			for _, v := range all {
+				if v.Path == resolved && semver.Compare(v.Version, interpreter.Version) >= 0 {
+					interpreter = &v
+					break
+				}
+			}
+		}
+	}
+
+	log.Debugf(ctx, "Using Python interpreter %s", interpreter)
+	return interpreter.Path, nil
+}
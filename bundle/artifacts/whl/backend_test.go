@@ -0,0 +1,220 @@
+package whl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadPyproject(t *testing.T) {
+	project, err := readPyproject("./testdata/poetry/pyproject.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "poetry.core.masonry.api", project.BuildBackend)
+	assert.Equal(t, "my_poetry_project", project.Name)
+	assert.False(t, project.HasToolUv)
+
+	project, err = readPyproject("./testdata/uv/pyproject.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "hatchling.build", project.BuildBackend)
+	assert.Equal(t, "my-uv-project", project.Name)
+	assert.True(t, project.HasToolUv)
+
+	project, err = readPyproject("./testdata/setuptools/pyproject.toml")
+	require.NoError(t, err)
+	assert.Equal(t, "setuptools.build_meta", project.BuildBackend)
+	assert.Equal(t, "my_setuptools_project", project.Name)
+}
+
+func TestDetectBackend(t *testing.T) {
+	for _, tc := range []struct {
+		dir      string
+		expected backend
+	}{
+		{"./testdata", backendSetupPy},
+		{"./testdata/poetry", backendPoetry},
+		{"./testdata/hatch", backendHatch},
+		{"./testdata/uv", backendUv},
+		{"./testdata/setuptools", backendBuild},
+		{"./testdata/setuptools_setup_py", backendSetupPy},
+	} {
+		t.Run(tc.dir, func(t *testing.T) {
+			b, err := detectBackend(tc.dir)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, b)
+		})
+	}
+}
+
+func TestDetectBackendOther(t *testing.T) {
+	b, err := detectBackend("./testdata/flit")
+	require.NoError(t, err)
+	assert.Equal(t, "flit_core.buildapi", b.name)
+	assert.Equal(t, backendBuild.args, b.args)
+}
+
+func TestDetectBackendUvLock(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, "./testdata/hatch/pyproject.toml", filepath.Join(dir, "pyproject.toml"))
+	touch(t, filepath.Join(dir, "uv.lock"))
+
+	b, err := detectBackend(dir)
+	require.NoError(t, err)
+	assert.Equal(t, backendUv, b)
+}
+
+func TestBuildCommandTool(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	bin := t.TempDir()
+	touchExecutable(t, filepath.Join(bin, "poetry"))
+	t.Setenv("PATH", bin)
+
+	command, err := backendPoetry.buildCommand(context.Background(), "./testdata/poetry")
+	require.NoError(t, err)
+	assert.Equal(t, "poetry build -f wheel", command)
+}
+
+func TestBuildCommandToolInVirtualEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	dir := t.TempDir()
+	copyFile(t, "./testdata/hatch/pyproject.toml", filepath.Join(dir, "pyproject.toml"))
+	touch(t, filepath.Join(dir, ".venv", "pyvenv.cfg"))
+	touchExecutable(t, filepath.Join(dir, ".venv", "bin", "hatch"))
+	t.Setenv("PATH", t.TempDir())
+
+	command, err := backendHatch.buildCommand(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+filepath.Join(dir, ".venv", "bin", "hatch")+`" build -t wheel`, command)
+}
+
+func TestBuildCommandToolNotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := backendUv.buildCommand(context.Background(), "./testdata/uv")
+	var notFound errToolNotFound
+	require.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "uv is not installed", err.Error())
+	assert.Contains(t, notFound.Detail(), "https://docs.astral.sh/uv/")
+}
+
+func TestBuildCommandInterpreterInVirtualEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "venv", "pyvenv.cfg"))
+	touchExecutable(t, filepath.Join(dir, "venv", "bin", "python3"))
+
+	command, err := backendSetupPy.buildCommand(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+filepath.Join(dir, "venv", "bin", "python3")+`" setup.py bdist_wheel`, command)
+}
+
+func TestBuildCommandBuildModuleNotFound(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	// The fake interpreter fails to import any module.
+	dir := t.TempDir()
+	touch(t, filepath.Join(dir, "venv", "pyvenv.cfg"))
+	py := filepath.Join(dir, "venv", "bin", "python3")
+	require.NoError(t, os.MkdirAll(filepath.Dir(py), 0755))
+	require.NoError(t, os.WriteFile(py, []byte("#!/bin/sh\nexit 1\n"), 0755))
+
+	_, err := backendBuild.buildCommand(context.Background(), dir)
+	var notFound errModuleNotFound
+	require.ErrorAs(t, err, &notFound)
+	assert.Contains(t, notFound.Detail(), "-m pip install build")
+}
+
+func TestDetectModuleName(t *testing.T) {
+	name, ok := detectModuleName("./testdata/poetry")
+	assert.True(t, ok)
+	assert.Equal(t, "my_poetry_project", name)
+
+	name, ok = detectModuleName("./testdata/hatch")
+	assert.True(t, ok)
+	assert.Equal(t, "my-hatch-project", name)
+
+	name, ok = detectModuleName("./testdata/setuptools_setup_py")
+	assert.True(t, ok)
+	assert.Equal(t, "my_test_code", name)
+
+	_, ok = detectModuleName(t.TempDir())
+	assert.False(t, ok)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	raw, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, raw, 0644))
+}
+
+func touch(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+}
+
+func touchExecutable(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0755))
+}
+
+func TestBuildCommandInterpreterOnPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	// The python3 on the PATH is used if no interpreter can be detected.
+	bin := t.TempDir()
+	touchExecutable(t, filepath.Join(bin, "python3"))
+	t.Setenv("PATH", bin)
+
+	command, err := backendSetupPy.buildCommand(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, `"`+filepath.Join(bin, "python3")+`" setup.py bdist_wheel`, command)
+}
+
+func writePythonExecutable(t *testing.T, path, version string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\necho Python "+version+"\n"), 0755))
+}
+
+func TestFindInterpreterDetectsInterpreters(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	// Interpreters are detected by their resolved paths.
+	first, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	second, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	// The python3 that is first on the PATH is too old, so another interpreter is used.
+	writePythonExecutable(t, filepath.Join(first, "python3"), "3.7.9")
+	writePythonExecutable(t, filepath.Join(second, "python3.11"), "3.11.4")
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	path, err := findInterpreter(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(second, "python3.11"), path)
+
+	// The python3 on the PATH is preferred if it is recent enough.
+	writePythonExecutable(t, filepath.Join(first, "python3"), "3.12.1")
+	path, err = findInterpreter(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(first, "python3"), path)
+}
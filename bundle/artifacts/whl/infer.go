@@ -2,11 +2,13 @@ package whl
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/libs/diag"
-	"github.com/databricks/cli/libs/python"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/log"
 )
 
 type infer struct {
@@ -15,10 +17,17 @@ type infer struct {
 
 func (m *infer) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 	artifact := b.Config.Artifacts[m.name]
-	py, err := python.DetectExecutable(ctx)
+
+	dir := artifact.Path
+	if dir == "" {
+		dir = b.RootPath
+	}
+
+	backend, err := detectBackend(dir)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	log.Infof(ctx, "Detected %s build backend for %s", backend.name, m.name)
 
 	// Note: using --build-number (build tag) flag does not help with re-installing
 	// libraries on all-purpose clusters. The reason is that `pip` ignoring build tag
@@ -34,7 +43,23 @@ func (m *infer) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 	//   version=datetime.datetime.utcnow().strftime("%Y%m%d.%H%M%S"),
 	// ...
 	//)
-	artifact.BuildCommand = fmt.Sprintf(`"%s" setup.py bdist_wheel`, py)
+	command, err := backend.buildCommand(ctx, dir)
+	if err != nil {
+		var detailed interface{ Detail() string }
+		if !errors.As(err, &detailed) {
+			return diag.FromErr(err)
+		}
+		path := dyn.NewPath(dyn.Key("artifacts"), dyn.Key(m.name))
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to build %s: %v", m.name, err),
+			Detail:   detailed.Detail(),
+			Location: b.Config.GetLocation(path.String()),
+			Path:     path,
+		}}
+	}
+
+	artifact.BuildCommand = command
 
 	return nil
 }
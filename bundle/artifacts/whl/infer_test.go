@@ -0,0 +1,38 @@
+package whl
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferBuildCommandDefaultsToBundleRoot(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake executables are shell scripts")
+	}
+
+	bin := t.TempDir()
+	touchExecutable(t, filepath.Join(bin, "poetry"))
+	t.Setenv("PATH", bin)
+
+	b := &bundle.Bundle{
+		RootPath: "./testdata/poetry",
+		Config: config.Root{
+			Artifacts: config.Artifacts{
+				"whl": &config.Artifact{
+					Type: config.ArtifactPythonWheel,
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, InferBuildCommand("whl"))
+	require.NoError(t, diags.Error())
+	assert.Equal(t, "poetry build -f wheel", b.Config.Artifacts["whl"].BuildCommand)
+}
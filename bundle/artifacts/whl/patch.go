@@ -0,0 +1,265 @@
+package whl
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/log"
+)
+
+// PatchVersions creates a copy of every wheel of the artifact with a local
+// version label appended to its version. The label is derived from the
+// specified time and, if the bundle is in a Git repository, the commit.
+// The copies are written to the bundle cache directory and uploaded instead
+// of the original wheels. Library references to the original wheels are
+// rewritten to the uploaded copies when the artifact is uploaded.
+func PatchVersions(ctx context.Context, b *bundle.Bundle, name string, now time.Time) diag.Diagnostics {
+	artifact := b.Config.Artifacts[name]
+
+	dir, err := b.CacheDir(ctx, "patched_wheels", name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	suffix := versionLabel(now, b.Config.Bundle.Git.Commit)
+	for i := range artifact.Files {
+		f := &artifact.Files[i]
+		if !strings.HasSuffix(f.Source, ".whl") {
+			continue
+		}
+
+		patched, err := patchWheel(f.Source, dir, suffix)
+		if err != nil {
+			return diag.Errorf("unable to patch version of %s: %v", filepath.Base(f.Source), err)
+		}
+
+		log.Infof(ctx, "Patched version of %s in %s", filepath.Base(f.Source), patched)
+		f.Patched = patched
+	}
+
+	return nil
+}
+
+// Wheel file names are formatted as {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl.
+// See https://packaging.python.org/en/latest/specifications/binary-distribution-format/.
+var wheelFileName = regexp.MustCompile(`^([^-]+)-([^-]+)(-.+)\.whl$`)
+
+// versionLabel returns the local version label for a deployment at the specified time.
+// The timestamp keeps the label unique for deployments of uncommitted changes and
+// the abbreviated commit (prefixed with "g" as in "git describe") identifies the source.
+func versionLabel(now time.Time, commit string) string {
+	label := now.UTC().Format("20060102150405")
+	if commit == "" {
+		return label
+	}
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	return label + ".g" + strings.ToLower(commit)
+}
+
+// patchVersion returns the version with the local version label appended.
+func patchVersion(version, label string) string {
+	if strings.Contains(version, "+") {
+		return version + "." + label
+	}
+	return version + "+" + label
+}
+
+// patchWheel writes a copy of the wheel at [path] to [dir] with the local version
+// label appended to its version. It updates the wheel's file name, the name of its
+// .dist-info directory, the version in its METADATA and the corresponding RECORD entries.
+//
+// The copy is written to a temporary file that is renamed once it is complete,
+// so that a failure doesn't leave a partially written wheel behind.
+func patchWheel(path, dir, label string) (string, error) {
+	matches := wheelFileName.FindStringSubmatch(filepath.Base(path))
+	if matches == nil {
+		return "", fmt.Errorf("invalid wheel file name")
+	}
+
+	distribution, version, rest := matches[1], matches[2], matches[3]
+	newVersion := patchVersion(version, label)
+
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(dir, ".patch-*.whl")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	err = writePatchedWheel(f, &r.Reader, distribution, version, newVersion)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	out := filepath.Join(dir, fmt.Sprintf("%s-%s%s.whl", distribution, newVersion, rest))
+	err = os.Rename(f.Name(), out)
+	if err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// writePatchedWheel writes the contents of the wheel in [r] to [out] with its version
+// changed from [version] to [newVersion].
+func writePatchedWheel(out io.Writer, r *zip.Reader, distribution, version, newVersion string) error {
+	oldInfo := fmt.Sprintf("%s-%s.dist-info/", distribution, version)
+	newInfo := fmt.Sprintf("%s-%s.dist-info/", distribution, newVersion)
+
+	w := zip.NewWriter(out)
+
+	// Hashes and sizes of files whose contents changed, keyed by their new name.
+	changed := make(map[string][2]string)
+
+	var record *zip.File
+	for _, zf := range r.File {
+		name := zf.Name
+		if strings.HasPrefix(name, oldInfo) {
+			name = newInfo + strings.TrimPrefix(name, oldInfo)
+		}
+
+		// RECORD refers to the other files, so it is written last.
+		if name == newInfo+"RECORD" {
+			record = zf
+			continue
+		}
+
+		raw, err := readZipFile(zf)
+		if err != nil {
+			return err
+		}
+
+		if name == newInfo+"METADATA" {
+			raw, err = patchMetadata(raw, newVersion)
+			if err != nil {
+				return err
+			}
+			changed[name] = recordHash(raw)
+		}
+
+		err = writeZipFile(w, zf, name, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	if record == nil {
+		return fmt.Errorf("%sRECORD not found", oldInfo)
+	}
+
+	raw, err := readZipFile(record)
+	if err != nil {
+		return err
+	}
+	raw, err = patchRecord(raw, oldInfo, newInfo, changed)
+	if err != nil {
+		return err
+	}
+	err = writeZipFile(w, record, newInfo+"RECORD", raw)
+	if err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+func readZipFile(zf *zip.File) ([]byte, error) {
+	rc, err := zf.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func writeZipFile(w *zip.Writer, zf *zip.File, name string, raw []byte) error {
+	header := zf.FileHeader
+	header.Name = name
+	fw, err := w.CreateHeader(&header)
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(raw)
+	return err
+}
+
+// patchMetadata replaces the Version header in the wheel's METADATA file.
+func patchMetadata(raw []byte, version string) ([]byte, error) {
+	lines := bytes.Split(raw, []byte("\n"))
+	for i, line := range lines {
+		// Headers end at the first empty line.
+		if len(bytes.TrimSpace(line)) == 0 {
+			break
+		}
+		if bytes.HasPrefix(line, []byte("Version:")) {
+			lines[i] = []byte("Version: " + version)
+			return bytes.Join(lines, []byte("\n")), nil
+		}
+	}
+	return nil, fmt.Errorf("version not found in METADATA")
+}
+
+// patchRecord renames the entries in the .dist-info directory and
+// updates the hashes and sizes of the entries that changed.
+func patchRecord(raw []byte, oldInfo, newInfo string, changed map[string][2]string) ([]byte, error) {
+	rows, err := csv.NewReader(bytes.NewReader(raw)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if len(row) != 3 {
+			return nil, fmt.Errorf("invalid RECORD entry: %v", row)
+		}
+		if strings.HasPrefix(row[0], oldInfo) {
+			row[0] = newInfo + strings.TrimPrefix(row[0], oldInfo)
+		}
+		if c, ok := changed[row[0]]; ok {
+			row[1], row[2] = c[0], c[1]
+		}
+	}
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	err = cw.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// recordHash returns the hash and size of a file as recorded in RECORD.
+func recordHash(raw []byte) [2]string {
+	sum := sha256.Sum256(raw)
+	return [2]string{
+		"sha256=" + base64.RawURLEncoding.EncodeToString(sum[:]),
+		fmt.Sprint(len(raw)),
+	}
+}
@@ -0,0 +1,126 @@
+package whl
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeWheel(t *testing.T, path string, files map[string]string, order []string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for _, name := range order {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func readWheel(t *testing.T, path string) map[string]string {
+	r, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	out := make(map[string]string)
+	for _, zf := range r.File {
+		raw, err := readZipFile(zf)
+		require.NoError(t, err)
+		out[zf.Name] = string(raw)
+	}
+	return out
+}
+
+func TestPatchVersion(t *testing.T) {
+	assert.Equal(t, "0.1.0+20240101", patchVersion("0.1.0", "20240101"))
+	assert.Equal(t, "0.1.0+local.20240101", patchVersion("0.1.0+local", "20240101"))
+}
+
+func TestPatchWheel(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "my_test_code-0.0.1-py3-none-any.whl")
+	metadata := "Metadata-Version: 2.1\nName: my_test_code\nVersion: 0.0.1\n\nVersion: not a header\n"
+	writeWheel(t, source, map[string]string{
+		"my_test_code/__init__.py":                   "",
+		"my_test_code-0.0.1.dist-info/METADATA":      metadata,
+		"my_test_code-0.0.1.dist-info/WHEEL":         "Wheel-Version: 1.0\n",
+		"my_test_code-0.0.1.dist-info/top_level.txt": "my_test_code\n",
+		"my_test_code-0.0.1.dist-info/RECORD": strings.Join([]string{
+			"my_test_code/__init__.py,sha256=47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU,0",
+			"my_test_code-0.0.1.dist-info/METADATA,sha256=abc,70",
+			"my_test_code-0.0.1.dist-info/WHEEL,sha256=def,19",
+			"my_test_code-0.0.1.dist-info/top_level.txt,sha256=ghi,13",
+			"my_test_code-0.0.1.dist-info/RECORD,,",
+			"",
+		}, "\n"),
+	}, []string{
+		"my_test_code/__init__.py",
+		"my_test_code-0.0.1.dist-info/METADATA",
+		"my_test_code-0.0.1.dist-info/WHEEL",
+		"my_test_code-0.0.1.dist-info/top_level.txt",
+		"my_test_code-0.0.1.dist-info/RECORD",
+	})
+
+	out, err := patchWheel(source, filepath.Join(dir, "patched"), "20240101120000")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "patched", "my_test_code-0.0.1+20240101120000-py3-none-any.whl"), out)
+
+	files := readWheel(t, out)
+	newMetadata := "Metadata-Version: 2.1\nName: my_test_code\nVersion: 0.0.1+20240101120000\n\nVersion: not a header\n"
+	newHash := recordHash([]byte(newMetadata))
+	assert.Equal(t, map[string]string{
+		"my_test_code/__init__.py":                                  "",
+		"my_test_code-0.0.1+20240101120000.dist-info/METADATA":      newMetadata,
+		"my_test_code-0.0.1+20240101120000.dist-info/WHEEL":         "Wheel-Version: 1.0\n",
+		"my_test_code-0.0.1+20240101120000.dist-info/top_level.txt": "my_test_code\n",
+		"my_test_code-0.0.1+20240101120000.dist-info/RECORD": strings.Join([]string{
+			"my_test_code/__init__.py,sha256=47DEQpj8HBSa-_TImW-5JCeuQeRkm5NMpJWZG3hSuFU,0",
+			"my_test_code-0.0.1+20240101120000.dist-info/METADATA," + newHash[0] + "," + newHash[1],
+			"my_test_code-0.0.1+20240101120000.dist-info/WHEEL,sha256=def,19",
+			"my_test_code-0.0.1+20240101120000.dist-info/top_level.txt,sha256=ghi,13",
+			"my_test_code-0.0.1+20240101120000.dist-info/RECORD,,",
+			"",
+		}, "\n"),
+	}, files)
+
+	// The original wheel is not modified.
+	assert.Contains(t, readWheel(t, source), "my_test_code-0.0.1.dist-info/METADATA")
+}
+
+func TestPatchWheelInvalidName(t *testing.T) {
+	_, err := patchWheel(filepath.Join(t.TempDir(), "invalid.whl"), t.TempDir(), "1")
+	assert.ErrorContains(t, err, "invalid wheel file name")
+}
+
+func TestPatchWheelMissingRecord(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "my_test_code-0.0.1-py3-none-any.whl")
+	writeWheel(t, source, map[string]string{
+		"my_test_code-0.0.1.dist-info/METADATA": "Version: 0.0.1\n",
+	}, []string{"my_test_code-0.0.1.dist-info/METADATA"})
+
+	out := t.TempDir()
+	_, err := patchWheel(source, out, "1")
+	assert.ErrorContains(t, err, "RECORD not found")
+
+	// No partially written wheel is left behind.
+	entries, err := os.ReadDir(out)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestVersionLabel(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, "20240102030405", versionLabel(now, ""))
+	assert.Equal(t, "20240102030405.gabcdef12", versionLabel(now, "ABCDEF1234567890"))
+}
@@ -0,0 +1,82 @@
+package whl
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// pyproject holds the fields of a pyproject.toml file that determine how a wheel is built.
+//
+// See https://packaging.python.org/en/latest/specifications/pyproject-toml/.
+type pyproject struct {
+	// Value of build-system.build-backend.
+	BuildBackend string
+
+	// Name of the project from project.name or tool.poetry.name.
+	Name string
+
+	// Whether the file configures uv in a tool.uv table.
+	HasToolUv bool
+}
+
+var (
+	tomlTable    = regexp.MustCompile(`^\[\s*([A-Za-z0-9_.\-" ]+?)\s*\]`)
+	tomlKeyValue = regexp.MustCompile(`^([A-Za-z0-9_\-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// readPyproject reads the fields of a pyproject.toml file that are relevant to building wheels.
+// It is not a complete TOML parser; it only recognizes table headers and
+// top level string values, which is sufficient for the fields it reads.
+func readPyproject(path string) (*pyproject, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out pyproject
+	var table string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Array tables such as [[tool.poetry.source]] never contain the fields we read.
+		if strings.HasPrefix(line, "[[") {
+			table = ""
+			continue
+		}
+
+		if m := tomlTable.FindStringSubmatch(line); m != nil {
+			table = strings.ReplaceAll(strings.ReplaceAll(m[1], `"`, ""), " ", "")
+			if table == "tool.uv" || strings.HasPrefix(table, "tool.uv.") {
+				out.HasToolUv = true
+			}
+			continue
+		}
+
+		m := tomlKeyValue.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		key, value := m[1], m[2]+m[3]
+		switch {
+		case table == "build-system" && key == "build-backend":
+			out.BuildBackend = value
+		case table == "project" && key == "name":
+			out.Name = value
+		case table == "tool.poetry" && key == "name" && out.Name == "":
+			out.Name = value
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
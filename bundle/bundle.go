@@ -16,6 +16,7 @@ import (
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/env"
 	"github.com/databricks/cli/bundle/metadata"
+	"github.com/databricks/cli/libs/dyn"
 	"github.com/databricks/cli/libs/fileset"
 	"github.com/databricks/cli/libs/folders"
 	"github.com/databricks/cli/libs/git"
@@ -38,6 +39,10 @@ type Bundle struct {
 
 	Config config.Root
 
+	// LoadedConfig is the configuration as it was loaded, before a target was selected.
+	// It is used to validate references, which are resolved when the bundle is initialized.
+	LoadedConfig dyn.Value
+
 	// Metadata about the bundle deployment. This is the interface Databricks services
 	// rely on to integrate with bundles when they need additional information about
 	// a bundle deployment.
@@ -66,6 +71,10 @@ type Bundle struct {
 	// files
 	AutoApprove bool
 
+	// if true, warnings are reported as errors, so that any warning
+	// that is not ignored in the configuration fails the command
+	Strict bool
+
 	// Tagging is used to normalize tag keys and values.
 	// The implementation depends on the cloud being targeted.
 	Tagging tags.Cloud
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/dyn"
 	"github.com/databricks/databricks-sdk-go"
 )
 
@@ -19,6 +20,15 @@ func (r ReadOnlyBundle) Config() config.Root {
 	return r.b.Config
 }
 
+// LoadedConfig returns the configuration as it was loaded, before a target was selected.
+// If a target hasn't been selected yet, it returns the current configuration.
+func (r ReadOnlyBundle) LoadedConfig() dyn.Value {
+	if r.b.LoadedConfig.IsValid() {
+		return r.b.LoadedConfig
+	}
+	return r.b.Config.Value()
+}
+
 func (r ReadOnlyBundle) RootPath() string {
 	return r.b.RootPath
 }
@@ -20,9 +20,18 @@ type ArtifactType string
 
 const ArtifactPythonWheel ArtifactType = `whl`
 
+const ArtifactJar ArtifactType = `jar`
+
 type ArtifactFile struct {
 	Source     string `json:"source"`
 	RemotePath string `json:"remote_path" bundle:"readonly"`
+
+	// Hash of the file's contents, set when the file is uploaded.
+	Hash string `json:"hash,omitempty" bundle:"readonly"`
+
+	// Patched is the path to a copy of the file with a patched version.
+	// If set, this copy is uploaded instead of the source file.
+	Patched string `json:"patched,omitempty" bundle:"readonly"`
 }
 
 // Artifact defines a single local code artifact that can be
@@ -41,6 +50,16 @@ type Artifact struct {
 
 	Executable exec.ExecutableType `json:"executable,omitempty"`
 
+	// DynamicVersion specifies whether the version of built wheels is suffixed
+	// with a local version label that is unique to every deployment. The label
+	// consists of a timestamp and, if available, the abbreviated Git commit.
+	// This ensures clusters install the wheel again even if its version did not change.
+	DynamicVersion bool `json:"dynamic_version,omitempty"`
+
+	// SourceHash is the hash of the artifact's source files and build command.
+	// It is used to skip the build if the inputs have not changed since the last deployment.
+	SourceHash string `json:"source_hash,omitempty" bundle:"readonly"`
+
 	paths.Paths
 }
 
@@ -46,4 +46,7 @@ type Bundle struct {
 
 	// Databricks CLI version constraints required to run the bundle.
 	DatabricksCliVersion string `json:"databricks_cli_version,omitempty"`
+
+	// Diagnostics ignores or escalates diagnostics with specific IDs.
+	Diagnostics []DiagnosticOverride `json:"diagnostics,omitempty"`
 }
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// rewriteConditions moves conditions that cannot be represented in the typed
+// configuration to a place where they can be retained until they are evaluated.
+//
+// Include entries may be specified in their long form (a map with a "path" and
+// a "condition" key). They are rewritten to their path and the condition is
+// stored under the "include_conditions" key, keyed by the include path.
+//
+// Tasks are defined by the SDK and don't have a field to hold a condition.
+// Their conditions are moved to the "task_conditions" key of the job that
+// contains them, keyed by task key.
+func rewriteConditions(v dyn.Value) (dyn.Value, error) {
+	if v.Kind() != dyn.KindMap {
+		return v, nil
+	}
+
+	v, err := rewriteIncludeConditions(v)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	return rewriteTaskField(v, "condition", "task_conditions")
+}
+
+func rewriteIncludeConditions(v dyn.Value) (dyn.Value, error) {
+	includes, ok := v.Get("include").AsSequence()
+	if !ok {
+		return v, nil
+	}
+
+	var paths []dyn.Value
+	conditions := dyn.NewMapping()
+	for _, include := range includes {
+		if include.Kind() != dyn.KindMap {
+			paths = append(paths, include)
+			continue
+		}
+
+		path := include.Get("path")
+		if path.Kind() != dyn.KindString {
+			return dyn.InvalidValue, fmt.Errorf("%s: include entries must specify a path", include.Location())
+		}
+
+		paths = append(paths, path)
+		if condition := include.Get("condition"); condition.IsValid() {
+			conditions.Set(path, condition)
+		}
+	}
+
+	v, err := dyn.Set(v, "include", dyn.NewValue(paths, v.Get("include").Location()))
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	if conditions.Len() == 0 {
+		return v, nil
+	}
+
+	return dyn.Set(v, "include_conditions", dyn.NewValue(conditions, v.Get("include").Location()))
+}
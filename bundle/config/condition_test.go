@@ -0,0 +1,53 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRewritesConditions(t *testing.T) {
+	r, diags := LoadFromBytes("databricks.yml", []byte(`
+include:
+  - a.yml
+  - path: b.yml
+    condition: "${bundle.target} == prod"
+
+resources:
+  jobs:
+    job:
+      condition: "true"
+      tasks:
+        - task_key: t1
+        - task_key: t2
+          condition: "false"
+
+targets:
+  dev:
+    resources:
+      jobs:
+        job:
+          tasks:
+            - task_key: t3
+              condition: "true"
+`))
+	require.Empty(t, diags)
+
+	assert.Equal(t, []string{"a.yml", "b.yml"}, r.Include)
+	assert.Equal(t, map[string]string{"b.yml": "${bundle.target} == prod"}, r.IncludeConditions)
+
+	job := r.Resources.Jobs["job"]
+	assert.Equal(t, "true", job.Condition)
+	assert.Len(t, job.Tasks, 2)
+	assert.Equal(t, map[string]string{"t2": "false"}, job.TaskConditions)
+	assert.Equal(t, map[string]string{"t3": "true"}, r.Targets["dev"].Resources.Jobs["job"].TaskConditions)
+}
+
+func TestLoadIncludeWithoutPath(t *testing.T) {
+	_, diags := LoadFromBytes("databricks.yml", []byte(`
+include:
+  - condition: "true"
+`))
+	assert.ErrorContains(t, diags.Error(), "include entries must specify a path")
+}
@@ -7,4 +7,12 @@ type Deployment struct {
 
 	// Lock configures locking behavior on deployment.
 	Lock Lock `json:"lock"`
+
+	// Parallelism is the maximum number of artifacts that are built or
+	// uploaded concurrently. It defaults to the number of CPUs.
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// UpdateLockfile specifies whether to write the bundle lockfile
+	// instead of verifying the deployment against it.
+	UpdateLockfile bool `json:"update_lockfile,omitempty" bundle:"readonly"`
 }
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/libs/diag"
+)
+
+// IDs of diagnostics reported for bundles.
+// These are referenced from user configuration and must not change once released.
+const (
+	// References and variables.
+	UndefinedVariable       diag.ID = "undefined-variable"
+	UndefinedResource       diag.ID = "undefined-resource"
+	UnknownReferenceField   diag.ID = "unknown-reference-field"
+	UnusedVariable          diag.ID = "unused-variable"
+	UndefinedTargetResource diag.ID = "undefined-target-resource"
+
+	// Jobs and tasks.
+	JobClusterKeyNotDefined  diag.ID = "job-cluster-key-not-defined"
+	DuplicateTaskKey         diag.ID = "duplicate-task-key"
+	EnvironmentKeyNotDefined diag.ID = "environment-key-not-defined"
+	InvalidRunIf             diag.ID = "invalid-run-if"
+	RunIfWithoutDependencies diag.ID = "run-if-without-dependencies"
+	TaskDependencyNotFound   diag.ID = "task-dependency-not-found"
+	InvalidDependencyOutcome diag.ID = "invalid-dependency-outcome"
+	UnreachableTask          diag.ID = "unreachable-task"
+	TaskDependencyCycle      diag.ID = "task-dependency-cycle"
+	ConditionSkipped         diag.ID = "condition-skipped"
+	SkippedTaskDependency    diag.ID = "skipped-task-dependency"
+
+	// Libraries.
+	ConflictingLibraryVersion diag.ID = "conflicting-library-version"
+	EntryPointNotFound        diag.ID = "entry-point-not-found"
+
+	// Files to synchronize.
+	NoFilesToSync      diag.ID = "no-files-to-sync"
+	SyncPatternNoMatch diag.ID = "sync-pattern-no-match"
+
+	// Compute settings verified against the workspace.
+	ComputeNotFound          diag.ID = "compute-not-found"
+	ComputeNotVerified       diag.ID = "compute-not-verified"
+	SparkVersionNotAvailable diag.ID = "spark-version-not-available"
+	NodeTypeNotAvailable     diag.ID = "node-type-not-available"
+	ClusterPolicyViolation   diag.ID = "cluster-policy-violation"
+
+	// Principals verified against the workspace.
+	PrincipalNotFound    diag.ID = "principal-not-found"
+	PrincipalNotVerified diag.ID = "principal-not-verified"
+
+	// Checks for targets with 'mode: production'.
+	ProductionExistingCluster      diag.ID = "production-existing-cluster"
+	ProductionGroupManage          diag.ID = "production-group-manage"
+	ProductionFailureNotifications diag.ID = "production-failure-notifications"
+	ProductionTaskRetries          diag.ID = "production-task-retries"
+	ProductionUserLibrary          diag.ID = "production-user-library"
+	ProductionUnprotectedBranch    diag.ID = "production-unprotected-branch"
+
+	// Deployment.
+	LegacyRunAs         diag.ID = "legacy-run-as"
+	PermissionOverlap   diag.ID = "permission-overlap"
+	LockfileDrift       diag.ID = "lockfile-drift"
+	LockfileNotVerified diag.ID = "lockfile-not-verified"
+
+	// Configuration of validation itself.
+	InvalidPolicyRule         diag.ID = "invalid-policy-rule"
+	InvalidDiagnosticOverride diag.ID = "invalid-diagnostic-override"
+)
+
+// diagnosticIDs are the IDs of diagnostics reported for bundles.
+var diagnosticIDs = []diag.ID{
+	UndefinedVariable,
+	UndefinedResource,
+	UnknownReferenceField,
+	UnusedVariable,
+	UndefinedTargetResource,
+	JobClusterKeyNotDefined,
+	DuplicateTaskKey,
+	EnvironmentKeyNotDefined,
+	InvalidRunIf,
+	RunIfWithoutDependencies,
+	TaskDependencyNotFound,
+	InvalidDependencyOutcome,
+	UnreachableTask,
+	TaskDependencyCycle,
+	ConditionSkipped,
+	SkippedTaskDependency,
+	ConflictingLibraryVersion,
+	EntryPointNotFound,
+	NoFilesToSync,
+	SyncPatternNoMatch,
+	ComputeNotFound,
+	ComputeNotVerified,
+	SparkVersionNotAvailable,
+	NodeTypeNotAvailable,
+	ClusterPolicyViolation,
+	PrincipalNotFound,
+	PrincipalNotVerified,
+	ProductionExistingCluster,
+	ProductionGroupManage,
+	ProductionFailureNotifications,
+	ProductionTaskRetries,
+	ProductionUserLibrary,
+	ProductionUnprotectedBranch,
+	LegacyRunAs,
+	PermissionOverlap,
+	LockfileDrift,
+	LockfileNotVerified,
+	InvalidPolicyRule,
+	InvalidDiagnosticOverride,
+}
+
+// IsKnownDiagnosticID returns true if the ID is defined in this package or is the ID of a policy rule.
+func IsKnownDiagnosticID(id diag.ID) bool {
+	return slices.Contains(diagnosticIDs, id) || strings.HasPrefix(string(id), policyRulePrefix)
+}
+
+const policyRulePrefix = "policy-rule:"
+
+// PolicyRuleID returns the ID of diagnostics reported for violations of a user-defined policy rule.
+func PolicyRuleID(name string) diag.ID {
+	return diag.ID(policyRulePrefix + name)
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// DiagnosticOverride changes how diagnostics with a specific ID are reported, for example:
+//
+//	bundle:
+//	  diagnostics:
+//	    - id: sync-pattern-no-match
+//	      severity: ignore
+//	    - id: unused-variable
+//	      paths: [variables.catalog]
+//	      severity: error
+type DiagnosticOverride struct {
+	// ID of the diagnostics to override.
+	ID string `json:"id"`
+
+	// Paths limits the override to diagnostics for values that match one of the patterns,
+	// or values below them. Patterns use the path syntax, where a "*" key matches any key
+	// and a "[*]" index matches any index, for example "resources.jobs.*".
+	Paths []string `json:"paths,omitempty"`
+
+	// Severity to report the diagnostics with: "error", "warning", "info",
+	// or "ignore" to not report them at all.
+	Severity string `json:"severity"`
+}
+
+// DiagnosticSeverityIgnore is the severity of diagnostics that are not reported.
+const DiagnosticSeverityIgnore = "ignore"
+
+type parsedDiagnosticOverride struct {
+	id       diag.ID
+	patterns []dyn.Pattern
+	severity diag.Severity
+	ignore   bool
+}
+
+func (o DiagnosticOverride) parse() (*parsedDiagnosticOverride, error) {
+	if o.ID == "" {
+		return nil, fmt.Errorf("id is not set")
+	}
+
+	p := &parsedDiagnosticOverride{id: diag.ID(o.ID)}
+	switch o.Severity {
+	case "error":
+		p.severity = diag.Error
+	case "warning":
+		p.severity = diag.Warning
+	case "info":
+		p.severity = diag.Info
+	case DiagnosticSeverityIgnore:
+		p.ignore = true
+	default:
+		return nil, fmt.Errorf("unknown severity %q, expected \"error\", \"warning\", \"info\" or \"ignore\"", o.Severity)
+	}
+
+	for _, path := range o.Paths {
+		pattern, err := dyn.NewPatternFromString(path)
+		if err != nil {
+			return nil, err
+		}
+		p.patterns = append(p.patterns, pattern)
+	}
+	return p, nil
+}
+
+// Validate returns an error if the override can't be applied.
+func (o DiagnosticOverride) Validate() error {
+	_, err := o.parse()
+	return err
+}
+
+func (p *parsedDiagnosticOverride) matches(d diag.Diagnostic) bool {
+	if d.ID != p.id {
+		return false
+	}
+	if len(p.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if pattern.MatchesPrefix(d.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyDiagnosticOverrides returns the diagnostics with the overrides applied.
+// If multiple overrides match a diagnostic, the last one wins.
+//
+// Errors are never overridden, because they mean that the bundle can't be deployed.
+// Invalid overrides are skipped here; they are reported by validation,
+// which runs as part of "bundle validate" and "bundle deploy".
+func ApplyDiagnosticOverrides(overrides []DiagnosticOverride, diags diag.Diagnostics) diag.Diagnostics {
+	if len(overrides) == 0 {
+		return diags
+	}
+
+	var parsed []*parsedDiagnosticOverride
+	for _, o := range overrides {
+		p, err := o.parse()
+		if err != nil {
+			continue
+		}
+		parsed = append(parsed, p)
+	}
+
+	var out diag.Diagnostics
+	for _, d := range diags {
+		ignore := false
+		if d.Severity != diag.Error {
+			for _, p := range parsed {
+				if p.matches(d) {
+					ignore = p.ignore
+					if !ignore {
+						d.Severity = p.severity
+					}
+				}
+			}
+		}
+		if !ignore {
+			out = append(out, d)
+		}
+	}
+	return out
+}
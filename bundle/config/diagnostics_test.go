@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/stretchr/testify/assert"
+)
+
+func testOverrideDiagnostics() diag.Diagnostics {
+	return diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "a", ID: SyncPatternNoMatch, Path: dyn.MustPathFromString("sync.include[0]")},
+		{Severity: diag.Warning, Summary: "b", ID: UnusedVariable, Path: dyn.MustPathFromString("variables.foo")},
+		{Severity: diag.Warning, Summary: "c", ID: UnusedVariable, Path: dyn.MustPathFromString("variables.bar")},
+		{Severity: diag.Error, Summary: "d", ID: TaskDependencyNotFound},
+		{Severity: diag.Info, Summary: "e"},
+	}
+}
+
+func summaries(diags diag.Diagnostics) map[string]diag.Severity {
+	out := make(map[string]diag.Severity)
+	for _, d := range diags {
+		out[d.Summary] = d.Severity
+	}
+	return out
+}
+
+func TestApplyDiagnosticOverrides(t *testing.T) {
+	diags := ApplyDiagnosticOverrides([]DiagnosticOverride{
+		{ID: "sync-pattern-no-match", Severity: "ignore"},
+		{ID: "unused-variable", Paths: []string{"variables.foo"}, Severity: "error"},
+		{ID: "task-dependency-not-found", Severity: "ignore"},
+	}, testOverrideDiagnostics())
+
+	assert.Equal(t, map[string]diag.Severity{
+		"b": diag.Error,
+		"c": diag.Warning,
+		"d": diag.Error,
+		"e": diag.Info,
+	}, summaries(diags))
+}
+
+func TestApplyDiagnosticOverridesLastMatchWins(t *testing.T) {
+	diags := ApplyDiagnosticOverrides([]DiagnosticOverride{
+		{ID: "unused-variable", Severity: "ignore"},
+		{ID: "unused-variable", Paths: []string{"variables.*"}, Severity: "info"},
+	}, testOverrideDiagnostics())
+
+	assert.Equal(t, diag.Info, summaries(diags)["b"])
+	assert.Equal(t, diag.Info, summaries(diags)["c"])
+}
+
+func TestApplyDiagnosticOverridesSkipsInvalid(t *testing.T) {
+	diags := ApplyDiagnosticOverrides([]DiagnosticOverride{
+		{ID: "unused-variable", Severity: "fatal"},
+		{ID: "sync-pattern-no-match", Paths: []string{"sync..include"}, Severity: "ignore"},
+	}, testOverrideDiagnostics())
+
+	assert.Equal(t, testOverrideDiagnostics(), diags)
+}
+
+func TestDiagnosticOverrideValidate(t *testing.T) {
+	assert.NoError(t, DiagnosticOverride{ID: "unused-variable", Severity: "ignore"}.Validate())
+	assert.ErrorContains(t, DiagnosticOverride{Severity: "ignore"}.Validate(), "id is not set")
+	assert.ErrorContains(t, DiagnosticOverride{ID: "unused-variable", Severity: "fatal"}.Validate(), `unknown severity "fatal"`)
+	assert.ErrorContains(t, DiagnosticOverride{ID: "unused-variable", Severity: "info", Paths: []string{"foo[x]"}}.Validate(), "invalid pattern: foo[x]")
+}
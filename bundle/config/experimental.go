@@ -1,6 +1,8 @@
 package config
 
 type Experimental struct {
+	// DEPRECATED. Use the top level scripts section instead.
+	// Scripts defined here are used for hooks that are not defined in the scripts section.
 	Scripts map[ScriptHook]Command `json:"scripts,omitempty"`
 
 	// By default Python wheel tasks deployed as is to Databricks platform.
@@ -28,6 +30,10 @@ type Experimental struct {
 	//
 	// PyDABs allows to define bundle configuration using Python.
 	PyDABs PyDABs `json:"pydabs,omitempty"`
+
+	// ConfigGenerators are executables that generate or modify bundle configuration.
+	// They are executed after PyDABs in the order in which they are defined.
+	ConfigGenerators []ConfigGenerator `json:"config_generators,omitempty"`
 }
 
 type PyDABs struct {
@@ -39,17 +45,42 @@ type PyDABs struct {
 	// Required if PyDABs is enabled. PyDABs will load the code in the specified
 	// environment.
 	VEnvPath string `json:"venv_path,omitempty"`
+
+	// DisableCache disables caching of PyDABs output.
+	//
+	// By default, the output is cached in .databricks/bundle/default/pydabs in the
+	// bundle root (or in the directory set by DATABRICKS_BUNDLE_TMP) and reused
+	// across CLI invocations as long as the bundle configuration, the Python source
+	// files in the bundle and the packages installed in the virtual environment
+	// don't change. The input and output files of PyDABs are written to the same
+	// directory rather than a new temporary directory.
+	DisableCache bool `json:"disable_cache,omitempty"`
+
+	// LongLived runs PyDABs in a single process that serves all phases of a
+	// CLI invocation instead of starting a new interpreter for every phase.
+	LongLived bool `json:"long_lived,omitempty"`
+
+	// Daemon runs PyDABs in a background process that is shared by CLI
+	// invocations for the same bundle. The CLI starts the process if it isn't
+	// running yet and communicates with it over a socket in a directory that
+	// only the current user can access.
+	Daemon bool `json:"daemon,omitempty"`
 }
 
-type Command string
-type ScriptHook string
-
-// These hook names are subject to change and currently experimental
-const (
-	ScriptPreInit    ScriptHook = "preinit"
-	ScriptPostInit   ScriptHook = "postinit"
-	ScriptPreBuild   ScriptHook = "prebuild"
-	ScriptPostBuild  ScriptHook = "postbuild"
-	ScriptPreDeploy  ScriptHook = "predeploy"
-	ScriptPostDeploy ScriptHook = "postdeploy"
-)
+// ConfigGenerator is an executable that generates or modifies bundle configuration.
+//
+// The executable reads the bundle configuration as JSON from the file named by the
+// DATABRICKS_BUNDLE_GENERATOR_INPUT environment variable. It writes a JSON object with
+// the modified configuration in the "config" field and, optionally, diagnostics in
+// the "diagnostics" field to the file named by DATABRICKS_BUNDLE_GENERATOR_OUTPUT.
+// The phase is passed in DATABRICKS_BUNDLE_GENERATOR_PHASE.
+//
+// Generators follow the same rules as PyDABs for which changes are allowed in each phase.
+type ConfigGenerator struct {
+	// Command to execute, relative to the bundle root.
+	Command string `json:"command"`
+
+	// Phases to execute the generator in, "load" or "init".
+	// The generator is executed in all phases if not set.
+	Phases []string `json:"phases,omitempty"`
+}
@@ -14,6 +14,8 @@ var FileNames = ConfigFileNames{
 	"databricks.yaml",
 	"bundle.yml",
 	"bundle.yaml",
+	"databricks.json",
+	"databricks.jsonc",
 }
 
 func (c ConfigFileNames) FindInPath(path string) (string, error) {
@@ -29,6 +29,12 @@ func TestConfigFileNames_FindInPath(t *testing.T) {
 			expected: "BASE/bundle.yml",
 			err:      "",
 		},
+		{
+			name:     "json file found",
+			files:    []string{"databricks.jsonc"},
+			expected: "BASE/databricks.jsonc",
+			err:      "",
+		},
 		{
 			name:     "multiple files found",
 			files:    []string{"databricks.yaml", "bundle.yml"},
@@ -44,7 +50,7 @@ func TestConfigFileNames_FindInPath(t *testing.T) {
 	}
 
 	if runtime.GOOS == "windows" {
-		testCases[3].err = "The system cannot find the file specified."
+		testCases[4].err = "The system cannot find the file specified."
 	}
 
 	for _, tc := range testCases {
@@ -6,8 +6,12 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/jobs"
 )
 
-var jobOrder = yamlsaver.NewOrder([]string{"name", "job_clusters", "compute", "tasks"})
-var taskOrder = yamlsaver.NewOrder([]string{"task_key", "depends_on", "existing_cluster_id", "new_cluster", "job_cluster_key"})
+// JobKeyOrder and TaskKeyOrder are the keys that are written first for jobs and tasks.
+var JobKeyOrder = []string{"name", "job_clusters", "compute", "tasks"}
+var TaskKeyOrder = []string{"task_key", "depends_on", "existing_cluster_id", "new_cluster", "job_cluster_key"}
+
+var jobOrder = yamlsaver.NewOrder(JobKeyOrder)
+var taskOrder = yamlsaver.NewOrder(TaskKeyOrder)
 
 func ConvertJobToValue(job *jobs.Job) (dyn.Value, error) {
 	value := make(map[string]dyn.Value)
@@ -6,7 +6,10 @@ import (
 	"github.com/databricks/databricks-sdk-go/service/pipelines"
 )
 
-var pipelineOrder = yamlsaver.NewOrder([]string{"name", "clusters", "configuration", "libraries"})
+// PipelineKeyOrder are the keys that are written first for pipelines.
+var PipelineKeyOrder = []string{"name", "clusters", "configuration", "libraries"}
+
+var pipelineOrder = yamlsaver.NewOrder(PipelineKeyOrder)
 
 func ConvertPipelineToValue(pipeline *pipelines.PipelineSpec) (dyn.Value, error) {
 	value := make(map[string]dyn.Value)
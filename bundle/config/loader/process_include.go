@@ -7,33 +7,81 @@ import (
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
 )
 
-type processInclude struct {
-	fullPath string
-	relPath  string
+type processIncludeMutator struct {
+	fullPath  string
+	relPath   string
+	condition string
 }
 
 // ProcessInclude loads the configuration at [fullPath] and merges it into the configuration.
 func ProcessInclude(fullPath, relPath string) bundle.Mutator {
-	return &processInclude{
-		fullPath: fullPath,
-		relPath:  relPath,
+	return processInclude(fullPath, relPath, "")
+}
+
+func processInclude(fullPath, relPath, condition string) bundle.Mutator {
+	return &processIncludeMutator{
+		fullPath:  fullPath,
+		relPath:   relPath,
+		condition: condition,
 	}
 }
 
-func (m *processInclude) Name() string {
+func (m *processIncludeMutator) Name() string {
 	return fmt.Sprintf("ProcessInclude(%s)", m.relPath)
 }
 
-func (m *processInclude) Apply(_ context.Context, b *bundle.Bundle) diag.Diagnostics {
+func (m *processIncludeMutator) Apply(_ context.Context, b *bundle.Bundle) diag.Diagnostics {
 	this, diags := config.Load(m.fullPath)
 	if diags.HasError() {
 		return diags
 	}
+	if m.condition != "" {
+		err := this.Mutate(func(v dyn.Value) (dyn.Value, error) {
+			return applyIncludeCondition(v, m.condition)
+		})
+		if err != nil {
+			return diags.Extend(diag.FromErr(err))
+		}
+	}
 	err := b.Config.Merge(this)
 	if err != nil {
 		diags = diags.Extend(diag.FromErr(err))
 	}
 	return diags
 }
+
+// applyIncludeCondition sets the condition of every resource defined in an included
+// configuration file, at the top level and in targets. If a resource already has
+// a condition, both conditions must hold for the resource to be deployed.
+//
+// The condition only applies to resources. Conditions are evaluated after the
+// configuration is loaded and a target is selected, so other sections of the file,
+// such as targets, variables and artifacts, are always merged.
+func applyIncludeCondition(v dyn.Value, condition string) (dyn.Value, error) {
+	patterns := []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("resources"), dyn.AnyKey(), dyn.AnyKey()),
+		dyn.NewPattern(dyn.Key("targets"), dyn.AnyKey(), dyn.Key("resources"), dyn.AnyKey(), dyn.AnyKey()),
+	}
+
+	for _, pattern := range patterns {
+		var err error
+		v, err = dyn.MapByPattern(v, pattern, func(_ dyn.Path, resource dyn.Value) (dyn.Value, error) {
+			if resource.Kind() != dyn.KindMap {
+				return resource, nil
+			}
+
+			nc := condition
+			if existing, ok := resource.Get("condition").AsString(); ok && existing != "" {
+				nc = fmt.Sprintf("(%s) && (%s)", condition, existing)
+			}
+			return dyn.Set(resource, "condition", dyn.NewValue(nc, resource.Location()))
+		})
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+	}
+	return v, nil
+}
@@ -2,12 +2,15 @@ package loader
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/lockfile"
 	"github.com/databricks/cli/libs/diag"
 )
 
@@ -40,7 +43,63 @@ func (m *processRootIncludes) Apply(ctx context.Context, b *bundle.Bundle) diag.
 	// For each glob, find all files to load.
 	// Ordering of the list of globs is maintained in the output.
 	// For matches that appear in multiple globs, only the first is kept.
+	// The lockfile is only loaded if there are remote includes.
+	var lock *lockfile.Lockfile
+	var lockChanged bool
+	var remote = map[string]bool{}
+	var diags diag.Diagnostics
+	mode := GetRemoteIncludeMode(ctx)
+
 	for _, entry := range b.Config.Include {
+		condition := b.Config.IncludeConditions[entry]
+
+		// Remote includes are fetched into a cache directory and verified against the lockfile.
+		if IsRemoteInclude(entry) {
+			remote[entry] = true
+			if lock == nil {
+				var err error
+				lock, err = lockfile.Load(b.RootPath)
+				if err != nil {
+					return diags.Extend(diag.FromErr(err))
+				}
+			}
+
+			r, err := parseRemoteInclude(entry)
+			if err != nil {
+				return diags.Extend(diag.FromErr(err))
+			}
+
+			dir, matches, changed, err := r.resolve(ctx, remoteIncludeCacheDir(ctx, b.RootPath), lock, mode)
+			if errors.Is(err, errRemoteIncludeNotCached) {
+				diags = diags.Append(diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("%s: skipped because it has not been fetched yet", entry),
+				})
+				continue
+			}
+			if err != nil {
+				return diags.Extend(diag.FromErr(err))
+			}
+			lockChanged = lockChanged || changed
+
+			for _, match := range matches {
+				fullPath := filepath.Join(dir, match)
+				if _, ok := seen[fullPath]; ok {
+					continue
+				}
+				seen[fullPath] = true
+				name := r.fileEntry(match)
+				files = append(files, name)
+				out = append(out, processInclude(fullPath, name, condition))
+			}
+			continue
+		}
+
+		err := checkUnsupportedRemoteInclude(entry)
+		if err != nil {
+			return diags.Extend(diag.FromErr(err))
+		}
+
 		// Include paths must be relative.
 		if filepath.IsAbs(entry) {
 			return diag.Errorf("%s: includes must be relative paths", entry)
@@ -76,12 +135,38 @@ func (m *processRootIncludes) Apply(ctx context.Context, b *bundle.Bundle) diag.
 		slices.Sort(includes)
 		files = append(files, includes...)
 		for _, include := range includes {
-			out = append(out, ProcessInclude(filepath.Join(b.RootPath, include), include))
+			out = append(out, processInclude(filepath.Join(b.RootPath, include), include, condition))
 		}
 	}
 
 	// Swap out the original includes list with the expanded globs.
 	b.Config.Include = files
 
-	return bundle.Apply(ctx, b, bundle.Seq(out...))
+	// Drop the hashes of remote includes that are no longer included
+	// if the lockfile is being updated.
+	if mode == RemoteIncludesUpdate {
+		if lock == nil {
+			var err error
+			lock, err = lockfile.Load(b.RootPath)
+			if err != nil {
+				return diags.Extend(diag.FromErr(err))
+			}
+		}
+		for entry := range lock.Includes {
+			if !remote[entry] {
+				delete(lock.Includes, entry)
+				lockChanged = true
+			}
+		}
+	}
+
+	// Record the hashes of remote includes if the lockfile is being updated.
+	if lockChanged {
+		err := lock.Save(b.RootPath)
+		if err != nil {
+			return diags.Extend(diag.FromErr(err))
+		}
+	}
+
+	return diags.Extend(bundle.Apply(ctx, b, bundle.Seq(out...)))
 }
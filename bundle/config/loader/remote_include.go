@@ -0,0 +1,243 @@
+package loader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle/env"
+	"github.com/databricks/cli/bundle/lockfile"
+	"github.com/databricks/cli/libs/git"
+	"github.com/databricks/cli/libs/log"
+)
+
+const remoteIncludePrefix = "git::"
+
+// remoteInclude is an include entry that refers to files in a Git repository.
+//
+// It is written as "git::<url>//<path>@<ref>", for example:
+//
+//	git::https://github.com/org/repo.git//bundles/notifications.yml@v1.2.0
+//
+// The path may be a glob pattern and must be a relative path within the repository.
+// The reference must be a branch or a tag.
+//
+// Only Git repositories are supported. Other URLs, such as HTTP(S) URLs
+// of individual files, are rejected.
+type remoteInclude struct {
+	entry string
+	url   string
+	path  string
+	ref   string
+}
+
+// IsRemoteInclude returns true if the include entry refers to files in a remote repository.
+func IsRemoteInclude(entry string) bool {
+	return strings.HasPrefix(entry, remoteIncludePrefix)
+}
+
+// checkUnsupportedRemoteInclude returns an error if the include entry is a URL
+// that isn't a remote include from a Git repository.
+func checkUnsupportedRemoteInclude(entry string) error {
+	if IsRemoteInclude(entry) || !strings.Contains(entry, "://") {
+		return nil
+	}
+	return fmt.Errorf("%s: only remote includes from Git repositories are supported; use git::<url>//<path>@<ref>", entry)
+}
+
+func parseRemoteInclude(entry string) (*remoteInclude, error) {
+	rest := strings.TrimPrefix(entry, remoteIncludePrefix)
+
+	// The reference follows the last "@" sign.
+	i := strings.LastIndex(rest, "@")
+	if i < 0 || i == len(rest)-1 {
+		return nil, fmt.Errorf("%s: remote includes must be pinned to a reference (e.g. @v1.0.0)", entry)
+	}
+	rest, ref := rest[:i], rest[i+1:]
+
+	// The path in the repository follows the first "//" after the URL scheme.
+	offset := 0
+	if j := strings.Index(rest, "://"); j >= 0 {
+		offset = j + len("://")
+	}
+	j := strings.Index(rest[offset:], "//")
+	if j < 0 {
+		return nil, fmt.Errorf("%s: remote includes must specify a path in the repository (e.g. //path/to/file.yml)", entry)
+	}
+	url, path := rest[:offset+j], rest[offset+j+2:]
+	if url == "" || path == "" {
+		return nil, fmt.Errorf("%s: invalid remote include", entry)
+	}
+
+	// The path is matched relative to the checkout and must not refer to files outside it.
+	if filepath.IsAbs(path) || !filepath.IsLocal(filepath.FromSlash(path)) {
+		return nil, fmt.Errorf("%s: the path in the repository must be a relative path within the repository", entry)
+	}
+
+	return &remoteInclude{
+		entry: entry,
+		url:   url,
+		path:  path,
+		ref:   ref,
+	}, nil
+}
+
+// remoteIncludeCacheDir returns the directory that holds checkouts of remote includes.
+func remoteIncludeCacheDir(ctx context.Context, root string) string {
+	if dir, ok := env.IncludeCacheDir(ctx); ok && dir != "" {
+		return dir
+	}
+	return filepath.Join(root, ".databricks", "bundle", "includes")
+}
+
+// errRemoteIncludeNotCached is returned by fetch in offline mode if
+// the repository has not been cloned into the cache directory yet.
+var errRemoteIncludeNotCached = errors.New("remote include is not cached")
+
+// fetch returns the path to a checkout of the repository at the pinned reference.
+// The repository is only cloned if it isn't already present in the cache directory,
+// or if the mode is [RemoteIncludesUpdate] so that branch references are refreshed.
+func (r *remoteInclude) fetch(ctx context.Context, cacheDir string, mode RemoteIncludeMode) (string, error) {
+	sum := sha256.Sum256([]byte(r.url + "@" + r.ref))
+	dir := filepath.Join(cacheDir, hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(dir); err == nil && mode != RemoteIncludesUpdate {
+		log.Debugf(ctx, "Using cached checkout of %s@%s at %s", r.url, r.ref, dir)
+		return dir, nil
+	}
+
+	if mode == RemoteIncludesOffline {
+		return "", errRemoteIncludeNotCached
+	}
+
+	err := os.MkdirAll(cacheDir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	// Clone into a temporary directory first so that an interrupted
+	// clone doesn't leave a partial checkout in the cache.
+	tmp, err := os.MkdirTemp(cacheDir, "clone-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	log.Infof(ctx, "Cloning %s@%s", r.url, r.ref)
+	err = git.Clone(ctx, r.url, r.ref, filepath.Join(tmp, "repo"))
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", r.entry, err)
+	}
+
+	// Replace a previous checkout of the same reference.
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.Rename(filepath.Join(tmp, "repo"), dir)
+	if err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// hashFiles computes a hash over the names and contents of the specified files.
+// The paths are relative to [root] and are hashed in sorted order.
+func hashFiles(root string, paths []string) (string, error) {
+	paths = slices.Clone(paths)
+	slices.Sort(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		f, err := os.Open(filepath.Join(root, path))
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(path))
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// resolve fetches the repository and returns the checkout directory and the paths of the
+// matching files relative to it. It verifies the hash of these files against the lockfile.
+// In [RemoteIncludesUpdate] mode, it records the hash in the lockfile instead and
+// returns true if the lockfile was changed.
+func (r *remoteInclude) resolve(ctx context.Context, cacheDir string, lock *lockfile.Lockfile, mode RemoteIncludeMode) (string, []string, bool, error) {
+	dir, err := r.fetch(ctx, cacheDir, mode)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, r.path))
+	if err != nil {
+		return "", nil, false, err
+	}
+	if len(matches) == 0 {
+		return "", nil, false, fmt.Errorf("%s defined in 'include' section does not match any files", r.entry)
+	}
+
+	var paths []string
+	for _, match := range matches {
+		rel, err := filepath.Rel(dir, match)
+		if err != nil {
+			return "", nil, false, err
+		}
+		paths = append(paths, rel)
+	}
+	slices.Sort(paths)
+
+	hash, err := hashFiles(dir, paths)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	locked, ok := lock.Includes[r.entry]
+	if ok && locked.Hash == hash {
+		return dir, paths, false, nil
+	}
+
+	if mode != RemoteIncludesUpdate {
+		// Loading the configuration offline (e.g. in an editor) doesn't
+		// require the lockfile to be complete; deployments still do.
+		if !ok && mode == RemoteIncludesOffline {
+			return dir, paths, false, nil
+		}
+		if !ok {
+			return "", nil, false, fmt.Errorf("%s: no hash recorded in %s; run \"databricks bundle deploy --update-lock\" to record it", r.entry, lockfile.FileName)
+		}
+		return "", nil, false, fmt.Errorf("%s: hash %s does not match hash %s recorded in %s", r.entry, hash, locked.Hash, lockfile.FileName)
+	}
+
+	if lock.Includes == nil {
+		lock.Includes = make(map[string]lockfile.Include)
+	}
+	lock.Includes[r.entry] = lockfile.Include{
+		Url:  r.url,
+		Ref:  r.ref,
+		Path: r.path,
+		Hash: hash,
+	}
+	return dir, paths, true, nil
+}
+
+// fileEntry returns the name of a single file from this include for observability.
+func (r *remoteInclude) fileEntry(path string) string {
+	return fmt.Sprintf("%s%s//%s@%s", remoteIncludePrefix, r.url, filepath.ToSlash(path), r.ref)
+}
@@ -0,0 +1,39 @@
+package loader
+
+import "context"
+
+// RemoteIncludeMode determines how remote includes are resolved.
+type RemoteIncludeMode int
+
+const (
+	// RemoteIncludesVerify fetches remote includes that are not cached yet and
+	// verifies them against the lockfile. Includes without an entry in the lockfile
+	// are an error. The lockfile is never written in this mode.
+	RemoteIncludesVerify RemoteIncludeMode = iota
+
+	// RemoteIncludesUpdate fetches remote includes, refreshing cached checkouts,
+	// and records their hashes in the lockfile.
+	RemoteIncludesUpdate
+
+	// RemoteIncludesOffline only uses cached checkouts of remote includes.
+	// Includes that are not cached are skipped with a warning.
+	// The lockfile is never written in this mode.
+	RemoteIncludesOffline
+)
+
+type remoteIncludeModeKey struct{}
+
+// WithRemoteIncludeMode returns a context that resolves remote includes in the specified mode.
+func WithRemoteIncludeMode(ctx context.Context, mode RemoteIncludeMode) context.Context {
+	return context.WithValue(ctx, remoteIncludeModeKey{}, mode)
+}
+
+// GetRemoteIncludeMode returns the mode to resolve remote includes in.
+// It defaults to [RemoteIncludesVerify].
+func GetRemoteIncludeMode(ctx context.Context) RemoteIncludeMode {
+	mode, ok := ctx.Value(remoteIncludeModeKey{}).(RemoteIncludeMode)
+	if !ok {
+		return RemoteIncludesVerify
+	}
+	return mode
+}
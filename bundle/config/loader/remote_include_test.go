@@ -0,0 +1,272 @@
+package loader
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/lockfile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteInclude(t *testing.T) {
+	r, err := parseRemoteInclude("git::https://github.com/org/repo.git//bundles/*.yml@v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/repo.git", r.url)
+	assert.Equal(t, "bundles/*.yml", r.path)
+	assert.Equal(t, "v1.2.0", r.ref)
+
+	r, err = parseRemoteInclude("git::git@github.com:org/repo.git//a.yml@main")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:org/repo.git", r.url)
+	assert.Equal(t, "a.yml", r.path)
+	assert.Equal(t, "main", r.ref)
+}
+
+func TestParseRemoteIncludeErrors(t *testing.T) {
+	_, err := parseRemoteInclude("git::https://github.com/org/repo.git//a.yml")
+	assert.ErrorContains(t, err, "must be pinned to a reference")
+
+	_, err = parseRemoteInclude("git::https://github.com/org/repo.git@v1")
+	assert.ErrorContains(t, err, "must specify a path in the repository")
+
+	for _, entry := range []string{
+		"git::https://github.com/org/repo.git//../../etc/*.yml@v1",
+		"git::https://github.com/org/repo.git//bundles/../../a.yml@v1",
+		"git::https://github.com/org/repo.git///etc/a.yml@v1",
+	} {
+		_, err = parseRemoteInclude(entry)
+		assert.ErrorContains(t, err, "must be a relative path within the repository", entry)
+	}
+}
+
+func TestCheckUnsupportedRemoteInclude(t *testing.T) {
+	assert.NoError(t, checkUnsupportedRemoteInclude("resources/*.yml"))
+	assert.NoError(t, checkUnsupportedRemoteInclude("git::https://github.com/org/repo.git//a.yml@v1"))
+
+	err := checkUnsupportedRemoteInclude("https://example.com/bundles/a.yml")
+	assert.ErrorContains(t, err, "only remote includes from Git repositories are supported")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func createRemoteRepository(t *testing.T) string {
+	dir := t.TempDir()
+	runGit(t, dir, "init", "--initial-branch=main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "host.yml"), []byte("workspace:\n  host: https://remote\n"), 0644))
+	runGit(t, dir, "add", "host.yml")
+	runGit(t, dir, "commit", "-m", "initial")
+	runGit(t, dir, "tag", "v1")
+	return dir
+}
+
+func TestProcessRootIncludesRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := createRemoteRepository(t)
+	entry := "git::file://" + filepath.ToSlash(repo) + "//host.yml@v1"
+
+	root := t.TempDir()
+	cacheDir := t.TempDir()
+	t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", cacheDir)
+
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Include: []string{entry},
+		},
+	}
+
+	ctx := WithRemoteIncludeMode(context.Background(), RemoteIncludesUpdate)
+	diags := bundle.Apply(ctx, b, ProcessRootIncludes())
+	require.NoError(t, diags.Error())
+	assert.Equal(t, "https://remote", b.Config.Workspace.Host)
+
+	// The location of the included configuration points to the cached file.
+	loc := b.Config.GetLocation("workspace.host")
+	assert.True(t, filepath.IsAbs(loc.File))
+	rel, err := filepath.Rel(cacheDir, loc.File)
+	require.NoError(t, err)
+	assert.NotContains(t, rel, "..")
+
+	// The hash is recorded in the lockfile.
+	lock, err := lockfile.Load(root)
+	require.NoError(t, err)
+	require.Contains(t, lock.Includes, entry)
+	assert.Equal(t, "v1", lock.Includes[entry].Ref)
+
+	// Loading again verifies the hash and uses the cache, even if the repository is gone.
+	require.NoError(t, os.RemoveAll(repo))
+	b = &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Include: []string{entry},
+		},
+	}
+	diags = bundle.Apply(context.Background(), b, ProcessRootIncludes())
+	require.NoError(t, diags.Error())
+	assert.Equal(t, "https://remote", b.Config.Workspace.Host)
+}
+
+func TestProcessRootIncludesRemoteHashMismatch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := createRemoteRepository(t)
+	entry := "git::file://" + filepath.ToSlash(repo) + "//host.yml@v1"
+
+	root := t.TempDir()
+	t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", t.TempDir())
+
+	lock := &lockfile.Lockfile{
+		Includes: map[string]lockfile.Include{
+			entry: {Hash: "sha256:0000"},
+		},
+	}
+	require.NoError(t, lock.Save(root))
+
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Include: []string{entry},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, ProcessRootIncludes())
+	assert.ErrorContains(t, diags.Error(), "does not match hash sha256:0000 recorded in databricks.lock")
+}
+
+func TestProcessRootIncludesRemoteNotLocked(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := createRemoteRepository(t)
+	entry := "git::file://" + filepath.ToSlash(repo) + "//host.yml@v1"
+
+	root := t.TempDir()
+	t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", t.TempDir())
+
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Include: []string{entry},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, ProcessRootIncludes())
+	assert.ErrorContains(t, diags.Error(), "no hash recorded in databricks.lock")
+
+	// The lockfile is not written.
+	assert.NoFileExists(t, filepath.Join(root, lockfile.FileName))
+}
+
+func TestProcessRootIncludesRemoteOffline(t *testing.T) {
+	entry := "git::file:///does/not/exist//host.yml@v1"
+
+	root := t.TempDir()
+	t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", t.TempDir())
+
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Include: []string{entry},
+		},
+	}
+
+	ctx := WithRemoteIncludeMode(context.Background(), RemoteIncludesOffline)
+	diags := bundle.Apply(ctx, b, ProcessRootIncludes())
+	require.NoError(t, diags.Error())
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, "skipped because it has not been fetched yet")
+	assert.NoFileExists(t, filepath.Join(root, lockfile.FileName))
+}
+
+func TestProcessRootIncludesRemoteUpdateRefreshesBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := createRemoteRepository(t)
+	entry := "git::file://" + filepath.ToSlash(repo) + "//host.yml@main"
+
+	root := t.TempDir()
+	t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", t.TempDir())
+
+	load := func(ctx context.Context) (*bundle.Bundle, error) {
+		b := &bundle.Bundle{
+			RootPath: root,
+			Config: config.Root{
+				Include: []string{entry},
+			},
+		}
+		diags := bundle.Apply(ctx, b, ProcessRootIncludes())
+		return b, diags.Error()
+	}
+
+	ctx := WithRemoteIncludeMode(context.Background(), RemoteIncludesUpdate)
+	_, err := load(ctx)
+	require.NoError(t, err)
+
+	// Move the branch.
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "host.yml"), []byte("workspace:\n  host: https://updated\n"), 0644))
+	runGit(t, repo, "commit", "-am", "update")
+
+	// Without updating, the cached checkout is used.
+	b, err := load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://remote", b.Config.Workspace.Host)
+
+	// Updating refreshes the checkout and records the new hash.
+	b, err = load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "https://updated", b.Config.Workspace.Host)
+
+	b, err = load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://updated", b.Config.Workspace.Host)
+}
+
+func TestProcessRootIncludesUpdateDropsStaleRemoteIncludes(t *testing.T) {
+	root := t.TempDir()
+	stale := "git::https://github.com/org/repo.git//a.yml@v1"
+	lock := &lockfile.Lockfile{
+		Version: lockfile.Version,
+		Includes: map[string]lockfile.Include{
+			stale: {Url: "https://github.com/org/repo.git", Ref: "v1", Path: "a.yml", Hash: "sha256:abc"},
+		},
+	}
+	require.NoError(t, lock.Save(root))
+
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config:   config.Root{},
+	}
+
+	// Without updating, the lockfile is left as is.
+	diags := bundle.Apply(context.Background(), b, ProcessRootIncludes())
+	require.NoError(t, diags.Error())
+	lock, err := lockfile.Load(root)
+	require.NoError(t, err)
+	assert.Contains(t, lock.Includes, stale)
+
+	ctx := WithRemoteIncludeMode(context.Background(), RemoteIncludesUpdate)
+	diags = bundle.Apply(ctx, b, ProcessRootIncludes())
+	require.NoError(t, diags.Error())
+	lock, err = lockfile.Load(root)
+	require.NoError(t, err)
+	assert.Empty(t, lock.Includes)
+}
@@ -0,0 +1,238 @@
+package mutator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/cli/libs/dyn/dynvar"
+)
+
+type applyConditions struct{}
+
+// ApplyConditions evaluates the conditions on resources and tasks and removes
+// the ones whose condition evaluates to false from the configuration.
+//
+// It must run before variable references are resolved. Conditions are parsed
+// before the references in their operands are replaced by the resolved values,
+// so that the values are compared as they are.
+func ApplyConditions() bundle.Mutator {
+	return &applyConditions{}
+}
+
+func (m *applyConditions) Name() string {
+	return "ApplyConditions"
+}
+
+func (m *applyConditions) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := b.Config.Mutate(func(root dyn.Value) (dyn.Value, error) {
+		if root.Get("resources").Kind() != dyn.KindMap {
+			return root, nil
+		}
+
+		resolve, err := conditionResolver(b.Config, root)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		return dyn.Map(root, "resources", dyn.Foreach(func(p dyn.Path, group dyn.Value) (dyn.Value, error) {
+			if group.Kind() != dyn.KindMap {
+				return group, nil
+			}
+
+			return filterMapping(group, func(pk string, resource dyn.Value) (dyn.Value, bool, error) {
+				path := p.Append(dyn.Key(pk))
+				keep, d, err := checkCondition(resource.Get("condition"), path, fmt.Sprintf("%s.%s", p[len(p)-1].Key(), pk), resolve)
+				diags = diags.Extend(d)
+				if err != nil || !keep {
+					return dyn.InvalidValue, false, err
+				}
+
+				resource, d, err = applyTaskConditions(resource, path, resolve)
+				diags = diags.Extend(d)
+				if err != nil {
+					return dyn.InvalidValue, false, err
+				}
+
+				// Conditions have been evaluated and are no longer needed.
+				resource, err = dropKeys(resource, "condition", "task_conditions")
+				return resource, true, err
+			})
+		}))
+	})
+
+	if err != nil {
+		return diags.Extend(diag.FromErr(err))
+	}
+
+	return diags
+}
+
+// conditionResolver returns a function that resolves the variable references in an
+// operand of a condition. References are resolved the same way as they are in the
+// rest of the configuration, but only to the bundle, workspace and variables keys.
+func conditionResolver(cfg config.Root, root dyn.Value) (func(string) (string, error), error) {
+	r := &resolveVariableReferences{
+		prefixes: []string{"bundle", "workspace", "variables"},
+		lookupFn: lookup,
+	}
+
+	resolved, err := r.resolve(cfg, root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include fields that aren't set, like [resolveVariableReferences] does.
+	resolved, _ = convert.Normalize(cfg, resolved, convert.IncludeMissingFields)
+
+	prefixes := make([]dyn.Path, len(r.prefixes))
+	for i, prefix := range r.prefixes {
+		prefixes[i] = dyn.MustPathFromString(prefix)
+	}
+
+	return func(s string) (string, error) {
+		v, err := dynvar.Resolve(dyn.V(s), func(path dyn.Path) (dyn.Value, error) {
+			path = expandVariableShorthand(path)
+			for _, prefix := range prefixes {
+				if path.HasPrefix(prefix) {
+					return lookup(resolved, path)
+				}
+			}
+			return dyn.InvalidValue, fmt.Errorf("conditions can only reference bundle, workspace and variables: ${%s}", path)
+		})
+		if err != nil {
+			return "", err
+		}
+
+		switch v.Kind() {
+		case dyn.KindNil:
+			return "", nil
+		case dyn.KindString, dyn.KindBool, dyn.KindInt, dyn.KindFloat:
+			return fmt.Sprint(v.AsAny()), nil
+		default:
+			return "", fmt.Errorf("%s does not resolve to a scalar value", s)
+		}
+	}, nil
+}
+
+// checkCondition evaluates the condition (if any) and returns whether
+// the element it belongs to must be kept.
+func checkCondition(v dyn.Value, path dyn.Path, name string, resolve func(string) (string, error)) (bool, diag.Diagnostics, error) {
+	if !v.IsValid() || v.Kind() == dyn.KindNil {
+		return true, nil, nil
+	}
+
+	s, ok := v.AsString()
+	if !ok {
+		return false, nil, fmt.Errorf("%s: condition of %s must be a string", v.Location(), name)
+	}
+
+	keep, err := evaluateCondition(s, resolve)
+	if err != nil {
+		return false, nil, fmt.Errorf("%s: unable to evaluate condition of %s: %w", v.Location(), name, err)
+	}
+
+	if keep {
+		return true, nil, nil
+	}
+
+	return false, diag.Diagnostics{{
+		Severity: diag.Info,
+		Summary:  fmt.Sprintf("skipping %s because its condition %q evaluated to false", name, s),
+		Location: v.Location(),
+		Path:     path,
+		ID:       config.ConditionSkipped,
+	}}, nil
+}
+
+// applyTaskConditions removes the tasks of a job whose condition evaluates to false.
+func applyTaskConditions(job dyn.Value, path dyn.Path, resolve func(string) (string, error)) (dyn.Value, diag.Diagnostics, error) {
+	conditions, ok := job.Get("task_conditions").AsMap()
+	if !ok {
+		return job, nil, nil
+	}
+
+	tasks, ok := job.Get("tasks").AsSequence()
+	if !ok {
+		return job, nil, nil
+	}
+
+	var diags diag.Diagnostics
+	var out []dyn.Value
+	var skipped []string
+	for i, task := range tasks {
+		key, _ := task.Get("task_key").AsString()
+		condition, ok := conditions.GetByString(key)
+		if !ok {
+			out = append(out, task)
+			continue
+		}
+
+		tpath := path.Append(dyn.Key("tasks"), dyn.Index(i))
+		keep, d, err := checkCondition(condition, tpath, fmt.Sprintf("task %s", key), resolve)
+		diags = diags.Extend(d)
+		if err != nil {
+			return dyn.InvalidValue, diags, err
+		}
+		if keep {
+			out = append(out, task)
+		} else {
+			skipped = append(skipped, key)
+		}
+	}
+
+	// Warn about tasks that depend on a task that was skipped.
+	for _, task := range out {
+		deps, _ := task.Get("depends_on").AsSequence()
+		for _, dep := range deps {
+			key, _ := dep.Get("task_key").AsString()
+			if !slices.Contains(skipped, key) {
+				continue
+			}
+			tkey, _ := task.Get("task_key").AsString()
+			diags = diags.Append(diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("task %s depends on task %s which is skipped because of its condition", tkey, key),
+				Location: dep.Location(),
+				Path:     path,
+				ID:       config.SkippedTaskDependency,
+			})
+		}
+	}
+
+	job, err := dyn.Set(job, "tasks", dyn.NewValue(out, job.Get("tasks").Location()))
+	return job, diags, err
+}
+
+// filterMapping returns a copy of the mapping with the values returned by fn.
+// Values for which fn returns false are removed.
+func filterMapping(v dyn.Value, fn func(string, dyn.Value) (dyn.Value, bool, error)) (dyn.Value, error) {
+	out := dyn.NewMapping()
+	for _, pair := range v.MustMap().Pairs() {
+		nv, keep, err := fn(pair.Key.MustString(), pair.Value)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		if keep {
+			out.Set(pair.Key, nv)
+		}
+	}
+	return dyn.NewValue(out, v.Location()), nil
+}
+
+// dropKeys returns a copy of the mapping without the specified keys.
+func dropKeys(v dyn.Value, keys ...string) (dyn.Value, error) {
+	if v.Kind() != dyn.KindMap {
+		return v, nil
+	}
+	return filterMapping(v, func(k string, v dyn.Value) (dyn.Value, bool, error) {
+		return v, !slices.Contains(keys, k), nil
+	})
+}
@@ -0,0 +1,67 @@
+package mutator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/config/variable"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConditionsResolvesOperandsAfterParsing(t *testing.T) {
+	empty := ""
+	quoted := `it's "quoted" == (a && b)`
+	env := "${bundle.target}"
+
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Target: "dev",
+			},
+			Variables: map[string]*variable.Variable{
+				"empty":  {Value: &empty},
+				"quoted": {Value: &quoted},
+				"env":    {Value: &env},
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"empty":     {Condition: "${var.empty} == prod", JobSettings: &jobs.JobSettings{Name: "job"}},
+					"quoted":    {Condition: "${var.quoted} == ${var.quoted}", JobSettings: &jobs.JobSettings{Name: "job"}},
+					"reference": {Condition: "${var.env} == dev", JobSettings: &jobs.JobSettings{Name: "job"}},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, ApplyConditions())
+	require.NoError(t, diags.Error())
+
+	assert.NotContains(t, b.Config.Resources.Jobs, "empty")
+	assert.Contains(t, b.Config.Resources.Jobs, "quoted")
+	assert.Contains(t, b.Config.Resources.Jobs, "reference")
+
+	infos := diags.Filter(diag.Info)
+	require.Len(t, infos, 1)
+	assert.Equal(t, `skipping jobs.empty because its condition "${var.empty} == prod" evaluated to false`, infos[0].Summary)
+}
+
+func TestApplyConditionsUnsupportedReference(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job": {Condition: "${resources.jobs.other.id} == 1", JobSettings: &jobs.JobSettings{Name: "job"}},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, ApplyConditions())
+	assert.ErrorContains(t, diags.Error(), "conditions can only reference bundle, workspace and variables: ${resources.jobs.other.id}")
+}
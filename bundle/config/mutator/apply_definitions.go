@@ -0,0 +1,171 @@
+package mutator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+)
+
+type applyDefinitions struct{}
+
+// ApplyDefinitions merges the definitions listed in the "apply" field of
+// jobs, tasks and pipelines into them. Explicitly set fields take precedence.
+// Mappings are merged key by key, while other values, including sequences such
+// as libraries and notification recipients, replace the value of the definition.
+//
+// It must run after job tasks have been merged so that the fields of
+// a task override its fragments regardless of where the task is defined.
+func ApplyDefinitions() bundle.Mutator {
+	return &applyDefinitions{}
+}
+
+func (m *applyDefinitions) Name() string {
+	return "ApplyDefinitions"
+}
+
+func (m *applyDefinitions) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := b.Config.Mutate(func(root dyn.Value) (dyn.Value, error) {
+		definitions := root.Get("definitions")
+
+		// Apply definitions to jobs and their tasks.
+		root, err := dyn.MapByPattern(
+			root,
+			dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey()),
+			func(p dyn.Path, job dyn.Value) (dyn.Value, error) {
+				job, err := applyTaskDefinitions(definitions, job)
+				if err != nil {
+					return dyn.InvalidValue, err
+				}
+				return applyDefinitionsTo(definitions, job)
+			},
+		)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		// Apply definitions to pipelines.
+		root, err = dyn.MapByPattern(
+			root,
+			dyn.NewPattern(dyn.Key("resources"), dyn.Key("pipelines"), dyn.AnyKey()),
+			func(p dyn.Path, pipeline dyn.Value) (dyn.Value, error) {
+				return applyDefinitionsTo(definitions, pipeline)
+			},
+		)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		// Fragments are not normalized when they are loaded.
+		// Normalize the result to surface fields that are invalid in the place they were applied.
+		root, diags = convert.Normalize(b.Config, root)
+		return root, nil
+	})
+
+	return diags.Extend(diag.FromErr(err))
+}
+
+// applyDefinitionsTo merges the definitions listed in the "apply" field of [v] into [v].
+func applyDefinitionsTo(definitions, v dyn.Value) (dyn.Value, error) {
+	names, ok := v.Get("apply").AsSequence()
+	if !ok {
+		return v, nil
+	}
+
+	v, err := dropKeys(v, "apply")
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	return mergeDefinitions(definitions, names, v)
+}
+
+// applyTaskDefinitions merges the definitions listed for every task in the
+// "task_apply" field of a job into the task with the corresponding key.
+func applyTaskDefinitions(definitions, job dyn.Value) (dyn.Value, error) {
+	taskApply, ok := job.Get("task_apply").AsMap()
+	if !ok {
+		return job, nil
+	}
+
+	job, err := dropKeys(job, "task_apply")
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	if job.Get("tasks").Kind() != dyn.KindSequence {
+		return job, nil
+	}
+
+	return dyn.Map(job, "tasks", dyn.Foreach(func(_ dyn.Path, task dyn.Value) (dyn.Value, error) {
+		key, _ := task.Get("task_key").AsString()
+		names, ok := taskApply.GetByString(key)
+		if !ok {
+			return task, nil
+		}
+
+		seq, _ := names.AsSequence()
+		return mergeDefinitions(definitions, seq, task)
+	}))
+}
+
+// mergeDefinitions merges the named definitions in order and then merges [v] on top.
+// The location of [v] is retained so that paths relative to it continue to resolve.
+func mergeDefinitions(definitions dyn.Value, names []dyn.Value, v dyn.Value) (dyn.Value, error) {
+	out := dyn.NilValue
+	for _, name := range names {
+		s, _ := name.AsString()
+		fragment := definitions.Get(s)
+		if !fragment.IsValid() {
+			return dyn.InvalidValue, fmt.Errorf("%s: definition %q is not defined", name.Location(), s)
+		}
+		if fragment.Kind() != dyn.KindMap {
+			return dyn.InvalidValue, fmt.Errorf("%s: definition %q must be a map", fragment.Location(), s)
+		}
+
+		var err error
+		out, err = overlay(out, fragment)
+		if err != nil {
+			return dyn.InvalidValue, fmt.Errorf("%s: unable to apply definition %q: %w", name.Location(), s, err)
+		}
+	}
+
+	out, err := overlay(out, v)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	return out.WithLocation(v.Location()), nil
+}
+
+// overlay returns [b] merged on top of [a]. Mappings are merged recursively.
+// Any other value in [b] replaces the value in [a], unless it is nil.
+func overlay(a, b dyn.Value) (dyn.Value, error) {
+	switch {
+	case a.Kind() == dyn.KindInvalid || a.Kind() == dyn.KindNil:
+		return b, nil
+	case b.Kind() == dyn.KindInvalid || b.Kind() == dyn.KindNil:
+		return a, nil
+	case a.Kind() != dyn.KindMap || b.Kind() != dyn.KindMap:
+		return b, nil
+	}
+
+	out := a.MustMap().Clone()
+	for _, pair := range b.MustMap().Pairs() {
+		existing, _ := out.Get(pair.Key)
+		nv, err := overlay(existing, pair.Value)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		err = out.Set(pair.Key, nv)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+	}
+	return dyn.NewValue(out, b.Location()), nil
+}
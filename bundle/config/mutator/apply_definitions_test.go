@@ -0,0 +1,148 @@
+package mutator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefinitionsOrder(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Definitions: map[string]dyn.Value{
+				"first": dyn.V(map[string]dyn.Value{
+					"name":                dyn.V("first"),
+					"max_concurrent_runs": dyn.V(1),
+				}),
+				"second": dyn.V(map[string]dyn.Value{
+					"name": dyn.V("second"),
+				}),
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job1": {
+						Apply:       []string{"first", "second"},
+						JobSettings: &jobs.JobSettings{Description: "job1"},
+					},
+					"job2": {
+						Apply: []string{"first", "second"},
+						JobSettings: &jobs.JobSettings{
+							Name: "job2",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, mutator.ApplyDefinitions())
+	require.NoError(t, diags.Error())
+
+	assert.Equal(t, "second", b.Config.Resources.Jobs["job1"].Name)
+	assert.Equal(t, 1, b.Config.Resources.Jobs["job1"].MaxConcurrentRuns)
+	assert.Equal(t, "job2", b.Config.Resources.Jobs["job2"].Name)
+	assert.Equal(t, 1, b.Config.Resources.Jobs["job2"].MaxConcurrentRuns)
+}
+
+func TestApplyDefinitionsUndefined(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job1": {
+						Apply:       []string{"missing"},
+						JobSettings: &jobs.JobSettings{Description: "job1"},
+					},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, mutator.ApplyDefinitions())
+	assert.ErrorContains(t, diags.Error(), `definition "missing" is not defined`)
+}
+
+func TestApplyDefinitionsInvalidField(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Definitions: map[string]dyn.Value{
+				"invalid": dyn.V(map[string]dyn.Value{
+					"no_such_field": dyn.V("value"),
+				}),
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job1": {
+						Apply:       []string{"invalid"},
+						JobSettings: &jobs.JobSettings{Description: "job1"},
+					},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, mutator.ApplyDefinitions())
+	require.NoError(t, diags.Error())
+	require.Len(t, diags, 1)
+	assert.Equal(t, "unknown field: no_such_field", diags[0].Summary)
+}
+
+func TestApplyDefinitionsExplicitSequencesReplaceDefinition(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Definitions: map[string]dyn.Value{
+				"notifications": dyn.V(map[string]dyn.Value{
+					"email_notifications": dyn.V(map[string]dyn.Value{
+						"on_failure": dyn.V([]dyn.Value{dyn.V("team@example.com")}),
+					}),
+					"tags": dyn.V(map[string]dyn.Value{
+						"team": dyn.V("data"),
+					}),
+				}),
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job1": {
+						Apply: []string{"notifications"},
+						JobSettings: &jobs.JobSettings{
+							EmailNotifications: &jobs.JobEmailNotifications{
+								OnFailure: []string{"oncall@example.com"},
+							},
+							Tags: map[string]string{"env": "prod"},
+						},
+					},
+					"job2": {
+						Apply: []string{"notifications"},
+						JobSettings: &jobs.JobSettings{
+							EmailNotifications: &jobs.JobEmailNotifications{
+								OnSuccess: []string{"owner@example.com"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, mutator.ApplyDefinitions())
+	require.NoError(t, diags.Error())
+
+	// Sequences that are set explicitly replace the sequences of the definition.
+	job1 := b.Config.Resources.Jobs["job1"]
+	assert.Equal(t, []string{"oncall@example.com"}, job1.EmailNotifications.OnFailure)
+
+	// Mappings are merged key by key.
+	assert.Equal(t, map[string]string{"team": "data", "env": "prod"}, job1.Tags)
+
+	job2 := b.Config.Resources.Jobs["job2"]
+	assert.Equal(t, []string{"team@example.com"}, job2.EmailNotifications.OnFailure)
+	assert.Equal(t, []string{"owner@example.com"}, job2.EmailNotifications.OnSuccess)
+}
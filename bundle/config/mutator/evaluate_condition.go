@@ -0,0 +1,202 @@
+package mutator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/databricks/cli/libs/dyn/dynvar"
+)
+
+// evaluateCondition evaluates a condition.
+//
+// The condition is parsed before its variable references are resolved, so that
+// values containing spaces, quotes or operators, and empty values, are compared
+// as they are. The resolve function returns the value of an operand that contains
+// variable references.
+//
+// The grammar is intentionally small:
+//
+//	expr       := and ( "||" and )*
+//	and        := unary ( "&&" unary )*
+//	unary      := "!" unary | "(" expr ")" | comparison
+//	comparison := operand ( ( "==" | "!=" ) operand )?
+//
+// Operands are either bare words or strings quoted with single or double quotes.
+// Operands that are not part of a comparison must be "true" or "false".
+func evaluateCondition(s string, resolve func(string) (string, error)) (bool, error) {
+	tokens, err := tokenizeCondition(s)
+	if err != nil {
+		return false, err
+	}
+
+	p := &conditionParser{tokens: tokens, resolve: resolve}
+	out, err := p.parseOr()
+	if err != nil {
+		return false, err
+	}
+	if p.pos < len(p.tokens) {
+		return false, fmt.Errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return out, nil
+}
+
+type conditionToken struct {
+	text string
+
+	// Set if the token is an operand (as opposed to an operator).
+	operand bool
+}
+
+var conditionOperators = []string{"==", "!=", "&&", "||", "!", "(", ")"}
+
+func tokenizeCondition(s string) ([]conditionToken, error) {
+	var tokens []conditionToken
+
+outer:
+	for i := 0; i < len(s); {
+		c := s[i]
+		if unicode.IsSpace(rune(c)) {
+			i++
+			continue
+		}
+
+		// Quoted operand.
+		if c == '\'' || c == '"' {
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string starting at offset %d", i)
+			}
+			tokens = append(tokens, conditionToken{text: s[i+1 : i+1+end], operand: true})
+			i += end + 2
+			continue
+		}
+
+		for _, op := range conditionOperators {
+			if strings.HasPrefix(s[i:], op) {
+				tokens = append(tokens, conditionToken{text: op})
+				i += len(op)
+				continue outer
+			}
+		}
+
+		// Bare operand; runs until whitespace or the start of an operator.
+		j := i
+		for j < len(s) && !unicode.IsSpace(rune(s[j])) && !strings.ContainsRune("=!&|()'\"", rune(s[j])) {
+			j++
+		}
+		if j == i {
+			return nil, fmt.Errorf("unexpected %q at offset %d", s[i], i)
+		}
+		tokens = append(tokens, conditionToken{text: s[i:j], operand: true})
+		i = j
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty condition")
+	}
+
+	return tokens, nil
+}
+
+type conditionParser struct {
+	tokens  []conditionToken
+	pos     int
+	resolve func(string) (string, error)
+}
+
+func (p *conditionParser) peek(op string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].operand && p.tokens[p.pos].text == op
+}
+
+func (p *conditionParser) parseOr() (bool, error) {
+	out, err := p.parseAnd()
+	if err != nil {
+		return false, err
+	}
+	for p.peek("||") {
+		p.pos++
+		rhs, err := p.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		out = out || rhs
+	}
+	return out, nil
+}
+
+func (p *conditionParser) parseAnd() (bool, error) {
+	out, err := p.parseUnary()
+	if err != nil {
+		return false, err
+	}
+	for p.peek("&&") {
+		p.pos++
+		rhs, err := p.parseUnary()
+		if err != nil {
+			return false, err
+		}
+		out = out && rhs
+	}
+	return out, nil
+}
+
+func (p *conditionParser) parseUnary() (bool, error) {
+	switch {
+	case p.peek("!"):
+		p.pos++
+		out, err := p.parseUnary()
+		return !out, err
+	case p.peek("("):
+		p.pos++
+		out, err := p.parseOr()
+		if err != nil {
+			return false, err
+		}
+		if !p.peek(")") {
+			return false, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return out, nil
+	}
+
+	lhs, err := p.operand()
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case p.peek("=="):
+		p.pos++
+		rhs, err := p.operand()
+		return lhs == rhs, err
+	case p.peek("!="):
+		p.pos++
+		rhs, err := p.operand()
+		return lhs != rhs, err
+	}
+
+	switch strings.ToLower(lhs) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected a boolean or a comparison, found %q", lhs)
+	}
+}
+
+func (p *conditionParser) operand() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("unexpected end of condition")
+	}
+	t := p.tokens[p.pos]
+	if !t.operand {
+		return "", fmt.Errorf("unexpected %q", t.text)
+	}
+	p.pos++
+	if !dynvar.ContainsVariableReference(t.text) {
+		return t.text, nil
+	}
+	return p.resolve(t.text)
+}
@@ -0,0 +1,86 @@
+package mutator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected bool
+	}{
+		{"true", true},
+		{"FALSE", false},
+		{"prod == prod", true},
+		{"prod != prod", false},
+		{"'my value' == \"my value\"", true},
+		{"!(dev == prod)", true},
+		{"dev == prod || true", true},
+		{"dev == dev && false", false},
+		{"false && false || true", true},
+		{"'' == ''", true},
+	} {
+		out, err := evaluateCondition(tc.input, nil)
+		if assert.NoError(t, err, tc.input) {
+			assert.Equal(t, tc.expected, out, tc.input)
+		}
+	}
+}
+
+func TestEvaluateConditionErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"", "empty condition"},
+		{"prod", `expected a boolean or a comparison, found "prod"`},
+		{"(true", "missing closing parenthesis"},
+		{"true true", `unexpected "true"`},
+		{"a ==", "unexpected end of condition"},
+		{"'a == b", "unterminated string"},
+	} {
+		_, err := evaluateCondition(tc.input, nil)
+		assert.ErrorContains(t, err, tc.err, tc.input)
+	}
+}
+
+func TestEvaluateConditionResolvesOperandsAfterParsing(t *testing.T) {
+	values := map[string]string{
+		"${var.empty}":  "",
+		"${var.spaces}": "my value",
+		"${var.quotes}": `it's "quoted"`,
+		"${var.tokens}": "a == b && (c || !d)",
+		"${var.true}":   "true",
+	}
+	resolve := func(s string) (string, error) {
+		v, ok := values[s]
+		if !ok {
+			return "", fmt.Errorf("unknown reference %s", s)
+		}
+		return v, nil
+	}
+
+	for _, tc := range []struct {
+		input    string
+		expected bool
+	}{
+		{"${var.empty} == prod", false},
+		{"${var.empty} == ''", true},
+		{"${var.spaces} == 'my value'", true},
+		{"${var.quotes} == ${var.quotes} && ${var.quotes} != ''", true},
+		{"${var.tokens} == 'a == b && (c || !d)'", true},
+		{"${var.true}", true},
+		{"!${var.true}", false},
+	} {
+		out, err := evaluateCondition(tc.input, resolve)
+		if assert.NoError(t, err, tc.input) {
+			assert.Equal(t, tc.expected, out, tc.input)
+		}
+	}
+
+	_, err := evaluateCondition("${var.unknown} == prod", resolve)
+	assert.ErrorContains(t, err, "unknown reference ${var.unknown}")
+}
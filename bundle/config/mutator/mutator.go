@@ -26,5 +26,6 @@ func DefaultMutators() []bundle.Mutator {
 		DefineDefaultTarget(),
 		LoadGitDetails(),
 		pythonmutator.PythonMutator(pythonmutator.PythonMutatorPhaseLoad),
+		pythonmutator.ConfigGenerators(pythonmutator.PythonMutatorPhaseLoad),
 	}
 }
@@ -146,17 +146,20 @@ func validateProductionMode(ctx context.Context, b *bundle.Bundle, isPrincipalUs
 		log.Warnf(ctx, "target with 'mode: production' should specify an explicit 'targets.%s.git' configuration", env)
 	}
 
+	var diags diag.Diagnostics
 	r := b.Config.Resources
 	for i := range r.Pipelines {
 		if r.Pipelines[i].Development {
-			return diag.Errorf("target with 'mode: production' cannot include a pipeline with 'development: true'")
+			diags = diags.Extend(diag.Errorf("target with 'mode: production' cannot include a pipeline with 'development: true'"))
+			break
 		}
 	}
 
 	if !isPrincipalUsed && !isRunAsSet(r) {
-		return diag.Errorf("'run_as' must be set for all jobs when using 'mode: production'")
+		diags = diags.Extend(diag.Errorf("'run_as' must be set for all jobs when using 'mode: production'"))
 	}
-	return nil
+
+	return diags.Extend(validateProductionChecks(b))
 }
 
 // Determines whether run_as is explicitly set for all resources.
@@ -9,9 +9,11 @@ import (
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/tags"
 	sdkconfig "github.com/databricks/databricks-sdk-go/config"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/databricks/databricks-sdk-go/service/jobs"
 	"github.com/databricks/databricks-sdk-go/service/ml"
@@ -345,3 +347,106 @@ func TestDisableLockingDisabled(t *testing.T) {
 	require.Nil(t, err)
 	assert.True(t, b.Config.Bundle.Deployment.Lock.IsEnabled(), "Deployment lock should remain enabled in development mode when explicitly enabled")
 }
+
+func TestProcessTargetModeProductionChecksDisabledByDefault(t *testing.T) {
+	b := mockBundle(config.Production)
+	b.Config.Resources.Jobs["job1"].Tasks = []jobs.Task{{TaskKey: "a", ExistingClusterId: "abc"}}
+
+	diags := validateProductionMode(context.Background(), b, true)
+	require.NoError(t, diags.Error())
+}
+
+func TestProcessTargetModeProductionChecks(t *testing.T) {
+	b := mockBundle(config.Production)
+	b.Config.Validation.Production = config.ProductionChecks{
+		NoExistingClusters:          true,
+		RequireFailureNotifications: true,
+		RequireTaskRetries:          true,
+		NoUserLibraries:             true,
+		ProtectedBranches:           []string{"release/*"},
+	}
+	for _, job := range b.Config.Resources.Jobs {
+		job.EmailNotifications = &jobs.JobEmailNotifications{OnFailure: []string{"team@company.com"}}
+	}
+	b.Config.Resources.Jobs["job1"].Tasks = []jobs.Task{
+		{TaskKey: "a", ExistingClusterId: "abc", MaxRetries: 1},
+		{
+			TaskKey:   "b",
+			Libraries: []compute.Library{{Whl: "/Workspace/Users/lennart@company.com/dist/lib.whl"}},
+		},
+	}
+	b.Config.Resources.Jobs["job2"].EmailNotifications = nil
+
+	diags := validateProductionMode(context.Background(), b, true)
+	var ids []diag.ID
+	for _, d := range diags {
+		assert.Equal(t, diag.Error, d.Severity)
+		ids = append(ids, d.ID)
+	}
+	assert.Equal(t, []diag.ID{
+		config.ProductionExistingCluster,
+		config.ProductionFailureNotifications,
+		config.ProductionTaskRetries,
+		config.ProductionUserLibrary,
+		config.ProductionUnprotectedBranch,
+	}, ids)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].existing_cluster_id", diags[0].Path.String())
+	assert.Equal(t, "resources.jobs.job1.tasks[1].libraries[0].whl", diags[3].Path.String())
+	assert.Contains(t, diags[4].Summary, "branch main is not a protected branch")
+
+	b.Config.Bundle.Git.ActualBranch = "release/1.0"
+	diags = validateProductionMode(context.Background(), b, true)
+	for _, d := range diags {
+		assert.NotEqual(t, config.ProductionUnprotectedBranch, d.ID)
+	}
+}
+
+func TestProcessTargetModeProductionChecksForEachTask(t *testing.T) {
+	b := mockBundle(config.Production)
+	b.Config.Validation.Production = config.ProductionChecks{
+		NoExistingClusters: true,
+		RequireTaskRetries: true,
+		NoUserLibraries:    true,
+	}
+	b.Config.Resources.Jobs["job1"].Tasks = []jobs.Task{
+		{
+			TaskKey:    "a",
+			MaxRetries: 1,
+			ForEachTask: &jobs.ForEachTask{
+				Task: jobs.Task{
+					TaskKey:           "a_iteration",
+					ExistingClusterId: "abc",
+					Libraries:         []compute.Library{{Jar: "/Users/lennart@company.com/lib.jar"}},
+				},
+			},
+		},
+	}
+
+	diags := validateProductionMode(context.Background(), b, true)
+	require.Len(t, diags, 3)
+	assert.Equal(t, config.ProductionExistingCluster, diags[0].ID)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].for_each_task.task.existing_cluster_id", diags[0].Path.String())
+	assert.Equal(t, config.ProductionTaskRetries, diags[1].ID)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].for_each_task.task", diags[1].Path.String())
+	assert.Equal(t, config.ProductionUserLibrary, diags[2].ID)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].for_each_task.task.libraries[0].jar", diags[2].Path.String())
+}
+
+func TestProcessTargetModeProductionRequireGroupManage(t *testing.T) {
+	b := mockBundle(config.Production)
+	b.Config.Validation.Production.RequireGroupManage = true
+
+	diags := validateProductionMode(context.Background(), b, true)
+	require.Len(t, diags, 9)
+	assert.Equal(t, config.ProductionGroupManage, diags[0].ID)
+	assert.Equal(t, "resources.jobs.job1", diags[0].Path.String())
+
+	// Permissions for a user don't allow a team to manage the resources.
+	b.Config.Permissions = []resources.Permission{{Level: "CAN_MANAGE", UserName: "lennart@company.com"}}
+	diags = validateProductionMode(context.Background(), b, true)
+	require.Len(t, diags, 9)
+
+	b.Config.Permissions = append(b.Config.Permissions, resources.Permission{Level: "CAN_MANAGE", GroupName: "data-team"})
+	diags = validateProductionMode(context.Background(), b, true)
+	require.NoError(t, diags.Error())
+}
@@ -0,0 +1,249 @@
+package mutator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"golang.org/x/exp/maps"
+)
+
+// validateProductionChecks runs the checks that are enabled in the
+// 'validation.production' section for targets with 'mode: production'.
+func validateProductionChecks(b *bundle.Bundle) diag.Diagnostics {
+	checks := b.Config.Validation.Production
+
+	var diags diag.Diagnostics
+	if checks.NoExistingClusters {
+		diags = diags.Extend(checkNoExistingClusters(b))
+	}
+	if checks.RequireGroupManage {
+		diags = diags.Extend(checkGroupManage(b))
+	}
+	if checks.RequireFailureNotifications {
+		diags = diags.Extend(checkFailureNotifications(b))
+	}
+	if checks.RequireTaskRetries {
+		diags = diags.Extend(checkTaskRetries(b))
+	}
+	if checks.NoUserLibraries {
+		diags = diags.Extend(checkNoUserLibraries(b))
+	}
+	if len(checks.ProtectedBranches) > 0 {
+		diags = diags.Extend(checkProtectedBranch(b, checks.ProtectedBranches))
+	}
+	return diags
+}
+
+func productionError(b *bundle.Bundle, id diag.ID, p dyn.Path, format string, args ...any) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		ID:       id,
+		Summary:  fmt.Sprintf(format, args...),
+		Location: b.Config.GetLocation(p.String()),
+		Path:     p,
+	}
+}
+
+func taskPath(jobKey string, i int) dyn.Path {
+	return dyn.NewPath(dyn.Key("resources"), dyn.Key("jobs"), dyn.Key(jobKey), dyn.Key("tasks"), dyn.Index(i))
+}
+
+// forEachTask calls fn for every task of every job, including tasks nested in for_each_task.
+func forEachTask(b *bundle.Bundle, fn func(jobKey string, p dyn.Path, task *jobs.Task)) {
+	js := b.Config.Resources.Jobs
+	for _, k := range sortedKeys(js) {
+		for i := range js[k].Tasks {
+			task := &js[k].Tasks[i]
+			p := taskPath(k, i)
+			fn(k, p, task)
+			if task.ForEachTask != nil {
+				fn(k, p.Append(dyn.Key("for_each_task"), dyn.Key("task")), &task.ForEachTask.Task)
+			}
+		}
+	}
+}
+
+func checkNoExistingClusters(b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+	forEachTask(b, func(k string, p dyn.Path, task *jobs.Task) {
+		if task.ExistingClusterId == "" {
+			return
+		}
+		diags = append(diags, productionError(b, config.ProductionExistingCluster, p.Append(dyn.Key("existing_cluster_id")),
+			"task %s in job %s must not use existing_cluster_id when using 'mode: production'", task.TaskKey, k))
+	})
+	return diags
+}
+
+func checkGroupManage(b *bundle.Bundle) diag.Diagnostics {
+	// Top-level permissions are applied to all resources.
+	if hasGroupManage(b.Config.Permissions) {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	check := func(kind, key string, permissions []resources.Permission) {
+		if hasGroupManage(permissions) {
+			return
+		}
+		p := dyn.NewPath(dyn.Key("resources"), dyn.Key(kind), dyn.Key(key))
+		diags = append(diags, productionError(b, config.ProductionGroupManage, p,
+			"resources.%s.%s must grant CAN_MANAGE to a group when using 'mode: production'", kind, key))
+	}
+
+	r := b.Config.Resources
+	for _, k := range sortedKeys(r.Jobs) {
+		check("jobs", k, r.Jobs[k].Permissions)
+	}
+	for _, k := range sortedKeys(r.Pipelines) {
+		check("pipelines", k, r.Pipelines[k].Permissions)
+	}
+	for _, k := range sortedKeys(r.Experiments) {
+		check("experiments", k, r.Experiments[k].Permissions)
+	}
+	for _, k := range sortedKeys(r.Models) {
+		check("models", k, r.Models[k].Permissions)
+	}
+	for _, k := range sortedKeys(r.ModelServingEndpoints) {
+		check("model_serving_endpoints", k, r.ModelServingEndpoints[k].Permissions)
+	}
+	return diags
+}
+
+func hasGroupManage(permissions []resources.Permission) bool {
+	for _, p := range permissions {
+		if p.GroupName != "" && p.Level == "CAN_MANAGE" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFailureNotifications(b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+	jobs := b.Config.Resources.Jobs
+	for _, k := range sortedKeys(jobs) {
+		job := jobs[k]
+		if job.EmailNotifications != nil && len(job.EmailNotifications.OnFailure) > 0 {
+			continue
+		}
+		if job.WebhookNotifications != nil && len(job.WebhookNotifications.OnFailure) > 0 {
+			continue
+		}
+		p := dyn.NewPath(dyn.Key("resources"), dyn.Key("jobs"), dyn.Key(k))
+		diags = append(diags, productionError(b, config.ProductionFailureNotifications, p,
+			"job %s must send email or webhook notifications on failure when using 'mode: production'", k))
+	}
+	return diags
+}
+
+func checkTaskRetries(b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+	forEachTask(b, func(k string, p dyn.Path, task *jobs.Task) {
+		// An explicit max_retries of 0 is allowed but can only be observed
+		// in the dynamic configuration, because the typed value is omitted.
+		if task.MaxRetries != 0 {
+			return
+		}
+		if _, err := dyn.GetByPath(b.Config.Value(), p.Append(dyn.Key("max_retries"))); err == nil {
+			return
+		}
+		diags = append(diags, productionError(b, config.ProductionTaskRetries, p,
+			"task %s in job %s must set max_retries when using 'mode: production'", task.TaskKey, k))
+	})
+	return diags
+}
+
+func isUserPath(p string) bool {
+	return strings.HasPrefix(p, "/Workspace/Users/") || strings.HasPrefix(p, "/Users/")
+}
+
+func checkNoUserLibraries(b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+	report := func(p dyn.Path, library string) {
+		diags = append(diags, productionError(b, config.ProductionUserLibrary, p,
+			"library %s must not be in a user folder when using 'mode: production'", library))
+	}
+
+	forEachTask(b, func(k string, p dyn.Path, task *jobs.Task) {
+		for j, lib := range task.Libraries {
+			for field, lp := range libraryPaths(lib) {
+				if isUserPath(lp) {
+					report(p.Append(dyn.Key("libraries"), dyn.Index(j), dyn.Key(field)), lp)
+				}
+			}
+		}
+	})
+
+	js := b.Config.Resources.Jobs
+	for _, k := range sortedKeys(js) {
+		job := js[k]
+		for i, env := range job.Environments {
+			if env.Spec == nil {
+				continue
+			}
+			for j, dep := range env.Spec.Dependencies {
+				if isUserPath(dep) {
+					p := dyn.NewPath(dyn.Key("resources"), dyn.Key("jobs"), dyn.Key(k), dyn.Key("environments"), dyn.Index(i), dyn.Key("spec"), dyn.Key("dependencies"), dyn.Index(j))
+					report(p, dep)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// libraryPaths returns the paths of a library by field name.
+func libraryPaths(lib compute.Library) map[string]string {
+	paths := make(map[string]string)
+	if lib.Whl != "" {
+		paths["whl"] = lib.Whl
+	}
+	if lib.Jar != "" {
+		paths["jar"] = lib.Jar
+	}
+	if lib.Egg != "" {
+		paths["egg"] = lib.Egg
+	}
+	if lib.Requirements != "" {
+		paths["requirements"] = lib.Requirements
+	}
+	return paths
+}
+
+func checkProtectedBranch(b *bundle.Bundle, patterns []string) diag.Diagnostics {
+	git := b.Config.Bundle.Git
+	branch := git.ActualBranch
+	if branch == "" {
+		branch = git.Branch
+	}
+
+	p := dyn.NewPath(dyn.Key("validation"), dyn.Key("production"), dyn.Key("protected_branches"))
+	if branch == "" {
+		return diag.Diagnostics{productionError(b, config.ProductionUnprotectedBranch, p,
+			"the Git branch must be known to deploy a target with 'mode: production'")}
+	}
+
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return nil
+		}
+	}
+	return diag.Diagnostics{productionError(b, config.ProductionUnprotectedBranch, p,
+		"branch %s is not a protected branch (%s) and can't be used with 'mode: production'", branch, strings.Join(patterns, ", "))}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
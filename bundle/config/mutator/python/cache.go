@@ -0,0 +1,154 @@
+package python
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// cacheVersion is included in the cache key to invalidate entries written
+// by CLI versions that compute the key or store the entry differently.
+const cacheVersion = "1"
+
+// cacheEntry is the cached output of PyDABs for a phase.
+type cacheEntry struct {
+	Key    string `json:"key"`
+	Output string `json:"output"`
+}
+
+// skippedDirs are directories in the bundle root that don't contain Python sources of the bundle.
+var skippedDirs = []string{".git", ".databricks", "__pycache__", "node_modules"}
+
+// computeCacheKey computes the key for the output of PyDABs.
+//
+// The key depends on the phase, the input configuration, the interpreter, the packages
+// installed in the virtual environment and the contents of the Python source files in
+// the bundle root. Any change to these invalidates the cached output.
+func computeCacheKey(phase phase, input []byte, rootPath string, venvPath string, pythonPath string) (string, error) {
+	if rootPath == "" {
+		rootPath = "."
+	}
+
+	h := sha256.New()
+	writeHashField(h, "version", cacheVersion)
+	writeHashField(h, "phase", string(phase))
+	writeHashField(h, "input", string(input))
+
+	// Installing or removing packages changes the modification time of site-packages.
+	paths := []string{pythonPath}
+	for _, pattern := range []string{
+		filepath.Join(venvPath, "lib", "python*", "site-packages"),
+		filepath.Join(venvPath, "Lib", "site-packages"),
+	} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", err
+		}
+		paths = append(paths, matches...)
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		writeHashField(h, "stat", fmt.Sprintf("%s %d %d", path, info.Size(), info.ModTime().UnixNano()))
+	}
+
+	// The virtual environment is often created in the bundle root and contains
+	// sources of installed packages, which are covered by site-packages above.
+	venvAbs, err := filepath.Abs(venvPath)
+	if err != nil {
+		return "", err
+	}
+
+	var sources []string
+	err = filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if abs == venvAbs || slices.Contains(skippedDirs, d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".py") {
+			sources = append(sources, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// WalkDir visits files in lexical order, so the key doesn't depend on the file system.
+	for _, path := range sources {
+		rel, err := filepath.Rel(rootPath, path)
+		if err != nil {
+			return "", err
+		}
+		writeHashField(h, "source", filepath.ToSlash(rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashField writes a length-prefixed field so that adjacent fields can't be confused.
+func writeHashField(h hash.Hash, name string, value string) {
+	fmt.Fprintf(h, "%s:%d:%s\n", name, len(value), value)
+}
+
+func cachePath(cacheDir string, phase phase) string {
+	return filepath.Join(cacheDir, fmt.Sprintf("cache-%s.json", phase))
+}
+
+// readCache returns the cached output for the phase if it was stored with the same key.
+func readCache(cacheDir string, phase phase, key string) ([]byte, bool) {
+	raw, err := os.ReadFile(cachePath(cacheDir, phase))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	err = json.Unmarshal(raw, &entry)
+	if err != nil || entry.Key != key {
+		return nil, false
+	}
+
+	return []byte(entry.Output), true
+}
+
+// writeCache stores the output for the phase, replacing the previous entry.
+func writeCache(cacheDir string, phase phase, key string, output []byte) error {
+	raw, err := json.Marshal(cacheEntry{
+		Key:    key,
+		Output: string(output),
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(cachePath(cacheDir, phase), raw, 0600)
+}
@@ -0,0 +1,161 @@
+package python
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/env"
+	"github.com/databricks/cli/libs/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cacheTestConfig = `
+      experimental:
+        pydabs:
+          enabled: true
+          venv_path: .venv
+      resources:
+        jobs:
+          job0:
+            name: job_0`
+
+func withCountingProcessStub(t *testing.T) (context.Context, *int) {
+	ctx, stub := process.WithStub(context.Background())
+	t.Setenv(env.TempDirVariable, t.TempDir())
+
+	calls := 0
+	stub.WithCallback(func(actual *exec.Cmd) error {
+		calls++
+
+		// The mutator returns the input with a description added to job0.
+		inputPath := actual.Args[len(actual.Args)-3]
+		outputPath := actual.Args[len(actual.Args)-1]
+		input, err := os.ReadFile(inputPath)
+		if err != nil {
+			return err
+		}
+
+		var root map[string]any
+		err = json.Unmarshal(input, &root)
+		if err != nil {
+			return err
+		}
+		job0 := root["resources"].(map[string]any)["jobs"].(map[string]any)["job0"].(map[string]any)
+		job0["description"] = "my job"
+
+		output, err := json.Marshal(root)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(outputPath, output, 0600)
+	})
+
+	return ctx, &calls
+}
+
+func TestPythonMutator_cache(t *testing.T) {
+	withFakeVEnv(t, ".venv")
+	ctx, calls := withCountingProcessStub(t)
+
+	for i := 0; i < 2; i++ {
+		b := loadYaml("databricks.yml", cacheTestConfig)
+		diags := bundle.Apply(ctx, b, PythonMutator(PythonMutatorPhaseInit))
+		require.NoError(t, diags.Error())
+		assert.Equal(t, "my job", b.Config.Resources.Jobs["job0"].Description)
+	}
+	assert.Equal(t, 1, *calls)
+
+	// Changing a Python source file invalidates the cache.
+	require.NoError(t, os.WriteFile("resources.py", []byte("print('hello')\n"), 0600))
+	b := loadYaml("databricks.yml", cacheTestConfig)
+	diags := bundle.Apply(ctx, b, PythonMutator(PythonMutatorPhaseInit))
+	require.NoError(t, diags.Error())
+	assert.Equal(t, 2, *calls)
+
+	// Changing the configuration invalidates the cache.
+	b = loadYaml("databricks.yml", cacheTestConfig+`
+            max_concurrent_runs: 2`)
+	diags = bundle.Apply(ctx, b, PythonMutator(PythonMutatorPhaseInit))
+	require.NoError(t, diags.Error())
+	assert.Equal(t, 3, *calls)
+}
+
+func TestPythonMutator_disableCache(t *testing.T) {
+	withFakeVEnv(t, ".venv")
+	ctx, calls := withCountingProcessStub(t)
+
+	for i := 0; i < 2; i++ {
+		b := loadYaml("databricks.yml", `
+      experimental:
+        pydabs:
+          enabled: true
+          venv_path: .venv
+          disable_cache: true
+      resources:
+        jobs:
+          job0:
+            name: job_0`)
+		diags := bundle.Apply(ctx, b, PythonMutator(PythonMutatorPhaseInit))
+		require.NoError(t, diags.Error())
+	}
+	assert.Equal(t, 2, *calls)
+}
+
+func TestComputeCacheKey(t *testing.T) {
+	root := t.TempDir()
+	venv := filepath.Join(root, ".venv")
+	python := interpreterPath(venv)
+	require.NoError(t, os.MkdirAll(filepath.Dir(python), 0755))
+	require.NoError(t, os.WriteFile(python, nil, 0755))
+
+	key := func() string {
+		k, err := computeCacheKey(PythonMutatorPhaseLoad, []byte(`{}`), root, venv, python)
+		require.NoError(t, err)
+		return k
+	}
+
+	k0 := key()
+	assert.Equal(t, k0, key())
+
+	// Files in the virtual environment and in skipped directories don't affect the key.
+	require.NoError(t, os.WriteFile(filepath.Join(venv, "module.py"), []byte("x = 1\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "__pycache__"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "__pycache__", "module.py"), []byte("x = 1\n"), 0600))
+	assert.Equal(t, k0, key())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "src"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "module.py"), []byte("x = 1\n"), 0600))
+	k1 := key()
+	assert.NotEqual(t, k0, k1)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "module.py"), []byte("x = 2\n"), 0600))
+	assert.NotEqual(t, k1, key())
+
+	k, err := computeCacheKey(PythonMutatorPhaseInit, []byte(`{}`), root, venv, python)
+	require.NoError(t, err)
+	assert.NotEqual(t, key(), k)
+}
+
+func TestReadWriteCache(t *testing.T) {
+	dir := t.TempDir()
+
+	_, ok := readCache(dir, PythonMutatorPhaseLoad, "key")
+	assert.False(t, ok)
+
+	require.NoError(t, writeCache(dir, PythonMutatorPhaseLoad, "key", []byte(`{"resources": {}}`)))
+
+	output, ok := readCache(dir, PythonMutatorPhaseLoad, "key")
+	assert.True(t, ok)
+	assert.Equal(t, `{"resources": {}}`, string(output))
+
+	_, ok = readCache(dir, PythonMutatorPhaseLoad, "other")
+	assert.False(t, ok)
+	_, ok = readCache(dir, PythonMutatorPhaseInit, "key")
+	assert.False(t, ok)
+}
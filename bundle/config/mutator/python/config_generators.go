@@ -0,0 +1,296 @@
+package python
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/merge"
+	"github.com/databricks/cli/libs/exec"
+	"github.com/databricks/cli/libs/log"
+)
+
+// Environment variables that pass the protocol parameters to config generators.
+const (
+	generatorPhaseVariable  = "DATABRICKS_BUNDLE_GENERATOR_PHASE"
+	generatorInputVariable  = "DATABRICKS_BUNDLE_GENERATOR_INPUT"
+	generatorOutputVariable = "DATABRICKS_BUNDLE_GENERATOR_OUTPUT"
+)
+
+// configGeneratorsGeneratedFile is the file name used as the location of values generated by config generators.
+const configGeneratorsGeneratedFile = "__generated_by_config_generators__.yml"
+
+type configGenerators struct {
+	phase phase
+}
+
+// ConfigGenerators executes the config generators defined in "experimental.config_generators"
+// for the phase. Generators can be written in any language. The CLI passes the phase and the
+// paths of the input and output files in the DATABRICKS_BUNDLE_GENERATOR_PHASE,
+// DATABRICKS_BUNDLE_GENERATOR_INPUT and DATABRICKS_BUNDLE_GENERATOR_OUTPUT environment variables.
+// The input file contains the bundle configuration as JSON. Generators write a JSON object with
+// the optional "config" (the modified configuration) and "diagnostics" fields to the output file.
+func ConfigGenerators(phase phase) bundle.Mutator {
+	return &configGenerators{
+		phase: phase,
+	}
+}
+
+func (m *configGenerators) Name() string {
+	return fmt.Sprintf("ConfigGenerators(%s)", m.phase)
+}
+
+// generatorOutput is the contents of the output file written by a config generator.
+type generatorOutput struct {
+	// Config is the modified bundle configuration. The configuration is left unchanged if it is not set.
+	Config json.RawMessage `json:"config,omitempty"`
+
+	Diagnostics []generatorDiagnostic `json:"diagnostics,omitempty"`
+}
+
+type generatorDiagnostic struct {
+	// Severity is "error", "warning" or "info".
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+
+	// Location in a source file of the generator. Relative paths are relative to the bundle root.
+	Location *generatorLocation `json:"location,omitempty"`
+
+	// Path to the value in the bundle configuration, for example "resources.jobs.my_job".
+	Path string `json:"path,omitempty"`
+}
+
+type generatorLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (m *configGenerators) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	generators := getExperimental(b).ConfigGenerators
+	if len(generators) == 0 {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for i, generator := range generators {
+		p := dyn.NewPath(dyn.Key("experimental"), dyn.Key("config_generators"), dyn.Index(i))
+
+		if generator.Command == "" {
+			diags = diags.Append(diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "config generator doesn't define a command",
+				Location: b.Config.GetLocation(p.String()),
+				Path:     p,
+			})
+			continue
+		}
+
+		phases := generator.Phases
+		if len(phases) == 0 {
+			phases = []string{string(PythonMutatorPhaseLoad), string(PythonMutatorPhaseInit)}
+		}
+		for j, v := range phases {
+			if v != string(PythonMutatorPhaseLoad) && v != string(PythonMutatorPhaseInit) {
+				pp := p.Append(dyn.Key("phases"), dyn.Index(j))
+				diags = diags.Append(diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("unknown config generator phase %q, expected %q or %q", v, PythonMutatorPhaseLoad, PythonMutatorPhaseInit),
+					Location: b.Config.GetLocation(pp.String()),
+					Path:     pp,
+				})
+			}
+		}
+		if diags.HasError() || !slices.Contains(phases, string(m.phase)) {
+			continue
+		}
+
+		var generatorDiags diag.Diagnostics
+		err := b.Config.Mutate(func(leftRoot dyn.Value) (dyn.Value, error) {
+			rightRoot, d, err := m.runGenerator(ctx, b.RootPath, i, generator, leftRoot)
+			generatorDiags = d
+			if err != nil {
+				return dyn.InvalidValue, err
+			}
+			if d.HasError() || !rightRoot.IsValid() {
+				return leftRoot, nil
+			}
+
+			visitor, err := createOverrideVisitor(ctx, m.phase)
+			if err != nil {
+				return dyn.InvalidValue, err
+			}
+
+			out, err := merge.Override(leftRoot, rightRoot, visitor)
+			if err != nil {
+				return dyn.InvalidValue, fmt.Errorf("config generator %q: %w", generator.Command, err)
+			}
+			return out, nil
+		})
+
+		// Diagnostics that only refer to a path are located at the value in the configuration.
+		for k, d := range generatorDiags {
+			if d.Location.File == "" && len(d.Path) > 0 {
+				generatorDiags[k].Location = b.Config.GetLocation(d.Path.String())
+			}
+		}
+		diags = diags.Extend(generatorDiags)
+
+		if err != nil {
+			return diags.Extend(diag.FromErr(err))
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// runGenerator executes a config generator and returns the configuration it generated,
+// or an invalid value if the generator didn't return a configuration.
+func (m *configGenerators) runGenerator(ctx context.Context, rootPath string, index int, generator config.ConfigGenerator, root dyn.Value) (dyn.Value, diag.Diagnostics, error) {
+	cacheDir, err := createCacheDirFor(ctx, rootPath, "config_generators")
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	inputPath := filepath.Join(cacheDir, fmt.Sprintf("input-%d.json", index))
+	outputPath := filepath.Join(cacheDir, fmt.Sprintf("output-%d.json", index))
+
+	// Remove the output of a previous run so that it isn't mistaken for the output of this run.
+	err = os.Remove(outputPath)
+	if err != nil && !os.IsNotExist(err) {
+		return dyn.InvalidValue, nil, err
+	}
+
+	// we need to marshal dyn.Value instead of bundle.Config to JSON to support
+	// non-string fields assigned with bundle variables
+	input, err := json.Marshal(root.AsAny())
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to marshal root config: %w", err)
+	}
+
+	err = os.WriteFile(inputPath, input, 0600)
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to write input file: %w", err)
+	}
+
+	executor, err := exec.NewCommandExecutor(rootPath)
+	if err != nil {
+		return dyn.InvalidValue, nil, err
+	}
+	executor.WithEnv(map[string]string{
+		generatorPhaseVariable:  string(m.phase),
+		generatorInputVariable:  inputPath,
+		generatorOutputVariable: outputPath,
+	})
+
+	log.Debugf(ctx, "Executing config generator %q", generator.Command)
+	cmd, err := executor.StartCommand(ctx, generator.Command)
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to start config generator %q: %w", generator.Command, err)
+	}
+
+	// Both streams are drained concurrently because the generator blocks
+	// if it fills the pipe of a stream that isn't being read.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(newLogWriter(ctx, "stdout: "), cmd.Stdout())
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(newLogWriter(ctx, "stderr: "), cmd.Stderr())
+	}()
+	wg.Wait()
+
+	err = cmd.Wait()
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("config generator %q failed: %w", generator.Command, err)
+	}
+
+	raw, err := os.ReadFile(outputPath)
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to open config generator %q output: %w", generator.Command, err)
+	}
+
+	var output generatorOutput
+	err = json.Unmarshal(raw, &output)
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to parse config generator %q output: %w", generator.Command, err)
+	}
+
+	diags, err := convertGeneratorDiagnostics(rootPath, output.Diagnostics)
+	if err != nil {
+		return dyn.InvalidValue, nil, fmt.Errorf("failed to parse config generator %q output: %w", generator.Command, err)
+	}
+
+	if len(output.Config) == 0 || string(output.Config) == "null" {
+		return dyn.InvalidValue, diags, nil
+	}
+
+	generated, err := loadOutput(rootPath, configGeneratorsGeneratedFile, fmt.Sprintf("config generator %q", generator.Command), output.Config)
+	if err != nil {
+		return dyn.InvalidValue, diags, err
+	}
+
+	return generated, diags, nil
+}
+
+func convertGeneratorDiagnostics(rootPath string, in []generatorDiagnostic) (diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+	for _, d := range in {
+		var severity diag.Severity
+		switch d.Severity {
+		case "error":
+			severity = diag.Error
+		case "warning":
+			severity = diag.Warning
+		case "info":
+			severity = diag.Info
+		default:
+			return nil, fmt.Errorf("unknown diagnostic severity %q", d.Severity)
+		}
+
+		out := diag.Diagnostic{
+			Severity: severity,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		}
+
+		if d.Location != nil {
+			file := d.Location.File
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(rootPath, file)
+			}
+			out.Location = dyn.Location{
+				File:   file,
+				Line:   d.Location.Line,
+				Column: d.Location.Column,
+			}
+		}
+
+		if d.Path != "" {
+			p, err := dyn.NewPathFromString(d.Path)
+			if err != nil {
+				return nil, err
+			}
+			out.Path = p
+		}
+
+		diags = append(diags, out)
+	}
+	return diags, nil
+}
@@ -0,0 +1,153 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/env"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+)
+
+// withGenerator creates an executable generator.sh in a new working directory
+// that writes the specified output.
+func withGenerator(t *testing.T, output string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("generator is a shell script")
+	}
+
+	withFakeVEnv(t, ".venv")
+	t.Setenv(env.TempDirVariable, t.TempDir())
+
+	script := "#!/bin/sh\n" +
+		"test \"$DATABRICKS_BUNDLE_GENERATOR_PHASE\" = load -o \"$DATABRICKS_BUNDLE_GENERATOR_PHASE\" = init || exit 1\n" +
+		"test -f \"$DATABRICKS_BUNDLE_GENERATOR_INPUT\" || exit 1\n" +
+		"cat > \"$DATABRICKS_BUNDLE_GENERATOR_OUTPUT\" <<'EOF'\n" + output + "\nEOF\n"
+	require.NoError(t, os.WriteFile("generator.sh", []byte(script), 0755))
+}
+
+func TestConfigGenerators_load(t *testing.T) {
+	withGenerator(t, `{
+		"config": {
+			"experimental": {"config_generators": [{"command": "./generator.sh", "phases": ["load"]}]},
+			"resources": {"jobs": {"job0": {"name": "job_0"}, "job1": {"name": "job_1"}}}
+		}
+	}`)
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh
+            phases: [load]
+      resources:
+        jobs:
+          job0:
+            name: job_0`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseLoad))
+	require.NoError(t, diags.Error())
+	assert.ElementsMatch(t, []string{"job0", "job1"}, maps.Keys(b.Config.Resources.Jobs))
+	assert.Equal(t, "job_1", b.Config.Resources.Jobs["job1"].Name)
+
+	expectedVirtualPath, err := filepath.Abs(configGeneratorsGeneratedFile)
+	require.NoError(t, err)
+	assert.Equal(t, expectedVirtualPath, b.Config.GetLocation("resources.jobs.job1.name").File)
+}
+
+func TestConfigGenerators_loadDisallowed(t *testing.T) {
+	withGenerator(t, `{
+		"config": {
+			"experimental": {"config_generators": [{"command": "./generator.sh"}]},
+			"resources": {"jobs": {"job0": {"name": "job_0", "description": "my job"}}}
+		}
+	}`)
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh
+      resources:
+        jobs:
+          job0:
+            name: job_0`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseLoad))
+	assert.EqualError(t, diags.Error(), `config generator "./generator.sh": unexpected change at "resources.jobs.job0.description" (insert)`)
+}
+
+func TestConfigGenerators_skipsOtherPhases(t *testing.T) {
+	withGenerator(t, `not valid output`)
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh
+            phases: [init]`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseLoad))
+	assert.NoError(t, diags.Error())
+}
+
+func TestConfigGenerators_diagnostics(t *testing.T) {
+	withGenerator(t, `{
+		"diagnostics": [
+			{"severity": "warning", "summary": "job0 has no tags", "path": "resources.jobs.job0"},
+			{"severity": "error", "summary": "job1 is invalid", "location": {"file": "src/jobs.ts", "line": 3, "column": 5}}
+		]
+	}`)
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh
+      resources:
+        jobs:
+          job0:
+            name: job_0`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseInit))
+	require.Len(t, diags, 2)
+
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "job0 has no tags", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job0", diags[0].Path.String())
+	assert.Equal(t, "databricks.yml", diags[0].Location.File)
+
+	assert.Equal(t, diag.Error, diags[1].Severity)
+	assert.Equal(t, "job1 is invalid", diags[1].Summary)
+	assert.Equal(t, filepath.Join("src", "jobs.ts"), diags[1].Location.File)
+	assert.Equal(t, 3, diags[1].Location.Line)
+	assert.Equal(t, 5, diags[1].Location.Column)
+}
+
+func TestConfigGenerators_failure(t *testing.T) {
+	withGenerator(t, `{}`)
+	require.NoError(t, os.WriteFile("generator.sh", []byte("#!/bin/sh\nexit 2\n"), 0755))
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseInit))
+	assert.ErrorContains(t, diags.Error(), `config generator "./generator.sh" failed`)
+}
+
+func TestConfigGenerators_invalidPhase(t *testing.T) {
+	b := loadYaml("databricks.yml", `
+      experimental:
+        config_generators:
+          - command: ./generator.sh
+            phases: [deploy]`)
+
+	diags := bundle.Apply(context.Background(), b, ConfigGenerators(PythonMutatorPhaseInit))
+	require.Len(t, diags, 1)
+	assert.Equal(t, `unknown config generator phase "deploy", expected "load" or "init"`, diags[0].Summary)
+	assert.Equal(t, "experimental.config_generators[0].phases[0]", diags[0].Path.String())
+}
@@ -1,12 +1,14 @@
 package python
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/databricks/cli/bundle/env"
 
@@ -18,7 +20,6 @@ import (
 	"github.com/databricks/cli/libs/dyn/merge"
 	"github.com/databricks/cli/libs/dyn/yamlloader"
 	"github.com/databricks/cli/libs/log"
-	"github.com/databricks/cli/libs/process"
 )
 
 type phase string
@@ -87,6 +88,12 @@ func (m *pythonMutator) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagno
 		return diag.Errorf("\"experimental.pydabs.enabled\" can only be used when \"experimental.pydabs.venv_path\" is set")
 	}
 
+	// The init phase is the last phase that runs PyDABs, so a long-lived
+	// process is no longer needed once it completes.
+	if m.phase == PythonMutatorPhaseInit {
+		defer stopServers()
+	}
+
 	err := b.Config.Mutate(func(leftRoot dyn.Value) (dyn.Value, error) {
 		pythonPath := interpreterPath(experimental.PyDABs.VEnvPath)
 
@@ -98,12 +105,12 @@ func (m *pythonMutator) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagno
 			}
 		}
 
-		cacheDir, err := createCacheDir(ctx)
+		cacheDir, err := createCacheDir(ctx, b.RootPath)
 		if err != nil {
 			return dyn.InvalidValue, fmt.Errorf("failed to create cache dir: %w", err)
 		}
 
-		rightRoot, err := m.runPythonMutator(ctx, cacheDir, b.RootPath, pythonPath, leftRoot)
+		rightRoot, err := m.runPythonMutator(ctx, cacheDir, b.RootPath, pythonPath, experimental.PyDABs, leftRoot)
 		if err != nil {
 			return dyn.InvalidValue, err
 		}
@@ -119,41 +126,45 @@ func (m *pythonMutator) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagno
 	return diag.FromErr(err)
 }
 
-func createCacheDir(ctx context.Context) (string, error) {
+func createCacheDir(ctx context.Context, rootPath string) (string, error) {
+	return createCacheDirFor(ctx, rootPath, "pydabs")
+}
+
+func createCacheDirFor(ctx context.Context, rootPath string, name string) (string, error) {
 	// b.CacheDir doesn't work because target isn't yet selected
 
 	// support the same env variable as in b.CacheDir
-	if tempDir, exists := env.TempDir(ctx); exists {
-		// use 'default' as target name
-		cacheDir := filepath.Join(tempDir, "default", "pydabs")
+	cacheDir, exists := env.TempDir(ctx)
+	if !exists || cacheDir == "" {
+		cacheDir = filepath.Join(rootPath, ".databricks", "bundle")
+	}
 
-		err := os.MkdirAll(cacheDir, 0700)
-		if err != nil {
-			return "", err
-		}
+	// use 'default' as target name
+	cacheDir = filepath.Join(cacheDir, "default", name)
 
-		return cacheDir, nil
+	err := os.MkdirAll(cacheDir, 0700)
+	if err != nil {
+		return "", err
 	}
 
-	return os.MkdirTemp("", "-pydabs")
+	return cacheDir, nil
 }
 
-func (m *pythonMutator) runPythonMutator(ctx context.Context, cacheDir string, rootPath string, pythonPath string, root dyn.Value) (dyn.Value, error) {
+func newRunner(experimental config.PyDABs, rootPath string, pythonPath string) runner {
+	switch {
+	case experimental.Daemon:
+		return &daemonRunner{rootPath: rootPath, pythonPath: pythonPath, startTimeout: 30 * time.Second}
+	case experimental.LongLived:
+		return &serverRunner{rootPath: rootPath, pythonPath: pythonPath}
+	default:
+		return &processRunner{rootPath: rootPath, pythonPath: pythonPath}
+	}
+}
+
+func (m *pythonMutator) runPythonMutator(ctx context.Context, cacheDir string, rootPath string, pythonPath string, experimental config.PyDABs, root dyn.Value) (dyn.Value, error) {
 	inputPath := filepath.Join(cacheDir, "input.json")
 	outputPath := filepath.Join(cacheDir, "output.json")
 
-	args := []string{
-		pythonPath,
-		"-m",
-		"databricks.bundles.build",
-		"--phase",
-		string(m.phase),
-		"--input",
-		inputPath,
-		"--output",
-		outputPath,
-	}
-
 	// we need to marshal dyn.Value instead of bundle.Config to JSON to support
 	// non-string fields assigned with bundle variables
 	rootConfigJson, err := json.Marshal(root.AsAny())
@@ -161,34 +172,59 @@ func (m *pythonMutator) runPythonMutator(ctx context.Context, cacheDir string, r
 		return dyn.InvalidValue, fmt.Errorf("failed to marshal root config: %w", err)
 	}
 
+	cacheKey := ""
+	if !experimental.DisableCache {
+		cacheKey, err = computeCacheKey(m.phase, rootConfigJson, rootPath, experimental.VEnvPath, pythonPath)
+		if err != nil {
+			// caching is an optimization, so we run the mutator if the key can't be computed
+			log.Debugf(ctx, "Failed to compute cache key for Python mutator: %s", err)
+		}
+	}
+
+	if cacheKey != "" {
+		if output, ok := readCache(cacheDir, m.phase, cacheKey); ok {
+			log.Debugf(ctx, "Using cached output of Python mutator (%s)", m.phase)
+			return loadOutput(rootPath, pydabsGeneratedFile, "Python mutator", output)
+		}
+	}
+
 	err = os.WriteFile(inputPath, rootConfigJson, 0600)
 	if err != nil {
 		return dyn.InvalidValue, fmt.Errorf("failed to write input file: %w", err)
 	}
 
-	stderrWriter := newLogWriter(ctx, "stderr: ")
-	stdoutWriter := newLogWriter(ctx, "stdout: ")
-
-	_, err = process.Background(
-		ctx,
-		args,
-		process.WithDir(rootPath),
-		process.WithStderrWriter(stderrWriter),
-		process.WithStdoutWriter(stdoutWriter),
-	)
+	err = newRunner(experimental, rootPath, pythonPath).run(ctx, m.phase, inputPath, outputPath)
 	if err != nil {
-		return dyn.InvalidValue, fmt.Errorf("python mutator process failed: %w", err)
+		return dyn.InvalidValue, err
 	}
 
-	outputFile, err := os.Open(outputPath)
+	output, err := os.ReadFile(outputPath)
 	if err != nil {
 		return dyn.InvalidValue, fmt.Errorf("failed to open Python mutator output: %w", err)
 	}
 
-	defer func() {
-		_ = outputFile.Close()
-	}()
+	generated, err := loadOutput(rootPath, pydabsGeneratedFile, "Python mutator", output)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	// only valid output is cached, so that failures are reported on every run
+	if cacheKey != "" {
+		err = writeCache(cacheDir, m.phase, cacheKey, output)
+		if err != nil {
+			log.Debugf(ctx, "Failed to cache output of Python mutator: %s", err)
+		}
+	}
+
+	return generated, nil
+}
+
+// pydabsGeneratedFile is the file name used as the location of values generated by PyDABs.
+const pydabsGeneratedFile = "__generated_by_pydabs__.yml"
 
+// loadOutput parses and normalizes the configuration generated by the Python mutator
+// or a config generator. Values are located in the virtual file with the specified name.
+func loadOutput(rootPath string, fileName string, source string, output []byte) (dyn.Value, error) {
 	// we need absolute path because later parts of pipeline assume all paths are absolute
 	// and this file will be used as location to resolve relative paths.
 	//
@@ -197,26 +233,26 @@ func (m *pythonMutator) runPythonMutator(ctx context.Context, cacheDir string, r
 	//   Error: path /var/folders/.../pydabs/dist/*.whl is not contained in bundle root path
 	//
 	// for that, we pass virtualPath instead of outputPath as file location
-	virtualPath, err := filepath.Abs(filepath.Join(rootPath, "__generated_by_pydabs__.yml"))
+	virtualPath, err := filepath.Abs(filepath.Join(rootPath, fileName))
 	if err != nil {
 		return dyn.InvalidValue, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	generated, err := yamlloader.LoadYAML(virtualPath, outputFile)
+	generated, err := yamlloader.LoadYAML(virtualPath, bytes.NewReader(output))
 	if err != nil {
-		return dyn.InvalidValue, fmt.Errorf("failed to parse Python mutator output: %w", err)
+		return dyn.InvalidValue, fmt.Errorf("failed to parse %s output: %w", source, err)
 	}
 
 	normalized, diagnostic := convert.Normalize(config.Root{}, generated)
 	if diagnostic.Error() != nil {
-		return dyn.InvalidValue, fmt.Errorf("failed to normalize Python mutator output: %w", diagnostic.Error())
+		return dyn.InvalidValue, fmt.Errorf("failed to normalize %s output: %w", source, diagnostic.Error())
 	}
 
 	// warnings shouldn't happen because output should be already normalized
 	// when it happens, it's a bug in the mutator, and should be treated as an error
 
 	for _, d := range diagnostic.Filter(diag.Warning) {
-		return dyn.InvalidValue, fmt.Errorf("failed to normalize Python mutator output: %s", d.Summary)
+		return dyn.InvalidValue, fmt.Errorf("failed to normalize %s output: %s", source, d.Summary)
 	}
 
 	return normalized, nil
@@ -424,7 +424,7 @@ func withProcessStub(t *testing.T, args []string, stdout string) context.Context
 	t.Setenv(env.TempDirVariable, t.TempDir())
 
 	// after we override env variable, we always get the same cache dir as mutator
-	cacheDir, err := createCacheDir(ctx)
+	cacheDir, err := createCacheDir(ctx, "")
 	require.NoError(t, err)
 
 	inputPath := filepath.Join(cacheDir, "input.json")
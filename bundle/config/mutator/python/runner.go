@@ -0,0 +1,366 @@
+package python
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/databricks/cli/libs/log"
+	"github.com/databricks/cli/libs/process"
+)
+
+// runner executes PyDABs for a phase. It reads the bundle configuration from
+// the input file and writes the resulting configuration to the output file.
+type runner interface {
+	run(ctx context.Context, phase phase, inputPath string, outputPath string) error
+}
+
+// processRunner starts a new interpreter for every phase.
+type processRunner struct {
+	rootPath   string
+	pythonPath string
+}
+
+func (r *processRunner) run(ctx context.Context, phase phase, inputPath string, outputPath string) error {
+	args := []string{
+		r.pythonPath,
+		"-m",
+		"databricks.bundles.build",
+		"--phase",
+		string(phase),
+		"--input",
+		inputPath,
+		"--output",
+		outputPath,
+	}
+
+	stderrWriter := newLogWriter(ctx, "stderr: ")
+	stdoutWriter := newLogWriter(ctx, "stdout: ")
+
+	_, err := process.Background(
+		ctx,
+		args,
+		process.WithDir(r.rootPath),
+		process.WithStderrWriter(stderrWriter),
+		process.WithStdoutWriter(stdoutWriter),
+	)
+	if err != nil {
+		return fmt.Errorf("python mutator process failed: %w", err)
+	}
+
+	return nil
+}
+
+// serverRequest is sent to a long-lived PyDABs process as a single line of JSON.
+type serverRequest struct {
+	Phase  phase  `json:"phase"`
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// serverResponse is sent by a long-lived PyDABs process as a single line of JSON
+// once the output for a request has been written.
+type serverResponse struct {
+	// Status is either "ok" or "error".
+	Status string `json:"status"`
+
+	// Error describes why the request failed.
+	Error string `json:"error,omitempty"`
+}
+
+func (r serverResponse) err() error {
+	if r.Status == "ok" {
+		return nil
+	}
+	return fmt.Errorf("python mutator process failed: %s", r.Error)
+}
+
+// parseServerResponse parses a line written by a long-lived PyDABs process.
+// It returns false if the line isn't a response, for example because user code printed it.
+func parseServerResponse(line []byte) (serverResponse, bool) {
+	var resp serverResponse
+	err := json.Unmarshal(line, &resp)
+	if err != nil || resp.Status == "" {
+		return serverResponse{}, false
+	}
+	return resp, true
+}
+
+// server is a PyDABs process that serves requests on its standard input and output.
+type server struct {
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+var (
+	serversMu sync.Mutex
+	servers   = map[string]*server{}
+)
+
+// serverRunner runs all phases of a CLI invocation in a single long-lived process.
+type serverRunner struct {
+	rootPath   string
+	pythonPath string
+}
+
+func (r *serverRunner) run(ctx context.Context, phase phase, inputPath string, outputPath string) error {
+	s, err := r.server(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = writeServerRequest(s.stdin, serverRequest{Phase: phase, Input: inputPath, Output: outputPath})
+	if err != nil {
+		r.discard(s)
+		return fmt.Errorf("failed to send request to python mutator process: %w", err)
+	}
+
+	err = readServerResponse(ctx, s.stdout)
+	if errors.Is(err, errServerExited) {
+		r.discard(s)
+	}
+	return err
+}
+
+// discard forgets a process that exited so that the next phase starts a new one.
+func (r *serverRunner) discard(s *server) {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	for key, v := range servers {
+		if v == s {
+			delete(servers, key)
+		}
+	}
+}
+
+// server returns the process for the interpreter and bundle root, starting it if necessary.
+func (r *serverRunner) server(ctx context.Context) (*server, error) {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	key := r.pythonPath + string(filepath.ListSeparator) + r.rootPath
+	if s, ok := servers[key]; ok {
+		return s, nil
+	}
+
+	// The process must outlive the context of the phase it is started in.
+	cmd := exec.Command(r.pythonPath, "-m", "databricks.bundles.build", "--server")
+	cmd.Dir = r.rootPath
+	cmd.Stderr = newLogWriter(context.WithoutCancel(ctx), "stderr: ")
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf(ctx, "Starting long-lived python mutator process")
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start python mutator process: %w", err)
+	}
+
+	s := &server{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReader(stdout),
+	}
+	servers[key] = s
+	return s, nil
+}
+
+// stopServers stops the long-lived processes started by this CLI invocation.
+// It is called once the last phase has run. The processes also exit on their own
+// when their standard input is closed as the CLI exits.
+func stopServers() {
+	serversMu.Lock()
+	defer serversMu.Unlock()
+
+	for key, s := range servers {
+		s.stdin.Close()
+		_ = s.cmd.Wait()
+		delete(servers, key)
+	}
+}
+
+// daemonRunner runs phases in a background process that is shared across CLI invocations.
+type daemonRunner struct {
+	rootPath   string
+	pythonPath string
+
+	// How long to wait for a newly started process to accept connections.
+	startTimeout time.Duration
+}
+
+func (r *daemonRunner) run(ctx context.Context, phase phase, inputPath string, outputPath string) error {
+	conn, err := r.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeServerRequest(conn, serverRequest{Phase: phase, Input: inputPath, Output: outputPath})
+	if err != nil {
+		return fmt.Errorf("failed to send request to python mutator daemon: %w", err)
+	}
+
+	return readServerResponse(ctx, bufio.NewReader(conn))
+}
+
+// dial connects to the daemon for the interpreter and bundle root, starting it if necessary.
+func (r *daemonRunner) dial(ctx context.Context) (net.Conn, error) {
+	socketPath, err := daemonSocketPath(r.rootPath, r.pythonPath)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.Dial("unix", socketPath)
+	if err == nil {
+		return conn, nil
+	}
+
+	// The socket of a daemon that didn't shut down cleanly prevents a new daemon from listening.
+	_ = os.Remove(socketPath)
+
+	logFile, err := os.OpenFile(socketPath+".log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	defer logFile.Close()
+
+	cmd := exec.Command(r.pythonPath, "-m", "databricks.bundles.build", "--server", "--socket", socketPath)
+	cmd.Dir = r.rootPath
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+
+	log.Debugf(ctx, "Starting python mutator daemon listening on %s", socketPath)
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start python mutator daemon: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	deadline := time.After(r.startTimeout)
+	for {
+		conn, err := net.Dial("unix", socketPath)
+		if err == nil {
+			return conn, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-exited:
+			return nil, fmt.Errorf("python mutator daemon exited: %v, see %s for details", err, logFile.Name())
+		case <-deadline:
+			return nil, fmt.Errorf("python mutator daemon didn't start within %s, see %s for details", r.startTimeout, logFile.Name())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// daemonSocketPath returns the path of the socket for the daemon of the interpreter and bundle root.
+// Socket paths are limited to about 100 characters, so the socket is created in the system
+// temporary directory rather than the bundle cache directory. The directory of the socket is
+// private to the current user, so other users can't connect to or impersonate the daemon.
+func daemonSocketPath(rootPath string, pythonPath string) (string, error) {
+	rootAbs, err := filepath.Abs(rootPath)
+	if err != nil {
+		return "", err
+	}
+	pythonAbs, err := filepath.Abs(pythonPath)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := daemonSocketDir()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.Sum256([]byte(pythonAbs + string(filepath.ListSeparator) + rootAbs))
+	return filepath.Join(dir, fmt.Sprintf("%s.sock", hex.EncodeToString(h[:8]))), nil
+}
+
+// daemonSocketDir returns the directory for daemon sockets of the current user, creating it if necessary.
+// It fails if the directory exists but isn't a directory that only the current user can access.
+func daemonSocketDir() (string, error) {
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("databricks-pydabs-%d", os.Getuid()))
+
+	err := os.Mkdir(dir, 0700)
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", err
+	}
+
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() || info.Mode().Perm() != 0700 {
+		return "", fmt.Errorf("%s must be a directory that is only accessible by the current user", dir)
+	}
+	return dir, nil
+}
+
+func writeServerRequest(w io.Writer, req serverRequest) error {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(raw, '\n'))
+	return err
+}
+
+var errServerExited = errors.New("python mutator process exited before responding")
+
+// readServerResponse reads lines until it finds a response. Other lines are logged.
+func readServerResponse(ctx context.Context, r *bufio.Reader) error {
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			if resp, ok := parseServerResponse(line); ok {
+				return resp.err()
+			}
+			log.Debugf(ctx, "stdout: %s", strings.TrimRight(string(line), "\r\n"))
+		}
+		if errors.Is(err, io.EOF) {
+			return errServerExited
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read response from python mutator process: %w", err)
+		}
+	}
+}
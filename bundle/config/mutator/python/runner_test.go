@@ -0,0 +1,160 @@
+package python
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServerScript serves requests like a long-lived PyDABs process. In the init
+// phase, it adds a description to job0 in the input. It records every start of the process.
+const fakeServerScript = `#!/bin/sh
+echo started >> "$0.starts"
+while read -r line; do
+  input=$(echo "$line" | sed -e 's/.*"input":"\([^"]*\)".*/\1/')
+  output=$(echo "$line" | sed -e 's/.*"output":"\([^"]*\)".*/\1/')
+  echo "print from user code"
+  case "$line" in
+    *'"phase":"init"'*) sed -e 's/"name":"job_0"/"name":"job_0","description":"my job"/' "$input" > "$output" ;;
+    *) cp "$input" "$output" ;;
+  esac
+  echo '{"status": "ok"}'
+done
+`
+
+func TestPythonMutator_longLived(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake interpreter is a shell script")
+	}
+
+	withFakeVEnv(t, ".venv")
+	require.NoError(t, os.WriteFile(interpreterPath(".venv"), []byte(fakeServerScript), 0755))
+	t.Setenv(env.TempDirVariable, t.TempDir())
+	t.Cleanup(stopServers)
+
+	b := loadYaml("databricks.yml", `
+      experimental:
+        pydabs:
+          enabled: true
+          venv_path: .venv
+          long_lived: true
+          disable_cache: true
+      resources:
+        jobs:
+          job0:
+            name: job_0`)
+
+	diags := bundle.Apply(context.Background(), b, PythonMutator(PythonMutatorPhaseLoad))
+	require.NoError(t, diags.Error())
+	assert.Len(t, servers, 1)
+
+	diags = bundle.Apply(context.Background(), b, PythonMutator(PythonMutatorPhaseInit))
+	require.NoError(t, diags.Error())
+	assert.Equal(t, "my job", b.Config.Resources.Jobs["job0"].Description)
+
+	// Both phases are served by the same process, which is stopped after the init phase.
+	starts, err := os.ReadFile(interpreterPath(".venv") + ".starts")
+	require.NoError(t, err)
+	assert.Equal(t, "started\n", string(starts))
+	assert.Empty(t, servers)
+}
+
+func TestDaemonRunner(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported on all versions of Windows")
+	}
+
+	root := t.TempDir()
+	python := filepath.Join(root, ".venv", "bin", "python3")
+	socketPath, err := daemonSocketPath(root, python)
+	require.NoError(t, err)
+
+	// Pretend that the daemon is already running.
+	_ = os.Remove(socketPath)
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	requests := make(chan serverRequest, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		line, err := bufio.NewReader(conn).ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var req serverRequest
+		if json.Unmarshal(line, &req) != nil {
+			return
+		}
+		requests <- req
+		conn.Write([]byte("{\"status\": \"error\", \"error\": \"failed to import resources\"}\n"))
+	}()
+
+	r := &daemonRunner{rootPath: root, pythonPath: python, startTimeout: time.Second}
+	err = r.run(context.Background(), PythonMutatorPhaseLoad, "input.json", "output.json")
+	assert.EqualError(t, err, "python mutator process failed: failed to import resources")
+	assert.Equal(t, serverRequest{Phase: PythonMutatorPhaseLoad, Input: "input.json", Output: "output.json"}, <-requests)
+}
+
+func TestDaemonSocketPath(t *testing.T) {
+	p0, err := daemonSocketPath("root0", "python")
+	require.NoError(t, err)
+	p1, err := daemonSocketPath("root1", "python")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, p0, p1)
+	assert.True(t, strings.HasSuffix(p0, ".sock"))
+	assert.Equal(t, os.TempDir(), filepath.Dir(filepath.Dir(p0)))
+
+	info, err := os.Stat(filepath.Dir(p0))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestDaemonSocketPathSharedDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+
+	t.Setenv("TMPDIR", t.TempDir())
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("databricks-pydabs-%d", os.Getuid()))
+	require.NoError(t, os.Mkdir(dir, 0777))
+	require.NoError(t, os.Chmod(dir, 0777))
+
+	_, err := daemonSocketPath("root", "python")
+	assert.ErrorContains(t, err, "must be a directory that is only accessible by the current user")
+}
+
+func TestParseServerResponse(t *testing.T) {
+	resp, ok := parseServerResponse([]byte(`{"status": "ok"}`))
+	assert.True(t, ok)
+	assert.NoError(t, resp.err())
+
+	resp, ok = parseServerResponse([]byte(`{"status": "error", "error": "boom"}`))
+	assert.True(t, ok)
+	assert.EqualError(t, resp.err(), "python mutator process failed: boom")
+
+	_, ok = parseServerResponse([]byte("print from user code"))
+	assert.False(t, ok)
+
+	_, ok = parseServerResponse([]byte(`{"jobs": {}}`))
+	assert.False(t, ok)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/variable"
 	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/dyn"
@@ -70,55 +71,8 @@ func (m *resolveVariableReferences) Validate(ctx context.Context, b *bundle.Bund
 }
 
 func (m *resolveVariableReferences) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
-	prefixes := make([]dyn.Path, len(m.prefixes))
-	for i, prefix := range m.prefixes {
-		prefixes[i] = dyn.MustPathFromString(prefix)
-	}
-
-	// The path ${var.foo} is a shorthand for ${variables.foo.value}.
-	// We rewrite it here to make the resolution logic simpler.
-	varPath := dyn.NewPath(dyn.Key("var"))
-
 	err := b.Config.Mutate(func(root dyn.Value) (dyn.Value, error) {
-		// Synthesize a copy of the root that has all fields that are present in the type
-		// but not set in the dynamic value set to their corresponding empty value.
-		// This enables users to interpolate variable references to fields that haven't
-		// been explicitly set in the dynamic value.
-		//
-		// For example: ${bundle.git.origin_url} should resolve to an empty string
-		// if a bundle isn't located in a Git repository (yet).
-		//
-		// This is consistent with the behavior prior to using the dynamic value system.
-		//
-		// We can ignore the diagnostics return value because we know that the dynamic value
-		// has already been normalized when it was first loaded from the configuration file.
-		//
-		normalized, _ := convert.Normalize(b.Config, root, convert.IncludeMissingFields)
-
-		// If the pattern is nil, we resolve references in the entire configuration.
-		root, err := dyn.MapByPattern(root, m.pattern, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
-			// Resolve variable references in all values.
-			return dynvar.Resolve(v, func(path dyn.Path) (dyn.Value, error) {
-				// Rewrite the shorthand path ${var.foo} into ${variables.foo.value}.
-				if path.HasPrefix(varPath) && len(path) == 2 {
-					path = dyn.NewPath(
-						dyn.Key("variables"),
-						path[1],
-						dyn.Key("value"),
-					)
-				}
-
-				// Perform resolution only if the path starts with one of the specified prefixes.
-				for _, prefix := range prefixes {
-					if path.HasPrefix(prefix) {
-						return m.lookupFn(normalized, path)
-					}
-				}
-
-				return dyn.InvalidValue, dynvar.ErrSkipResolution
-			})
-		})
-
+		root, err := m.resolve(b.Config, root)
 		if err != nil {
 			return dyn.InvalidValue, err
 		}
@@ -136,3 +90,56 @@ func (m *resolveVariableReferences) Apply(ctx context.Context, b *bundle.Bundle)
 
 	return diag.FromErr(err)
 }
+
+// resolve resolves the variable references in the configuration tree.
+// The typed configuration is used to resolve references to fields that aren't set.
+func (m *resolveVariableReferences) resolve(cfg config.Root, root dyn.Value) (dyn.Value, error) {
+	prefixes := make([]dyn.Path, len(m.prefixes))
+	for i, prefix := range m.prefixes {
+		prefixes[i] = dyn.MustPathFromString(prefix)
+	}
+
+	// Synthesize a copy of the root that has all fields that are present in the type
+	// but not set in the dynamic value set to their corresponding empty value.
+	// This enables users to interpolate variable references to fields that haven't
+	// been explicitly set in the dynamic value.
+	//
+	// For example: ${bundle.git.origin_url} should resolve to an empty string
+	// if a bundle isn't located in a Git repository (yet).
+	//
+	// This is consistent with the behavior prior to using the dynamic value system.
+	//
+	// We can ignore the diagnostics return value because we know that the dynamic value
+	// has already been normalized when it was first loaded from the configuration file.
+	//
+	normalized, _ := convert.Normalize(cfg, root, convert.IncludeMissingFields)
+
+	// If the pattern is nil, we resolve references in the entire configuration.
+	return dyn.MapByPattern(root, m.pattern, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+		// Resolve variable references in all values.
+		return dynvar.Resolve(v, func(path dyn.Path) (dyn.Value, error) {
+			path = expandVariableShorthand(path)
+
+			// Perform resolution only if the path starts with one of the specified prefixes.
+			for _, prefix := range prefixes {
+				if path.HasPrefix(prefix) {
+					return m.lookupFn(normalized, path)
+				}
+			}
+
+			return dyn.InvalidValue, dynvar.ErrSkipResolution
+		})
+	})
+}
+
+// expandVariableShorthand rewrites the shorthand path ${var.foo} into ${variables.foo.value}.
+func expandVariableShorthand(path dyn.Path) dyn.Path {
+	if len(path) == 2 && path.HasPrefix(dyn.NewPath(dyn.Key("var"))) {
+		return dyn.NewPath(
+			dyn.Key("variables"),
+			path[1],
+			dyn.Key("value"),
+		)
+	}
+	return path
+}
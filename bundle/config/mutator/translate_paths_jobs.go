@@ -51,6 +51,11 @@ func rewritePatterns(base dyn.Pattern) []jobRewritePattern {
 			translateNoOp,
 			noSkipRewrite,
 		},
+		{
+			base.Append(dyn.Key("spark_jar_task"), dyn.Key("jar_uri")),
+			translateNoOp,
+			noSkipRewrite,
+		},
 	}
 }
 
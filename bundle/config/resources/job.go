@@ -17,6 +17,22 @@ type Job struct {
 	Permissions    []Permission   `json:"permissions,omitempty"`
 	ModifiedStatus ModifiedStatus `json:"modified_status,omitempty" bundle:"internal"`
 
+	// Condition is evaluated after variable resolution. If it evaluates to
+	// false, the resource is removed from the configuration before deployment.
+	Condition string `json:"condition,omitempty"`
+
+	// TaskConditions holds the conditions of individual tasks, keyed by task key.
+	// It is populated when loading configuration because [jobs.Task] has no
+	// field to hold a condition.
+	TaskConditions map[string]string `json:"task_conditions,omitempty" bundle:"internal"`
+
+	// Apply lists the names of definitions to apply to this job.
+	Apply []string `json:"apply,omitempty"`
+
+	// TaskApply holds the names of definitions to apply to individual tasks,
+	// keyed by task key. It is populated in the same way as [Job.TaskConditions].
+	TaskApply map[string][]string `json:"task_apply,omitempty" bundle:"internal"`
+
 	paths.Paths
 
 	*jobs.JobSettings
@@ -16,6 +16,9 @@ type MlflowExperiment struct {
 	Permissions    []Permission   `json:"permissions,omitempty"`
 	ModifiedStatus ModifiedStatus `json:"modified_status,omitempty" bundle:"internal"`
 
+	// Condition determines whether this resource is deployed (see [Job.Condition]).
+	Condition string `json:"condition,omitempty"`
+
 	paths.Paths
 
 	*ml.Experiment
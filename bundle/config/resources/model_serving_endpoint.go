@@ -29,6 +29,9 @@ type ModelServingEndpoint struct {
 	Permissions []Permission `json:"permissions,omitempty"`
 
 	ModifiedStatus ModifiedStatus `json:"modified_status,omitempty" bundle:"internal"`
+
+	// Condition determines whether this resource is deployed (see [Job.Condition]).
+	Condition string `json:"condition,omitempty"`
 }
 
 func (s *ModelServingEndpoint) UnmarshalJSON(b []byte) error {
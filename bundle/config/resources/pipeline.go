@@ -16,6 +16,12 @@ type Pipeline struct {
 	Permissions    []Permission   `json:"permissions,omitempty"`
 	ModifiedStatus ModifiedStatus `json:"modified_status,omitempty" bundle:"internal"`
 
+	// Condition determines whether this resource is deployed (see [Job.Condition]).
+	Condition string `json:"condition,omitempty"`
+
+	// Apply lists the names of definitions to apply to this pipeline.
+	Apply []string `json:"apply,omitempty"`
+
 	paths.Paths
 
 	*pipelines.PipelineSpec
@@ -26,6 +26,9 @@ type QualityMonitor struct {
 	paths.Paths
 
 	ModifiedStatus ModifiedStatus `json:"modified_status,omitempty" bundle:"internal"`
+
+	// Condition determines whether this resource is deployed (see [Job.Condition]).
+	Condition string `json:"condition,omitempty"`
 }
 
 func (s *QualityMonitor) UnmarshalJSON(b []byte) error {
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// rewriteTaskField moves [field] of every task in the root and in every target
+// to the [dest] key of the job that contains the task, keyed by task key.
+//
+// Tasks are defined by the SDK and cannot hold fields that are specific to bundles.
+// This function lets the typed configuration of the job retain them instead.
+// Tasks without a task key are left as is.
+func rewriteTaskField(v dyn.Value, field, dest string) (dyn.Value, error) {
+	if v.Kind() != dyn.KindMap {
+		return v, nil
+	}
+
+	v, err := rewriteJobTaskField(v, field, dest)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	if v.Get("targets").Kind() != dyn.KindMap {
+		return v, nil
+	}
+
+	return dyn.Map(v, "targets", dyn.Foreach(func(_ dyn.Path, target dyn.Value) (dyn.Value, error) {
+		return rewriteJobTaskField(target, field, dest)
+	}))
+}
+
+func rewriteJobTaskField(v dyn.Value, field, dest string) (dyn.Value, error) {
+	if v.Get("resources").Get("jobs").Kind() != dyn.KindMap {
+		return v, nil
+	}
+
+	return dyn.Map(v, "resources.jobs", dyn.Foreach(func(_ dyn.Path, job dyn.Value) (dyn.Value, error) {
+		tasks, ok := job.Get("tasks").AsSequence()
+		if !ok {
+			return job, nil
+		}
+
+		var out []dyn.Value
+		values := dyn.NewMapping()
+		for _, task := range tasks {
+			m, ok := task.AsMap()
+			value := task.Get(field)
+			key := task.Get("task_key")
+			if !ok || !value.IsValid() || key.Kind() != dyn.KindString {
+				out = append(out, task)
+				continue
+			}
+
+			// Copy the task without the field.
+			nm := dyn.NewMapping()
+			for _, pair := range m.Pairs() {
+				if pair.Key.MustString() == field {
+					continue
+				}
+				nm.Set(pair.Key, pair.Value)
+			}
+
+			out = append(out, dyn.NewValue(nm, task.Location()))
+			values.Set(key, value)
+		}
+
+		if values.Len() == 0 {
+			return job, nil
+		}
+
+		job, err := dyn.Set(job, "tasks", dyn.NewValue(out, job.Get("tasks").Location()))
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		return dyn.Set(job, dest, dyn.NewValue(values, job.Get("tasks").Location()))
+	}))
+}
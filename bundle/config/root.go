@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/databricks/cli/bundle/config/resources"
@@ -12,6 +13,7 @@ import (
 	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/dyn"
 	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/cli/libs/dyn/jsonloader"
 	"github.com/databricks/cli/libs/dyn/merge"
 	"github.com/databricks/cli/libs/dyn/yamlloader"
 	"github.com/databricks/cli/libs/log"
@@ -34,6 +36,18 @@ type Root struct {
 	// `databricks.yml` are processed. Defaults to an empty list.
 	Include []string `json:"include,omitempty"`
 
+	// IncludeConditions maps include paths to the condition that applies to
+	// the resources they define. It is populated from include entries that
+	// are specified as a map with a "path" and a "condition" key.
+	// Other sections of the included files are merged regardless of the condition.
+	IncludeConditions map[string]string `json:"include_conditions,omitempty" bundle:"internal"`
+
+	// Definitions contains named configuration fragments that can be applied
+	// to jobs, tasks and pipelines by listing their names in an "apply" field.
+	// Fields that are set explicitly take precedence over fields in a fragment.
+	// Sequences that are set explicitly replace the sequences in a fragment.
+	Definitions map[string]dyn.Value `json:"definitions,omitempty"`
+
 	// Workspace contains details about the workspace to connect to
 	// and paths in the workspace tree to use for this bundle.
 	Workspace Workspace `json:"workspace,omitempty"`
@@ -60,8 +74,15 @@ type Root struct {
 	// RunAs section allows to define an execution identity for jobs and pipelines runs
 	RunAs *jobs.JobRunAs `json:"run_as,omitempty"`
 
+	// Scripts section allows to define commands that are executed
+	// at hooks before and after bundle commands and their phases.
+	Scripts map[ScriptHook]Script `json:"scripts,omitempty"`
+
 	Experimental *Experimental `json:"experimental,omitempty"`
 
+	// Validation section allows to define policy rules for the bundle configuration.
+	Validation Validation `json:"validation,omitempty"`
+
 	// Permissions section allows to define permissions which will be
 	// applied to all resources defined in bundle
 	Permissions []resources.Permission `json:"permissions,omitempty"`
@@ -80,8 +101,15 @@ func Load(path string) (*Root, diag.Diagnostics) {
 func LoadFromBytes(path string, raw []byte) (*Root, diag.Diagnostics) {
 	r := Root{}
 
-	// Load configuration tree from YAML.
-	v, err := yamlloader.LoadYAML(path, bytes.NewBuffer(raw))
+	// Load configuration tree from YAML or JSON, depending on the file extension.
+	var v dyn.Value
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc":
+		v, err = jsonloader.LoadJSON(path, bytes.NewBuffer(raw))
+	default:
+		v, err = yamlloader.LoadYAML(path, bytes.NewBuffer(raw))
+	}
 	if err != nil {
 		return nil, diag.Errorf("failed to load %s: %v", path, err)
 	}
@@ -92,6 +120,18 @@ func LoadFromBytes(path string, raw []byte) (*Root, diag.Diagnostics) {
 		return nil, diag.Errorf("failed to rewrite %s: %v", path, err)
 	}
 
+	// Move conditions that have no typed counterpart to where they can be retained.
+	v, err = rewriteConditions(v)
+	if err != nil {
+		return nil, diag.Errorf("failed to rewrite %s: %v", path, err)
+	}
+
+	// Move references to definitions from tasks to the job that contains them.
+	v, err = rewriteTaskField(v, "apply", "task_apply")
+	if err != nil {
+		return nil, diag.Errorf("failed to rewrite %s: %v", path, err)
+	}
+
 	// Normalize dynamic configuration tree according to configuration type.
 	v, diags := convert.Normalize(r, v)
 
@@ -334,6 +374,8 @@ func (r *Root) MergeTargetOverrides(name string) error {
 		"sync",
 		"permissions",
 		"variables",
+		"scripts",
+		"validation",
 	} {
 		if root, err = mergeField(root, target, f); err != nil {
 			return err
@@ -411,8 +453,19 @@ func rewriteShorthands(v dyn.Value) (dyn.Value, error) {
 		return v, nil
 	}
 
-	// For each target, rewrite the variables block.
+	// Rewrite the scripts block.
+	v, err := rewriteScriptShorthands(v)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	// For each target, rewrite the variables and scripts blocks.
 	return dyn.Map(v, "targets", dyn.Foreach(func(_ dyn.Path, target dyn.Value) (dyn.Value, error) {
+		target, err := rewriteScriptShorthands(target)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
 		// Confirm it has a variables block.
 		if target.Get("variables") == dyn.InvalidValue {
 			return target, nil
@@ -437,6 +490,23 @@ func rewriteShorthands(v dyn.Value) (dyn.Value, error) {
 	}))
 }
 
+// rewriteScriptShorthands rewrites scripts that are defined as a single string
+// to a map with a single key called "command".
+func rewriteScriptShorthands(v dyn.Value) (dyn.Value, error) {
+	if v.Kind() != dyn.KindMap || v.Get("scripts").Kind() != dyn.KindMap {
+		return v, nil
+	}
+
+	return dyn.Map(v, "scripts", dyn.Foreach(func(_ dyn.Path, script dyn.Value) (dyn.Value, error) {
+		if script.Kind() != dyn.KindString {
+			return script, nil
+		}
+		return dyn.NewValue(map[string]dyn.Value{
+			"command": script,
+		}, script.Location()), nil
+	}))
+}
+
 // validateVariableOverrides checks that all variables specified
 // in the target override are also defined in the root.
 func validateVariableOverrides(root, target dyn.Value) (err error) {
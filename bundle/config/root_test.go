@@ -119,3 +119,24 @@ func TestRootMergeTargetOverridesWithMode(t *testing.T) {
 	require.NoError(t, root.MergeTargetOverrides("development"))
 	assert.Equal(t, Development, root.Bundle.Mode)
 }
+
+func TestRootMergeTargetOverridesWithValidation(t *testing.T) {
+	root, diags := LoadFromBytes("databricks.yml", []byte(`
+validation:
+  production:
+    no_existing_clusters: true
+    require_task_retries: true
+targets:
+  prod:
+    validation:
+      production:
+        require_task_retries: false
+        require_failure_notifications: true
+`))
+	require.NoError(t, diags.Error())
+	require.NoError(t, root.MergeTargetOverrides("prod"))
+	assert.Equal(t, ProductionChecks{
+		NoExistingClusters:          true,
+		RequireFailureNotifications: true,
+	}, root.Validation.Production)
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Command string
+type ScriptHook string
+
+const (
+	ScriptPreInit      ScriptHook = "preinit"
+	ScriptPostInit     ScriptHook = "postinit"
+	ScriptPreBuild     ScriptHook = "prebuild"
+	ScriptPostBuild    ScriptHook = "postbuild"
+	ScriptPreDeploy    ScriptHook = "predeploy"
+	ScriptPostDeploy   ScriptHook = "postdeploy"
+	ScriptPreDestroy   ScriptHook = "predestroy"
+	ScriptPostDestroy  ScriptHook = "postdestroy"
+	ScriptPreValidate  ScriptHook = "prevalidate"
+	ScriptPostValidate ScriptHook = "postvalidate"
+	ScriptPreBind      ScriptHook = "prebind"
+	ScriptPostBind     ScriptHook = "postbind"
+	ScriptPreRun       ScriptHook = "prerun"
+	ScriptPostRun      ScriptHook = "postrun"
+)
+
+// ScriptHooks lists all hooks that scripts can be defined for.
+var ScriptHooks = []ScriptHook{
+	ScriptPreInit,
+	ScriptPostInit,
+	ScriptPreBuild,
+	ScriptPostBuild,
+	ScriptPreDeploy,
+	ScriptPostDeploy,
+	ScriptPreDestroy,
+	ScriptPostDestroy,
+	ScriptPreValidate,
+	ScriptPostValidate,
+	ScriptPreBind,
+	ScriptPostBind,
+	ScriptPreRun,
+	ScriptPostRun,
+}
+
+// Script defines a command that is executed at a hook.
+// A script can also be defined as a string, which is shorthand for its command.
+type Script struct {
+	// Command to execute in the bundle root directory.
+	Command Command `json:"command"`
+
+	// Maximum duration of the script, for example "30s" or "5m".
+	// The script is stopped and fails if it runs longer. By default there is no limit.
+	Timeout string `json:"timeout,omitempty"`
+
+	// If set, a failure of the script is reported as a warning
+	// and the command that executed the hook continues.
+	ContinueOnError bool `json:"continue_on_error,omitempty"`
+
+	// Keys of the resources to execute the script for.
+	// Only applies to the prerun and postrun hooks. If empty, the script is executed for all resources.
+	// Keys may be qualified with their resource type, for example "jobs.my_job".
+	Resources []string `json:"resources,omitempty"`
+}
+
+// TimeoutDuration returns the parsed timeout of the script or zero if it has none.
+func (s Script) TimeoutDuration() (time.Duration, error) {
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", s.Timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q: must be positive", s.Timeout)
+	}
+	return d, nil
+}
+
+// AppliesTo returns true if the script must be executed for the resource with the specified key.
+// The key includes the resource type, for example "jobs.my_job".
+func (s Script) AppliesTo(key string) bool {
+	if len(s.Resources) == 0 {
+		return true
+	}
+	_, name, _ := strings.Cut(key, ".")
+	return slices.Contains(s.Resources, key) || slices.Contains(s.Resources, name)
+}
@@ -43,6 +43,13 @@ type Target struct {
 	Sync *Sync `json:"sync,omitempty"`
 
 	Permissions []resources.Permission `json:"permissions,omitempty"`
+
+	// Override or define scripts for this target.
+	Scripts map[ScriptHook]Script `json:"scripts,omitempty"`
+
+	// Override validation settings for this target, for example to enable
+	// or disable individual production checks.
+	Validation *Validation `json:"validation,omitempty"`
 }
 
 const (
@@ -0,0 +1,376 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/dynvar"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+)
+
+// ComputeSettings validates the compute settings of jobs and pipelines against the workspace.
+//
+// It verifies that Spark versions, node types, instance pools, cluster policies and existing
+// clusters exist in the workspace, and that cluster specifications comply with their policy.
+func ComputeSettings() bundle.ReadOnlyMutator {
+	return &computeSettings{}
+}
+
+type computeSettings struct {
+}
+
+func (v *computeSettings) Name() string {
+	return "validate:compute_settings"
+}
+
+var (
+	// Patterns for cluster specifications.
+	clusterSpecPatterns = []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("job_clusters"), dyn.AnyIndex(), dyn.Key("new_cluster")),
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks"), dyn.AnyIndex(), dyn.Key("new_cluster")),
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks"), dyn.AnyIndex(), dyn.Key("for_each_task"), dyn.Key("task"), dyn.Key("new_cluster")),
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("pipelines"), dyn.AnyKey(), dyn.Key("clusters"), dyn.AnyIndex()),
+	}
+
+	// Patterns for references to existing clusters.
+	existingClusterPatterns = []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks"), dyn.AnyIndex(), dyn.Key("existing_cluster_id")),
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks"), dyn.AnyIndex(), dyn.Key("for_each_task"), dyn.Key("task"), dyn.Key("existing_cluster_id")),
+	}
+)
+
+func (v *computeSettings) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	c := &computeChecker{
+		ctx:      ctx,
+		w:        rb.WorkspaceClient(),
+		pools:    make(map[string]*lookupResult),
+		policies: make(map[string]*policyLookupResult),
+		clusters: make(map[string]*lookupResult),
+	}
+
+	root := rb.Config().Value()
+	for _, pattern := range clusterSpecPatterns {
+		_, err := dyn.MapByPattern(root, pattern, func(p dyn.Path, spec dyn.Value) (dyn.Value, error) {
+			c.checkClusterSpec(slices.Clone(p), spec)
+			return spec, nil
+		})
+		if err != nil {
+			c.diags = c.diags.Extend(diag.FromErr(err))
+		}
+	}
+
+	for _, pattern := range existingClusterPatterns {
+		_, err := dyn.MapByPattern(root, pattern, func(p dyn.Path, id dyn.Value) (dyn.Value, error) {
+			c.checkExistingCluster(slices.Clone(p), id)
+			return id, nil
+		})
+		if err != nil {
+			c.diags = c.diags.Extend(diag.FromErr(err))
+		}
+	}
+
+	return c.diags
+}
+
+// lookupResult is the cached result of looking up an object in the workspace.
+type lookupResult struct {
+	err error
+}
+
+type policyLookupResult struct {
+	definition map[string]policyElement
+	err        error
+}
+
+// computeChecker caches the objects it looks up in the workspace,
+// because cluster specifications often share their settings.
+type computeChecker struct {
+	ctx context.Context
+	w   *databricks.WorkspaceClient
+
+	sparkVersions []string
+	nodeTypes     []string
+	listErr       map[string]error
+
+	pools    map[string]*lookupResult
+	policies map[string]*policyLookupResult
+	clusters map[string]*lookupResult
+
+	diags diag.Diagnostics
+}
+
+func (c *computeChecker) report(severity diag.Severity, id diag.ID, p dyn.Path, v dyn.Value, format string, args ...any) {
+	c.diags = c.diags.Append(diag.Diagnostic{
+		Severity: severity,
+		Summary:  fmt.Sprintf(format, args...),
+		Location: v.Location(),
+		Path:     p,
+		ID:       id,
+	})
+}
+
+// reportLookupError reports a missing object as an error. Other errors are reported
+// as warnings because they mean that the object couldn't be verified, for example
+// because the user doesn't have permission to view it.
+func (c *computeChecker) reportLookupError(err error, p dyn.Path, v dyn.Value, kind string, id string) {
+	if apierr.IsMissing(err) {
+		c.report(diag.Error, config.ComputeNotFound, p, v, "%s %s does not exist", kind, id)
+		return
+	}
+	c.report(diag.Warning, config.ComputeNotVerified, p, v, "unable to verify %s %s: %v", kind, id, err)
+}
+
+// stringField returns the value of a field if it is set to a string without references.
+// References to resources are resolved during deployment, so they can't be verified.
+func stringField(v dyn.Value, key string) (string, dyn.Value, bool) {
+	fv := v.Get(key)
+	s, ok := fv.AsString()
+	if !ok || s == "" || dynvar.ContainsVariableReference(s) {
+		return "", fv, false
+	}
+	return s, fv, true
+}
+
+func (c *computeChecker) checkClusterSpec(p dyn.Path, spec dyn.Value) {
+	if spec.Kind() != dyn.KindMap {
+		return
+	}
+
+	if version, v, ok := stringField(spec, "spark_version"); ok {
+		versions, ok := c.listSparkVersions(p, v)
+		if ok && !slices.Contains(versions, version) {
+			c.report(diag.Error, config.SparkVersionNotAvailable, p.Append(dyn.Key("spark_version")), v, "Spark version %s is not available in the workspace", version)
+		}
+	}
+
+	for _, key := range []string{"node_type_id", "driver_node_type_id"} {
+		nodeType, v, ok := stringField(spec, key)
+		if !ok {
+			continue
+		}
+		nodeTypes, ok := c.listNodeTypes(p, v)
+		if ok && !slices.Contains(nodeTypes, nodeType) {
+			c.report(diag.Error, config.NodeTypeNotAvailable, p.Append(dyn.Key(key)), v, "node type %s is not available in the workspace", nodeType)
+		}
+	}
+
+	for _, key := range []string{"instance_pool_id", "driver_instance_pool_id"} {
+		id, v, ok := stringField(spec, key)
+		if !ok {
+			continue
+		}
+		if err := c.getInstancePool(id); err != nil {
+			c.reportLookupError(err, p.Append(dyn.Key(key)), v, "instance pool", id)
+		}
+	}
+
+	if id, v, ok := stringField(spec, "policy_id"); ok {
+		definition, err := c.getPolicy(id)
+		if err != nil {
+			c.reportLookupError(err, p.Append(dyn.Key("policy_id")), v, "cluster policy", id)
+		} else {
+			c.checkPolicyCompliance(p, spec, id, definition)
+		}
+	}
+}
+
+func (c *computeChecker) checkExistingCluster(p dyn.Path, v dyn.Value) {
+	id, ok := v.AsString()
+	if !ok || id == "" || dynvar.ContainsVariableReference(id) {
+		return
+	}
+
+	r, ok := c.clusters[id]
+	if !ok {
+		_, err := c.w.Clusters.Get(c.ctx, compute.GetClusterRequest{ClusterId: id})
+		r = &lookupResult{err: err}
+		c.clusters[id] = r
+	}
+	if r.err != nil {
+		c.reportLookupError(r.err, p, v, "cluster", id)
+	}
+}
+
+// listFailed returns true if listing objects failed. The failure is only reported once.
+func (c *computeChecker) listFailed(kind string, err error, p dyn.Path, v dyn.Value) bool {
+	if err == nil {
+		return false
+	}
+	if c.listErr == nil {
+		c.listErr = make(map[string]error)
+	}
+	if _, ok := c.listErr[kind]; !ok {
+		c.listErr[kind] = err
+		c.report(diag.Warning, config.ComputeNotVerified, p, v, "unable to list %s: %v", kind, err)
+	}
+	return true
+}
+
+func (c *computeChecker) listSparkVersions(p dyn.Path, v dyn.Value) ([]string, bool) {
+	if _, ok := c.listErr["Spark versions"]; ok {
+		return nil, false
+	}
+	if c.sparkVersions == nil {
+		resp, err := c.w.Clusters.SparkVersions(c.ctx)
+		if c.listFailed("Spark versions", err, p, v) {
+			return nil, false
+		}
+		c.sparkVersions = []string{}
+		for _, version := range resp.Versions {
+			c.sparkVersions = append(c.sparkVersions, version.Key)
+		}
+	}
+	return c.sparkVersions, true
+}
+
+func (c *computeChecker) listNodeTypes(p dyn.Path, v dyn.Value) ([]string, bool) {
+	if _, ok := c.listErr["node types"]; ok {
+		return nil, false
+	}
+	if c.nodeTypes == nil {
+		resp, err := c.w.Clusters.ListNodeTypes(c.ctx)
+		if c.listFailed("node types", err, p, v) {
+			return nil, false
+		}
+		c.nodeTypes = []string{}
+		for _, nodeType := range resp.NodeTypes {
+			c.nodeTypes = append(c.nodeTypes, nodeType.NodeTypeId)
+		}
+	}
+	return c.nodeTypes, true
+}
+
+func (c *computeChecker) getInstancePool(id string) error {
+	r, ok := c.pools[id]
+	if !ok {
+		_, err := c.w.InstancePools.Get(c.ctx, compute.GetInstancePoolRequest{InstancePoolId: id})
+		r = &lookupResult{err: err}
+		c.pools[id] = r
+	}
+	return r.err
+}
+
+func (c *computeChecker) getPolicy(id string) (map[string]policyElement, error) {
+	r, ok := c.policies[id]
+	if !ok {
+		r = &policyLookupResult{}
+		policy, err := c.w.ClusterPolicies.Get(c.ctx, compute.GetClusterPolicyRequest{PolicyId: id})
+		if err != nil {
+			r.err = err
+		} else if policy.Definition != "" {
+			err = json.Unmarshal([]byte(policy.Definition), &r.definition)
+			if err != nil {
+				r.err = fmt.Errorf("failed to parse policy definition: %w", err)
+			}
+		}
+		c.policies[id] = r
+	}
+	return r.definition, r.err
+}
+
+// policyElement is the definition of a single attribute in a cluster policy.
+// See https://docs.databricks.com/en/admin/clusters/policy-definition.html.
+type policyElement struct {
+	Type     string   `json:"type"`
+	Value    any      `json:"value,omitempty"`
+	Values   []any    `json:"values,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+}
+
+// policyAttributePath returns the path of a policy attribute in a cluster specification.
+// Attributes of map fields such as "spark_conf.spark.speculation" refer to a single key
+// that may contain dots. Attributes with wildcards or indices are not supported.
+func policyAttributePath(attr string) (dyn.Path, bool) {
+	if strings.ContainsAny(attr, "*[") {
+		return nil, false
+	}
+	for _, prefix := range []string{"spark_conf", "spark_env_vars", "custom_tags"} {
+		if rest, ok := strings.CutPrefix(attr, prefix+"."); ok {
+			return dyn.NewPath(dyn.Key(prefix), dyn.Key(rest)), true
+		}
+	}
+	p, err := dyn.NewPathFromString(attr)
+	return p, err == nil
+}
+
+// checkPolicyCompliance reports values in the cluster specification that the policy doesn't allow.
+// Attributes that aren't set are filled in by the policy when the cluster is created, so only
+// values that are set are verified.
+func (c *computeChecker) checkPolicyCompliance(p dyn.Path, spec dyn.Value, policyId string, definition map[string]policyElement) {
+	attrs := make([]string, 0, len(definition))
+	for attr := range definition {
+		attrs = append(attrs, attr)
+	}
+	slices.Sort(attrs)
+
+	for _, attr := range attrs {
+		ap, ok := policyAttributePath(attr)
+		if !ok {
+			continue
+		}
+		v, err := dyn.GetByPath(spec, ap)
+		if err != nil || !v.IsValid() {
+			continue
+		}
+		if s, ok := v.AsString(); ok && dynvar.ContainsVariableReference(s) {
+			continue
+		}
+
+		reason := policyViolation(definition[attr], v)
+		if reason != "" {
+			c.report(diag.Error, config.ClusterPolicyViolation, p.Append(ap...), v, "%s does not comply with cluster policy %s: %s", attr, policyId, reason)
+		}
+	}
+}
+
+// policyViolation returns why the value violates the policy element, or an empty string if it doesn't.
+func policyViolation(e policyElement, v dyn.Value) string {
+	actual := fmt.Sprint(v.AsAny())
+	switch e.Type {
+	case "fixed":
+		if actual != fmt.Sprint(e.Value) {
+			return fmt.Sprintf("the value must be %v, found %s", e.Value, actual)
+		}
+	case "forbidden":
+		return "the attribute is forbidden"
+	case "allowlist":
+		if !slices.ContainsFunc(e.Values, func(a any) bool { return fmt.Sprint(a) == actual }) {
+			return fmt.Sprintf("the value must be one of %v, found %s", e.Values, actual)
+		}
+	case "blocklist":
+		if slices.ContainsFunc(e.Values, func(a any) bool { return fmt.Sprint(a) == actual }) {
+			return fmt.Sprintf("the value %s is not allowed", actual)
+		}
+	case "regex":
+		// Policies match the pattern against the whole value.
+		re, err := regexp.Compile("^(?:" + e.Pattern + ")$")
+		if err == nil && !re.MatchString(actual) {
+			return fmt.Sprintf("the value %s doesn't match %q", actual, e.Pattern)
+		}
+	case "range":
+		n, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return fmt.Sprintf("the value %s must be a number", actual)
+		}
+		if e.MinValue != nil && n < *e.MinValue {
+			return fmt.Sprintf("the value %s must be at least %v", actual, *e.MinValue)
+		}
+		if e.MaxValue != nil && n > *e.MaxValue {
+			return fmt.Sprintf("the value %s must be at most %v", actual, *e.MaxValue)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,166 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func applyComputeSettings(t *testing.T, m *mocks.MockWorkspaceClient, yaml string) diag.Diagnostics {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(yaml))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	return bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), ComputeSettings())
+}
+
+func mockComputeLists(m *mocks.MockWorkspaceClient) {
+	clustersApi := m.GetMockClustersAPI()
+	clustersApi.EXPECT().SparkVersions(mock.Anything).Return(&compute.GetSparkVersionsResponse{
+		Versions: []compute.SparkVersion{{Key: "14.3.x-scala2.12"}},
+	}, nil).Maybe()
+	clustersApi.EXPECT().ListNodeTypes(mock.Anything).Return(&compute.ListNodeTypesResponse{
+		NodeTypes: []compute.NodeType{{NodeTypeId: "i3.xlarge"}},
+	}, nil).Maybe()
+}
+
+func TestComputeSettingsValid(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	mockComputeLists(m)
+	m.GetMockInstancePoolsAPI().EXPECT().Get(mock.Anything, compute.GetInstancePoolRequest{InstancePoolId: "pool"}).Return(&compute.GetInstancePool{}, nil).Once()
+	m.GetMockClustersAPI().EXPECT().Get(mock.Anything, compute.GetClusterRequest{ClusterId: "cluster"}).Return(&compute.ClusterDetails{}, nil).Once()
+
+	diags := applyComputeSettings(t, m, `
+resources:
+  jobs:
+    job1:
+      job_clusters:
+        - job_cluster_key: default
+          new_cluster:
+            spark_version: 14.3.x-scala2.12
+            node_type_id: i3.xlarge
+        - job_cluster_key: pool
+          new_cluster:
+            spark_version: 14.3.x-scala2.12
+            instance_pool_id: pool
+            driver_instance_pool_id: pool
+      tasks:
+        - task_key: a
+          existing_cluster_id: cluster
+        - task_key: b
+          existing_cluster_id: cluster
+        - task_key: c
+          existing_cluster_id: ${resources.clusters.other.id}
+`)
+	assert.Empty(t, diags)
+}
+
+func TestComputeSettingsUnavailable(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	mockComputeLists(m)
+	m.GetMockInstancePoolsAPI().EXPECT().Get(mock.Anything, compute.GetInstancePoolRequest{InstancePoolId: "missing"}).Return(nil, apierr.ErrNotFound).Once()
+	m.GetMockClustersAPI().EXPECT().Get(mock.Anything, compute.GetClusterRequest{ClusterId: "missing"}).Return(nil, apierr.ErrNotFound).Once()
+	m.GetMockClustersAPI().EXPECT().Get(mock.Anything, compute.GetClusterRequest{ClusterId: "forbidden"}).Return(nil, errors.New("permission denied")).Once()
+
+	diags := applyComputeSettings(t, m, `
+resources:
+  jobs:
+    job1:
+      tasks:
+        - task_key: a
+          new_cluster:
+            spark_version: 9.9.x-scala2.12
+            node_type_id: x9.huge
+            instance_pool_id: missing
+        - task_key: b
+          existing_cluster_id: missing
+        - task_key: c
+          existing_cluster_id: forbidden
+`)
+	require.Len(t, diags, 5)
+
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "Spark version 9.9.x-scala2.12 is not available in the workspace", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].new_cluster.spark_version", diags[0].Path.String())
+	assert.Equal(t, "databricks.yml", diags[0].Location.File)
+	assert.Equal(t, 8, diags[0].Location.Line)
+
+	assert.Equal(t, "node type x9.huge is not available in the workspace", diags[1].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].new_cluster.node_type_id", diags[1].Path.String())
+
+	assert.Equal(t, "instance pool missing does not exist", diags[2].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].new_cluster.instance_pool_id", diags[2].Path.String())
+
+	assert.Equal(t, diag.Error, diags[3].Severity)
+	assert.Equal(t, "cluster missing does not exist", diags[3].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[1].existing_cluster_id", diags[3].Path.String())
+
+	assert.Equal(t, diag.Warning, diags[4].Severity)
+	assert.Equal(t, "unable to verify cluster forbidden: permission denied", diags[4].Summary)
+}
+
+func TestComputeSettingsPolicyCompliance(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	mockComputeLists(m)
+	m.GetMockClusterPoliciesAPI().EXPECT().Get(mock.Anything, compute.GetClusterPolicyRequest{PolicyId: "policy"}).Return(&compute.Policy{
+		Definition: `{
+			"node_type_id": {"type": "allowlist", "values": ["i3.xlarge", "i3.2xlarge"]},
+			"autoscale.max_workers": {"type": "range", "maxValue": 10},
+			"spark_conf.spark.databricks.cluster.profile": {"type": "forbidden"},
+			"custom_tags.team": {"type": "fixed", "value": "data"},
+			"init_scripts.*.workspace.destination": {"type": "unlimited"}
+		}`,
+	}, nil).Once()
+	m.GetMockClusterPoliciesAPI().EXPECT().Get(mock.Anything, compute.GetClusterPolicyRequest{PolicyId: "missing"}).Return(nil, apierr.ErrNotFound).Once()
+
+	diags := applyComputeSettings(t, m, `
+resources:
+  pipelines:
+    pipeline1:
+      clusters:
+        - label: default
+          policy_id: policy
+          node_type_id: i3.xlarge
+          autoscale:
+            min_workers: 1
+            max_workers: 20
+          spark_conf:
+            spark.databricks.cluster.profile: singleNode
+          custom_tags:
+            team: data
+        - label: maintenance
+          policy_id: missing
+`)
+	require.Len(t, diags, 3)
+
+	assert.Equal(t, "autoscale.max_workers does not comply with cluster policy policy: the value 20 must be at most 10", diags[0].Summary)
+	assert.Equal(t, "resources.pipelines.pipeline1.clusters[0].autoscale.max_workers", diags[0].Path.String())
+	assert.Equal(t, 11, diags[0].Location.Line)
+
+	assert.Equal(t, "spark_conf.spark.databricks.cluster.profile does not comply with cluster policy policy: the attribute is forbidden", diags[1].Summary)
+	assert.Equal(t, "resources.pipelines.pipeline1.clusters[0].spark_conf.spark.databricks.cluster.profile", diags[1].Path.String())
+
+	assert.Equal(t, "cluster policy missing does not exist", diags[2].Summary)
+	assert.Equal(t, "resources.pipelines.pipeline1.clusters[1].policy_id", diags[2].Path.String())
+}
+
+func TestPolicyViolationRegexMatchesWholeValue(t *testing.T) {
+	e := policyElement{Type: "regex", Pattern: "[0-9.]+x-scala2\\.12|custom"}
+
+	assert.Empty(t, policyViolation(e, dyn.V("14.3.x-scala2.12")))
+	assert.Empty(t, policyViolation(e, dyn.V("custom")))
+	assert.Equal(t, `the value 14.3.x-scala2.12-photon doesn't match "[0-9.]+x-scala2\\.12|custom"`, policyViolation(e, dyn.V("14.3.x-scala2.12-photon")))
+	assert.NotEmpty(t, policyViolation(e, dyn.V("not-custom")))
+}
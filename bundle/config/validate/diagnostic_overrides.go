@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+)
+
+// DiagnosticOverrides reports overrides in the "bundle.diagnostics" section that can't be applied
+// and warns about overrides for IDs that are never reported, which are likely misspelled.
+func DiagnosticOverrides() bundle.ReadOnlyMutator {
+	return &diagnosticOverrides{}
+}
+
+type diagnosticOverrides struct {
+}
+
+func (v *diagnosticOverrides) Name() string {
+	return "validate:diagnostic_overrides"
+}
+
+// ValidateDiagnosticOverrides applies the [DiagnosticOverrides] validator as part of a phase.
+func ValidateDiagnosticOverrides() bundle.Mutator {
+	return &validateDiagnosticOverrides{}
+}
+
+type validateDiagnosticOverrides struct {
+}
+
+func (v *validateDiagnosticOverrides) Name() string {
+	return "ValidateDiagnosticOverrides"
+}
+
+func (v *validateDiagnosticOverrides) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	return bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), DiagnosticOverrides())
+}
+
+func (v *diagnosticOverrides) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	for i, o := range rb.Config().Bundle.Diagnostics {
+		err := o.Validate()
+		if err == nil {
+			if !config.IsKnownDiagnosticID(diag.ID(o.ID)) {
+				loc := location{path: fmt.Sprintf("bundle.diagnostics[%d].id", i), rb: rb}
+				diags = diags.Append(diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("unknown diagnostic ID %s", o.ID),
+					Detail:   "The override has no effect because diagnostics with this ID are never reported.",
+					Location: loc.Location(),
+					Path:     loc.Path(),
+					ID:       config.InvalidDiagnosticOverride,
+				})
+			}
+			continue
+		}
+
+		loc := location{path: fmt.Sprintf("bundle.diagnostics[%d]", i), rb: rb}
+		diags = diags.Append(diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("invalid diagnostic override: %v", err),
+			Location: loc.Location(),
+			Path:     loc.Path(),
+			ID:       config.InvalidDiagnosticOverride,
+		})
+	}
+	return diags
+}
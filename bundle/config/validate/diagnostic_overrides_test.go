@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticOverrides(t *testing.T) {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(`
+bundle:
+  name: test
+  diagnostics:
+    - id: sync-pattern-no-match
+      severity: ignore
+    - id: unused-variable
+      severity: fatal
+    - id: unused-variable
+      paths: ["variables[x]"]
+      severity: error
+`))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	diags = bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), DiagnosticOverrides())
+	require.Len(t, diags, 2)
+
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, config.InvalidDiagnosticOverride, diags[0].ID)
+	assert.Equal(t, `invalid diagnostic override: unknown severity "fatal", expected "error", "warning", "info" or "ignore"`, diags[0].Summary)
+	assert.Equal(t, "bundle.diagnostics[1]", diags[0].Path.String())
+	assert.Equal(t, 7, diags[0].Location.Line)
+
+	assert.Equal(t, "invalid diagnostic override: invalid pattern: variables[x]", diags[1].Summary)
+	assert.Equal(t, "bundle.diagnostics[2]", diags[1].Path.String())
+}
+
+func TestDiagnosticOverridesUnknownID(t *testing.T) {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(`
+bundle:
+  name: test
+  diagnostics:
+    - id: sync-patern-no-match
+      severity: ignore
+    - id: policy-rule:owner-tag
+      severity: warning
+`))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	diags = bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), DiagnosticOverrides())
+	require.Len(t, diags, 1)
+
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.InvalidDiagnosticOverride, diags[0].ID)
+	assert.Equal(t, "unknown diagnostic ID sync-patern-no-match", diags[0].Summary)
+	assert.Equal(t, "bundle.diagnostics[0].id", diags[0].Path.String())
+	assert.Equal(t, 5, diags[0].Location.Line)
+}
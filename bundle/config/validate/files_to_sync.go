@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deploy/files"
 	"github.com/databricks/cli/libs/diag"
 )
@@ -39,6 +40,7 @@ func (v *filesToSync) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.
 		diags = diags.Append(diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "There are no files to sync, please check your .gitignore",
+			ID:       config.NoFilesToSync,
 		})
 	} else {
 		loc := location{path: "sync.exclude", rb: rb}
@@ -47,6 +49,7 @@ func (v *filesToSync) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.
 			Summary:  "There are no files to sync, please check your .gitignore and sync.exclude configuration",
 			Location: loc.Location(),
 			Path:     loc.Path(),
+			ID:       config.NoFilesToSync,
 		})
 	}
 
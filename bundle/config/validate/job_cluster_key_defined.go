@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
 )
 
@@ -43,6 +44,7 @@ func (v *jobClusterKeyDefined) Apply(ctx context.Context, rb bundle.ReadOnlyBund
 						Summary:  fmt.Sprintf("job_cluster_key %s is not defined", task.JobClusterKey),
 						Location: loc.Location(),
 						Path:     loc.Path(),
+						ID:       config.JobClusterKeyNotDefined,
 					})
 				}
 			}
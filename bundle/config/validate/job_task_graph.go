@@ -0,0 +1,283 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"golang.org/x/exp/maps"
+)
+
+// JobTaskGraph validates the task graph of every job.
+//
+// It reports duplicate task keys, dependencies on tasks that don't exist, dependency cycles,
+// run_if conditions and dependency outcomes that can't be met, and environment keys
+// that are not defined in the job's environments.
+//
+// Tasks of a job that share a key are merged into one when the bundle is initialized,
+// so duplicate keys of a job's tasks are reported as warnings based on the configuration
+// as it was loaded, before a target was selected.
+func JobTaskGraph() bundle.ReadOnlyMutator {
+	return &jobTaskGraph{}
+}
+
+type jobTaskGraph struct {
+}
+
+func (v *jobTaskGraph) Name() string {
+	return "validate:job_task_graph"
+}
+
+func (v *jobTaskGraph) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	diags = diags.Extend(duplicateTaskKeys(rb.LoadedConfig()))
+
+	jobs := rb.Config().Resources.Jobs
+	keys := maps.Keys(jobs)
+	slices.Sort(keys)
+	for _, k := range keys {
+		if jobs[k] == nil || jobs[k].JobSettings == nil {
+			continue
+		}
+		g := &taskGraph{
+			rb:   rb,
+			job:  jobs[k],
+			path: dyn.NewPath(dyn.Key("resources"), dyn.Key("jobs"), dyn.Key(k)),
+		}
+		diags = diags.Extend(g.validate())
+	}
+
+	return diags
+}
+
+// taskGraph validates the tasks of a single job.
+type taskGraph struct {
+	rb   bundle.ReadOnlyBundle
+	job  *resources.Job
+	path dyn.Path
+
+	// Index of the first task with a given key.
+	tasks map[string]int
+
+	diags diag.Diagnostics
+}
+
+func (g *taskGraph) report(severity diag.Severity, id diag.ID, p dyn.Path, format string, args ...any) {
+	loc := location{path: p.String(), rb: g.rb}
+	g.diags = g.diags.Append(diag.Diagnostic{
+		Severity: severity,
+		Summary:  fmt.Sprintf(format, args...),
+		Location: loc.Location(),
+		Path:     loc.Path(),
+		ID:       id,
+	})
+}
+
+func (g *taskGraph) validate() diag.Diagnostics {
+	g.tasks = make(map[string]int)
+
+	environments := make(map[string]bool)
+	for _, env := range g.job.Environments {
+		environments[env.EnvironmentKey] = true
+	}
+
+	checkEnvironment := func(task jobs.Task, p dyn.Path) {
+		if task.EnvironmentKey != "" && !environments[task.EnvironmentKey] {
+			g.report(diag.Error, config.EnvironmentKeyNotDefined, p.Append(dyn.Key("environment_key")), "environment_key %s is not defined", task.EnvironmentKey)
+		}
+	}
+
+	// Task keys of tasks nested in for_each_task share the namespace of the job's tasks.
+	// The job's tasks themselves have unique keys because tasks with the same key are merged.
+	keys := make(map[string]bool)
+	for i, task := range g.job.Tasks {
+		keys[task.TaskKey] = true
+		if _, ok := g.tasks[task.TaskKey]; !ok {
+			g.tasks[task.TaskKey] = i
+		}
+	}
+
+	for i, task := range g.job.Tasks {
+		p := g.path.Append(dyn.Key("tasks"), dyn.Index(i))
+		checkEnvironment(task, p)
+		if task.ForEachTask == nil {
+			continue
+		}
+
+		nested := task.ForEachTask.Task
+		np := p.Append(dyn.Key("for_each_task"), dyn.Key("task"))
+		if nested.TaskKey != "" {
+			if keys[nested.TaskKey] {
+				g.report(diag.Error, config.DuplicateTaskKey, np.Append(dyn.Key("task_key")), "duplicate task key %s", nested.TaskKey)
+			}
+			keys[nested.TaskKey] = true
+		}
+		checkEnvironment(nested, np)
+	}
+
+	for i, task := range g.job.Tasks {
+		g.validateDependencies(i, task)
+	}
+
+	g.validateCycles()
+	return g.diags
+}
+
+func (g *taskGraph) validateDependencies(i int, task jobs.Task) {
+	p := g.path.Append(dyn.Key("tasks"), dyn.Index(i))
+
+	switch task.RunIf {
+	case "", jobs.RunIfAllSuccess, jobs.RunIfAllDone, jobs.RunIfAllFailed,
+		jobs.RunIfAtLeastOneFailed, jobs.RunIfAtLeastOneSuccess, jobs.RunIfNoneFailed:
+	default:
+		g.report(diag.Error, config.InvalidRunIf, p.Append(dyn.Key("run_if")), "run_if %s is not a valid condition", task.RunIf)
+	}
+
+	if len(task.DependsOn) == 0 {
+		if task.RunIf != "" && task.RunIf != jobs.RunIfAllSuccess {
+			g.report(diag.Warning, config.RunIfWithoutDependencies, p.Append(dyn.Key("run_if")), "run_if %s has no effect because task %s doesn't depend on other tasks", task.RunIf, task.TaskKey)
+		}
+		return
+	}
+
+	// Outcomes required from each condition task.
+	outcomes := make(map[string]map[string]bool)
+
+	for j, dep := range task.DependsOn {
+		dp := p.Append(dyn.Key("depends_on"), dyn.Index(j))
+
+		k, ok := g.tasks[dep.TaskKey]
+		if !ok {
+			g.report(diag.Error, config.TaskDependencyNotFound, dp.Append(dyn.Key("task_key")), "task %s depends on task %s, which doesn't exist", task.TaskKey, dep.TaskKey)
+			continue
+		}
+
+		if dep.Outcome == "" {
+			continue
+		}
+		if g.job.Tasks[k].ConditionTask == nil {
+			g.report(diag.Error, config.InvalidDependencyOutcome, dp.Append(dyn.Key("outcome")), "outcome can only be specified for dependencies on condition tasks, but %s is not a condition task", dep.TaskKey)
+			continue
+		}
+		if dep.Outcome != "true" && dep.Outcome != "false" {
+			g.report(diag.Error, config.InvalidDependencyOutcome, dp.Append(dyn.Key("outcome")), "outcome %s of condition task %s must be \"true\" or \"false\"", dep.Outcome, dep.TaskKey)
+			continue
+		}
+		if outcomes[dep.TaskKey] == nil {
+			outcomes[dep.TaskKey] = make(map[string]bool)
+		}
+		outcomes[dep.TaskKey][dep.Outcome] = true
+	}
+
+	// A condition task has a single outcome, so requiring both outcomes for
+	// all dependencies to succeed means the task never runs.
+	if task.RunIf == "" || task.RunIf == jobs.RunIfAllSuccess {
+		conditions := maps.Keys(outcomes)
+		slices.Sort(conditions)
+		for _, condition := range conditions {
+			if len(outcomes[condition]) > 1 {
+				g.report(diag.Warning, config.UnreachableTask, p.Append(dyn.Key("depends_on")), "task %s never runs because it requires both outcomes of condition task %s to succeed", task.TaskKey, condition)
+			}
+		}
+	}
+}
+
+// validateCycles reports every dependency cycle found by a depth-first search of the tasks.
+func (g *taskGraph) validateCycles() {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(g.job.Tasks))
+	var stack []int
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		stack = append(stack, i)
+
+		for j, dep := range g.job.Tasks[i].DependsOn {
+			k, ok := g.tasks[dep.TaskKey]
+			if !ok {
+				continue
+			}
+			switch state[k] {
+			case unvisited:
+				visit(k)
+			case visiting:
+				start := slices.Index(stack, k)
+				var cycle []string
+				for _, n := range stack[start:] {
+					cycle = append(cycle, g.job.Tasks[n].TaskKey)
+				}
+				cycle = append(cycle, dep.TaskKey)
+				p := g.path.Append(dyn.Key("tasks"), dyn.Index(i), dyn.Key("depends_on"), dyn.Index(j))
+				g.report(diag.Error, config.TaskDependencyCycle, p, "tasks have a dependency cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+	}
+
+	for i := range g.job.Tasks {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+}
+
+// duplicateTaskKeys returns a warning for every task of a job in the loaded configuration
+// that has the same task key as a task that precedes it, both in the top-level resources
+// and in the resources of every target.
+func duplicateTaskKeys(root dyn.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	patterns := []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks")),
+		dyn.NewPattern(dyn.Key("targets"), dyn.AnyKey(), dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("tasks")),
+	}
+
+	for _, pattern := range patterns {
+		_, err := dyn.MapByPattern(root, pattern, func(p dyn.Path, tasks dyn.Value) (dyn.Value, error) {
+			seq, ok := tasks.AsSequence()
+			if !ok {
+				return tasks, nil
+			}
+
+			seen := make(map[string]bool)
+			for i, task := range seq {
+				key, ok := task.Get("task_key").AsString()
+				if !ok || key == "" {
+					continue
+				}
+				if seen[key] {
+					diags = diags.Append(diag.Diagnostic{
+						Severity: diag.Warning,
+						Summary:  fmt.Sprintf("duplicate task key %s; tasks with the same key are merged into one", key),
+						Location: task.Get("task_key").Location(),
+						Path:     p.Append(dyn.Index(i), dyn.Key("task_key")),
+						ID:       config.DuplicateTaskKey,
+					})
+				}
+				seen[key] = true
+			}
+			return tasks, nil
+		})
+		if err != nil {
+			return diags.Extend(diag.FromErr(err))
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,156 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyJobTaskGraph(t *testing.T, settings *jobs.JobSettings) diag.Diagnostics {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job1": {JobSettings: settings},
+				},
+			},
+		},
+	}
+
+	// Initialize the dynamic configuration value that duplicate task keys are detected in.
+	err := b.Config.Mutate(func(v dyn.Value) (dyn.Value, error) {
+		return v, nil
+	})
+	require.NoError(t, err)
+
+	return bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), JobTaskGraph())
+}
+
+func TestJobTaskGraphValid(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Environments: []jobs.JobEnvironment{
+			{EnvironmentKey: "default", Spec: &compute.Environment{Client: "1"}},
+		},
+		Tasks: []jobs.Task{
+			{TaskKey: "check", ConditionTask: &jobs.ConditionTask{Left: "1", Op: jobs.ConditionTaskOpEqualTo, Right: "1"}},
+			{TaskKey: "a", DependsOn: []jobs.TaskDependency{{TaskKey: "check", Outcome: "true"}}, EnvironmentKey: "default"},
+			{TaskKey: "b", DependsOn: []jobs.TaskDependency{{TaskKey: "check", Outcome: "false"}}},
+			{
+				TaskKey:   "c",
+				DependsOn: []jobs.TaskDependency{{TaskKey: "a"}, {TaskKey: "b"}},
+				RunIf:     jobs.RunIfAtLeastOneSuccess,
+				ForEachTask: &jobs.ForEachTask{
+					Task: jobs.Task{TaskKey: "c_iteration", EnvironmentKey: "default"},
+				},
+			},
+		},
+	})
+	assert.Empty(t, diags)
+}
+
+func TestJobTaskGraphDuplicateTaskKeys(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Tasks: []jobs.Task{
+			{TaskKey: "a"},
+			{TaskKey: "b", ForEachTask: &jobs.ForEachTask{Task: jobs.Task{TaskKey: "a"}}},
+			{TaskKey: "b"},
+			{TaskKey: "c", ForEachTask: &jobs.ForEachTask{Task: jobs.Task{TaskKey: "c_iteration"}}},
+			{TaskKey: "d", ForEachTask: &jobs.ForEachTask{Task: jobs.Task{TaskKey: "c_iteration"}}},
+		},
+	})
+	require.Len(t, diags, 3)
+
+	// Tasks of the job with the same key are merged, so they are only reported as a warning.
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.DuplicateTaskKey, diags[0].ID)
+	assert.Equal(t, "duplicate task key b; tasks with the same key are merged into one", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[2].task_key", diags[0].Path.String())
+
+	// Nested tasks must not share a key with any other task.
+	assert.Equal(t, diag.Error, diags[1].Severity)
+	assert.Equal(t, "duplicate task key a", diags[1].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[1].for_each_task.task.task_key", diags[1].Path.String())
+	assert.Equal(t, diag.Error, diags[2].Severity)
+	assert.Equal(t, "duplicate task key c_iteration", diags[2].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[4].for_each_task.task.task_key", diags[2].Path.String())
+}
+
+func TestJobTaskGraphMissingDependency(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Tasks: []jobs.Task{
+			{TaskKey: "a", DependsOn: []jobs.TaskDependency{{TaskKey: "b"}}},
+		},
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "task a depends on task b, which doesn't exist", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].depends_on[0].task_key", diags[0].Path.String())
+}
+
+func TestJobTaskGraphCycle(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Tasks: []jobs.Task{
+			{TaskKey: "a", DependsOn: []jobs.TaskDependency{{TaskKey: "c"}}},
+			{TaskKey: "b", DependsOn: []jobs.TaskDependency{{TaskKey: "a"}}},
+			{TaskKey: "c", DependsOn: []jobs.TaskDependency{{TaskKey: "b"}}},
+			{TaskKey: "d", DependsOn: []jobs.TaskDependency{{TaskKey: "d"}}},
+		},
+	})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "tasks have a dependency cycle: a -> c -> b -> a", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[1].depends_on[0]", diags[0].Path.String())
+	assert.Equal(t, "tasks have a dependency cycle: d -> d", diags[1].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[3].depends_on[0]", diags[1].Path.String())
+}
+
+func TestJobTaskGraphRunIf(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Tasks: []jobs.Task{
+			{TaskKey: "check", ConditionTask: &jobs.ConditionTask{Left: "1", Op: jobs.ConditionTaskOpEqualTo, Right: "1"}},
+			{TaskKey: "a", RunIf: jobs.RunIfAllFailed},
+			{TaskKey: "b", DependsOn: []jobs.TaskDependency{{TaskKey: "check", Outcome: "true"}, {TaskKey: "check", Outcome: "false"}}},
+			{TaskKey: "c", DependsOn: []jobs.TaskDependency{{TaskKey: "a", Outcome: "true"}}},
+			{TaskKey: "d", DependsOn: []jobs.TaskDependency{{TaskKey: "check", Outcome: "yes"}}},
+			{TaskKey: "e", DependsOn: []jobs.TaskDependency{{TaskKey: "a"}}, RunIf: "SOMETIMES"},
+		},
+	})
+	require.Len(t, diags, 5)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "run_if ALL_FAILED has no effect because task a doesn't depend on other tasks", diags[0].Summary)
+	assert.Equal(t, diag.Warning, diags[1].Severity)
+	assert.Equal(t, "task b never runs because it requires both outcomes of condition task check to succeed", diags[1].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[2].depends_on", diags[1].Path.String())
+	assert.Equal(t, diag.Error, diags[2].Severity)
+	assert.Equal(t, "outcome can only be specified for dependencies on condition tasks, but a is not a condition task", diags[2].Summary)
+	assert.Equal(t, `outcome yes of condition task check must be "true" or "false"`, diags[3].Summary)
+	assert.Equal(t, "run_if SOMETIMES is not a valid condition", diags[4].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[5].run_if", diags[4].Path.String())
+}
+
+func TestJobTaskGraphEnvironmentKey(t *testing.T) {
+	diags := applyJobTaskGraph(t, &jobs.JobSettings{
+		Environments: []jobs.JobEnvironment{
+			{EnvironmentKey: "default"},
+		},
+		Tasks: []jobs.Task{
+			{TaskKey: "a", EnvironmentKey: "default"},
+			{TaskKey: "b", EnvironmentKey: "other"},
+			{TaskKey: "c", ForEachTask: &jobs.ForEachTask{Task: jobs.Task{TaskKey: "c_iteration", EnvironmentKey: "other"}}},
+		},
+	})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "environment_key other is not defined", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.tasks[1].environment_key", diags[0].Path.String())
+	assert.Equal(t, "resources.jobs.job1.tasks[2].for_each_task.task.environment_key", diags[1].Path.String())
+}
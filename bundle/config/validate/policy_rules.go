@@ -0,0 +1,324 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/jsonschema"
+)
+
+// PolicyRules checks the configuration against the user-defined rules in "validation.rules".
+//
+// Every rule selects values with a pattern and reports a diagnostic with the rule's severity
+// for each selected value that doesn't satisfy the rule's JSON schema.
+func PolicyRules() bundle.ReadOnlyMutator {
+	return &policyRules{}
+}
+
+type policyRules struct {
+}
+
+func (v *policyRules) Name() string {
+	return "validate:policy_rules"
+}
+
+// ValidatePolicyRules applies the [PolicyRules] validator as part of a phase.
+func ValidatePolicyRules() bundle.Mutator {
+	return &validatePolicyRules{}
+}
+
+type validatePolicyRules struct {
+}
+
+func (v *validatePolicyRules) Name() string {
+	return "ValidatePolicyRules"
+}
+
+func (v *validatePolicyRules) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	return bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), PolicyRules())
+}
+
+func (v *policyRules) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	for i, rule := range rb.Config().Validation.Rules {
+		p := dyn.NewPath(dyn.Key("validation"), dyn.Key("rules"), dyn.Index(i))
+		if !ruleApplies(rb, rule) {
+			continue
+		}
+		diags = diags.Extend(checkRule(rb, rule, p))
+	}
+
+	return diags
+}
+
+// ruleApplies returns true if the rule applies to the selected target.
+func ruleApplies(rb bundle.ReadOnlyBundle, rule config.ValidationRule) bool {
+	b := rb.Config().Bundle
+	if len(rule.Targets) > 0 && !slices.Contains(rule.Targets, b.Target) {
+		return false
+	}
+	if len(rule.Modes) > 0 && !slices.Contains(rule.Modes, b.Mode) {
+		return false
+	}
+	return true
+}
+
+func checkRule(rb bundle.ReadOnlyBundle, rule config.ValidationRule, p dyn.Path) diag.Diagnostics {
+	name := rule.Name
+	if name == "" {
+		name = p.String()
+	}
+
+	invalid := func(field string, format string, args ...any) diag.Diagnostics {
+		loc := location{path: p.Append(dyn.Key(field)).String(), rb: rb}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("invalid rule %s: %s", name, fmt.Sprintf(format, args...)),
+			Location: loc.Location(),
+			Path:     loc.Path(),
+			ID:       config.InvalidPolicyRule,
+		}}
+	}
+
+	var severity diag.Severity
+	switch rule.Severity {
+	case "", "error":
+		severity = diag.Error
+	case "warning":
+		severity = diag.Warning
+	case "info":
+		severity = diag.Info
+	default:
+		return invalid("severity", "unknown severity %q, expected \"error\", \"warning\" or \"info\"", rule.Severity)
+	}
+
+	if rule.Match == "" {
+		return invalid("match", "match is not set")
+	}
+	pattern, err := dyn.NewPatternFromString(rule.Match)
+	if err != nil {
+		return invalid("match", "%v", err)
+	}
+
+	schema, err := parseRuleSchema(rule.Schema)
+	if err != nil {
+		return invalid("schema", "%v", err)
+	}
+
+	summary := rule.Message
+	if summary == "" {
+		summary = fmt.Sprintf("rule %s is violated", name)
+	}
+
+	diags := diag.Diagnostics{}
+	_, err = dyn.MapByPattern(rb.Config().Value(), pattern, func(vp dyn.Path, vv dyn.Value) (dyn.Value, error) {
+		// The path is reused for sibling values, so it must be copied to be retained.
+		vp = slices.Clone(vp)
+		var violations []schemaViolation
+		if schema == nil {
+			violations = []schemaViolation{{path: vp, value: vv, message: "the value is not allowed"}}
+		} else {
+			violations = checkSchema(schema, vv, vp)
+		}
+
+		for _, violation := range violations {
+			loc := violation.value.Location()
+			if !violation.value.IsValid() {
+				loc = rb.Config().GetLocation(violation.path.String())
+			}
+			diags = diags.Append(diag.Diagnostic{
+				Severity: severity,
+				Summary:  summary,
+				Detail:   fmt.Sprintf("Rule %s: %s.", name, violation.message),
+				Location: loc,
+				Path:     violation.path,
+				ID:       config.PolicyRuleID(name),
+			})
+		}
+		return vv, nil
+	})
+	if err != nil {
+		return invalid("match", "%v", err)
+	}
+
+	return diags
+}
+
+// parseRuleSchema converts the schema of a rule to a [jsonschema.Schema].
+// It returns nil if the rule doesn't define a schema.
+func parseRuleSchema(m map[string]dyn.Value) (*jsonschema.Schema, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(dyn.V(m).AsAny())
+	if err != nil {
+		return nil, err
+	}
+
+	var schema jsonschema.Schema
+	err = json.Unmarshal(raw, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// schemaViolation describes a value that doesn't satisfy a schema.
+type schemaViolation struct {
+	path dyn.Path
+
+	// The value that violates the schema. It is invalid if a required property is missing.
+	value dyn.Value
+
+	message string
+}
+
+// checkSchema returns the violations of the schema by the value at the path.
+//
+// It supports the subset of JSON schema that is relevant for bundle configuration:
+// type, const, enum, pattern, required, properties, additionalProperties, items and anyOf.
+func checkSchema(s *jsonschema.Schema, v dyn.Value, p dyn.Path) []schemaViolation {
+	violation := func(format string, args ...any) []schemaViolation {
+		return []schemaViolation{{path: p, value: v, message: fmt.Sprintf(format, args...)}}
+	}
+
+	if s.Type != "" && !kindMatchesType(v.Kind(), s.Type) {
+		return violation("expected %s, found %s", s.Type, v.Kind())
+	}
+
+	if s.Const != nil && !valueEquals(v, s.Const) {
+		return violation("expected %v, found %v", s.Const, v.AsAny())
+	}
+
+	if s.Enum != nil && !slices.ContainsFunc(s.Enum, func(e any) bool { return valueEquals(v, e) }) {
+		return violation("expected one of %v, found %v", s.Enum, v.AsAny())
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return violation("invalid pattern %q: %v", s.Pattern, err)
+		}
+		if str, ok := v.AsString(); ok && !re.MatchString(str) {
+			return violation("%q doesn't match pattern %q", str, s.Pattern)
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matches := slices.ContainsFunc(s.AnyOf, func(as *jsonschema.Schema) bool {
+			return len(checkSchema(as, v, p)) == 0
+		})
+		if !matches {
+			return violation("the value doesn't match any of the schemas in anyOf")
+		}
+	}
+
+	var violations []schemaViolation
+
+	if m, ok := v.AsMap(); ok {
+		for _, name := range s.Required {
+			if _, ok := m.GetByString(name); !ok {
+				violations = append(violations, schemaViolation{path: p, value: v, message: fmt.Sprintf("missing required property %s", name)})
+			}
+		}
+
+		additional, err := additionalPropertiesSchema(s.AdditionalProperties)
+		if err != nil {
+			return violation("%v", err)
+		}
+
+		for _, pair := range m.Pairs() {
+			key := pair.Key.MustString()
+			kp := p.Append(dyn.Key(key))
+			if ps, ok := s.Properties[key]; ok {
+				violations = append(violations, checkSchema(ps, pair.Value, kp)...)
+				continue
+			}
+			if s.AdditionalProperties == false {
+				violations = append(violations, schemaViolation{path: kp, value: pair.Value, message: fmt.Sprintf("property %s is not allowed", key)})
+				continue
+			}
+			if additional != nil {
+				violations = append(violations, checkSchema(additional, pair.Value, kp)...)
+			}
+		}
+	}
+
+	if seq, ok := v.AsSequence(); ok && s.Items != nil {
+		for i, item := range seq {
+			violations = append(violations, checkSchema(s.Items, item, p.Append(dyn.Index(i)))...)
+		}
+	}
+
+	return violations
+}
+
+// additionalPropertiesSchema returns the schema for additional properties if it is set to a schema.
+// After parsing JSON, [jsonschema.Schema.AdditionalProperties] is either a boolean or a map.
+func additionalPropertiesSchema(v any) (*jsonschema.Schema, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema jsonschema.Schema
+	err = json.Unmarshal(raw, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse additionalProperties: %w", err)
+	}
+	return &schema, nil
+}
+
+func kindMatchesType(kind dyn.Kind, typ jsonschema.Type) bool {
+	switch typ {
+	case jsonschema.ObjectType:
+		return kind == dyn.KindMap
+	case jsonschema.ArrayType:
+		return kind == dyn.KindSequence
+	case jsonschema.StringType:
+		return kind == dyn.KindString
+	case jsonschema.BooleanType:
+		return kind == dyn.KindBool
+	case jsonschema.IntegerType:
+		return kind == dyn.KindInt
+	case jsonschema.NumberType:
+		return kind == dyn.KindInt || kind == dyn.KindFloat
+	}
+	return true
+}
+
+// valueEquals compares a value of the configuration to a value parsed from JSON.
+// Numbers in JSON are parsed as float64, while the configuration may hold integers.
+func valueEquals(v dyn.Value, expected any) bool {
+	switch e := expected.(type) {
+	case float64:
+		switch v.Kind() {
+		case dyn.KindInt:
+			return float64(v.MustInt()) == e
+		case dyn.KindFloat:
+			return v.MustFloat() == e
+		}
+		return false
+	case map[string]any, []any:
+		return false
+	}
+
+	if v.Kind() == dyn.KindMap || v.Kind() == dyn.KindSequence {
+		return false
+	}
+	return v.AsAny() == expected
+}
@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyPolicyRules(t *testing.T, target string, mode config.Mode, yaml string) diag.Diagnostics {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(yaml))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	b.Config.Bundle.Target = target
+	b.Config.Bundle.Mode = mode
+	return bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), PolicyRules())
+}
+
+const policyRulesJobs = `
+resources:
+  jobs:
+    with_owner:
+      name: with owner
+      tags:
+        owner: data-platform
+      email_notifications:
+        on_failure:
+          - alerts@example.com
+      tasks:
+        - task_key: a
+          job_cluster_key: default
+    without_owner:
+      name: without owner
+      email_notifications:
+        on_failure:
+          - someone@gmail.com
+      tasks:
+        - task_key: a
+          existing_cluster_id: 1234-567890-abcdefgh
+`
+
+func TestPolicyRulesRequiredProperty(t *testing.T) {
+	diags := applyPolicyRules(t, "dev", "", policyRulesJobs+`
+validation:
+  rules:
+    - name: job-owner-tag
+      match: resources.jobs.*
+      schema:
+        required: [tags]
+        properties:
+          tags:
+            required: [owner]
+      message: All jobs must have an owner tag.
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "All jobs must have an owner tag.", diags[0].Summary)
+	assert.Equal(t, "Rule job-owner-tag: missing required property tags.", diags[0].Detail)
+	assert.Equal(t, "resources.jobs.without_owner", diags[0].Path.String())
+	assert.Equal(t, "databricks.yml", diags[0].Location.File)
+	assert.Equal(t, 15, diags[0].Location.Line)
+}
+
+func TestPolicyRulesForbiddenFieldInProduction(t *testing.T) {
+	rules := `
+validation:
+  rules:
+    - name: no-existing-clusters
+      match: resources.jobs.*.tasks[*].existing_cluster_id
+      modes: [production]
+`
+
+	diags := applyPolicyRules(t, "dev", config.Development, policyRulesJobs+rules)
+	assert.Empty(t, diags)
+
+	diags = applyPolicyRules(t, "prod", config.Production, policyRulesJobs+rules)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "rule no-existing-clusters is violated", diags[0].Summary)
+	assert.Equal(t, "Rule no-existing-clusters: the value is not allowed.", diags[0].Detail)
+	assert.Equal(t, "resources.jobs.without_owner.tasks[0].existing_cluster_id", diags[0].Path.String())
+	assert.Equal(t, 21, diags[0].Location.Line)
+}
+
+func TestPolicyRulesPatternWithSeverityAndTargets(t *testing.T) {
+	rules := `
+validation:
+  rules:
+    - name: notification-domain
+      match: resources.jobs.*.email_notifications.on_failure
+      severity: warning
+      targets: [prod]
+      schema:
+        type: array
+        items:
+          type: string
+          pattern: "@example\\.com$"
+`
+
+	diags := applyPolicyRules(t, "dev", "", policyRulesJobs+rules)
+	assert.Empty(t, diags)
+
+	diags = applyPolicyRules(t, "prod", "", policyRulesJobs+rules)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, `Rule notification-domain: "someone@gmail.com" doesn't match pattern "@example\\.com$".`, diags[0].Detail)
+	assert.Equal(t, "resources.jobs.without_owner.email_notifications.on_failure[0]", diags[0].Path.String())
+}
+
+func TestPolicyRulesEnumAndAdditionalProperties(t *testing.T) {
+	diags := applyPolicyRules(t, "dev", "", policyRulesJobs+`
+validation:
+  rules:
+    - name: allowed-owners
+      match: resources.jobs.*.tags
+      schema:
+        additionalProperties: false
+        properties:
+          owner:
+            enum: [data-engineering]
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Rule allowed-owners: expected one of [data-engineering], found data-platform.", diags[0].Detail)
+	assert.Equal(t, "resources.jobs.with_owner.tags.owner", diags[0].Path.String())
+}
+
+func TestPolicyRulesInvalidRule(t *testing.T) {
+	diags := applyPolicyRules(t, "dev", "", policyRulesJobs+`
+validation:
+  rules:
+    - name: bad-severity
+      match: resources.jobs.*
+      severity: fatal
+    - name: bad-match
+      match: resources.jobs[
+`)
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, `invalid rule bad-severity: unknown severity "fatal", expected "error", "warning" or "info"`, diags[0].Summary)
+	assert.Equal(t, "validation.rules[0].severity", diags[0].Path.String())
+	assert.Equal(t, "invalid rule bad-match: invalid pattern: resources.jobs[", diags[1].Summary)
+	assert.Equal(t, "validation.rules[1].match", diags[1].Path.String())
+}
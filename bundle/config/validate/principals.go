@@ -0,0 +1,374 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/dynvar"
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+)
+
+// Principals validates that the users, groups and service principals referenced in
+// permissions, grants and run_as exist in the workspace.
+//
+// Unity Catalog grants can refer to account-level principals that are not visible in
+// the workspace, so principals in grants that are not found are reported as warnings.
+func Principals() bundle.ReadOnlyMutator {
+	return &principals{}
+}
+
+type principals struct {
+}
+
+func (v *principals) Name() string {
+	return "validate:principals"
+}
+
+// ValidatePrincipals applies the [Principals] validator as part of a phase.
+//
+// Deployments fail halfway through if a principal doesn't exist, so this runs before
+// any state is modified. Users, groups and service principals that don't exist are
+// reported as errors. Principals in grants that are not found and principals that
+// can't be looked up are reported as warnings, so they don't fail the deployment.
+func ValidatePrincipals() bundle.Mutator {
+	return &validatePrincipals{}
+}
+
+type validatePrincipals struct {
+}
+
+func (v *validatePrincipals) Name() string {
+	return "ValidatePrincipals"
+}
+
+func (v *validatePrincipals) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	return bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), Principals())
+}
+
+type principalKind int
+
+const (
+	principalUser principalKind = iota
+	principalGroup
+	principalServicePrincipal
+
+	// Grants refer to principals of any kind.
+	principalAny
+)
+
+func (k principalKind) String() string {
+	switch k {
+	case principalUser:
+		return "user"
+	case principalGroup:
+		return "group"
+	case principalServicePrincipal:
+		return "service principal"
+	}
+	return "principal"
+}
+
+var (
+	// Patterns for values that reference principals.
+	principalPatterns = []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("permissions"), dyn.AnyIndex()),
+		dyn.NewPattern(dyn.Key("resources"), dyn.AnyKey(), dyn.AnyKey(), dyn.Key("permissions"), dyn.AnyIndex()),
+		dyn.NewPattern(dyn.Key("resources"), dyn.AnyKey(), dyn.AnyKey(), dyn.Key("grants"), dyn.AnyIndex()),
+		dyn.NewPattern(dyn.Key("run_as")),
+		dyn.NewPattern(dyn.Key("resources"), dyn.Key("jobs"), dyn.AnyKey(), dyn.Key("run_as")),
+	}
+
+	// Fields of these values that contain the name of a principal.
+	principalFields = []struct {
+		key  string
+		kind principalKind
+	}{
+		{"user_name", principalUser},
+		{"group_name", principalGroup},
+		{"service_principal_name", principalServicePrincipal},
+		{"principal", principalAny},
+	}
+
+	// Kinds of principals that can be looked up.
+	principalKinds = []principalKind{principalUser, principalGroup, principalServicePrincipal}
+
+	// Groups that exist in every workspace or account. The "account users"
+	// group is not returned by the workspace SCIM API, so none are looked up.
+	builtinGroups = []string{"users", "admins", "account users"}
+)
+
+// Number of principals to look up in a single request.
+const principalBatchSize = 20
+
+// Maximum number of principals to consider for suggestions.
+const principalSuggestionCount = 100
+
+type principalRef struct {
+	kind principalKind
+	name string
+}
+
+type principalUse struct {
+	path dyn.Path
+	v    dyn.Value
+}
+
+func (v *principals) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	c := &principalChecker{
+		ctx:   ctx,
+		w:     rb.WorkspaceClient(),
+		uses:  make(map[principalRef][]principalUse),
+		found: make(map[principalKind]map[string]bool),
+		errs:  make(map[principalKind]map[string]error),
+	}
+
+	root := rb.Config().Value()
+	for _, pattern := range principalPatterns {
+		_, err := dyn.MapByPattern(root, pattern, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+			c.collect(slices.Clone(p), v)
+			return v, nil
+		})
+		if err != nil {
+			c.diags = c.diags.Extend(diag.FromErr(err))
+		}
+	}
+
+	for _, kind := range principalKinds {
+		c.lookup(kind)
+	}
+	for _, ref := range c.refs {
+		c.check(ref)
+	}
+	return c.diags
+}
+
+// principalChecker looks up the referenced principals in batches, because
+// bundles often reference the same principals many times.
+type principalChecker struct {
+	ctx context.Context
+	w   *databricks.WorkspaceClient
+
+	// References in the order they were found in the configuration.
+	refs []principalRef
+	uses map[principalRef][]principalUse
+
+	// Names of principals that exist, and errors for principals that couldn't be looked up,
+	// by kind. Names are compared case-insensitively.
+	found map[principalKind]map[string]bool
+	errs  map[principalKind]map[string]error
+
+	diags diag.Diagnostics
+}
+
+func (c *principalChecker) collect(p dyn.Path, v dyn.Value) {
+	if v.Kind() != dyn.KindMap {
+		return
+	}
+	for _, f := range principalFields {
+		fv := v.Get(f.key)
+		name, ok := fv.AsString()
+		if !ok || name == "" || dynvar.ContainsVariableReference(name) {
+			continue
+		}
+		if (f.kind == principalGroup || f.kind == principalAny) && slices.Contains(builtinGroups, strings.ToLower(name)) {
+			continue
+		}
+		ref := principalRef{kind: f.kind, name: name}
+		if _, ok := c.uses[ref]; !ok {
+			c.refs = append(c.refs, ref)
+		}
+		c.uses[ref] = append(c.uses[ref], principalUse{path: p.Append(dyn.Key(f.key)), v: fv})
+	}
+}
+
+// lookup looks up all referenced principals of a kind.
+func (c *principalChecker) lookup(kind principalKind) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range c.refs {
+		key := strings.ToLower(ref.name)
+		if (ref.kind == kind || ref.kind == principalAny) && !seen[key] {
+			seen[key] = true
+			names = append(names, ref.name)
+		}
+	}
+
+	c.found[kind] = make(map[string]bool)
+	c.errs[kind] = make(map[string]error)
+	for _, batch := range chunk(names, principalBatchSize) {
+		filters := make([]string, len(batch))
+		for i, name := range batch {
+			filters[i] = fmt.Sprintf("%s eq %s", principalAttribute(kind), scimString(name))
+		}
+		found, err := c.list(kind, strings.Join(filters, " or "), int64(len(batch)))
+		if err != nil {
+			for _, name := range batch {
+				c.errs[kind][strings.ToLower(name)] = err
+			}
+			continue
+		}
+		for _, name := range found {
+			c.found[kind][strings.ToLower(name)] = true
+		}
+	}
+}
+
+// list returns the names of principals of a kind that match a SCIM filter.
+func (c *principalChecker) list(kind principalKind, filter string, count int64) ([]string, error) {
+	var names []string
+	switch kind {
+	case principalUser:
+		users, err := c.w.Users.ListAll(c.ctx, iam.ListUsersRequest{Filter: filter, Attributes: "userName", Count: count})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			names = append(names, u.UserName)
+		}
+	case principalGroup:
+		groups, err := c.w.Groups.ListAll(c.ctx, iam.ListGroupsRequest{Filter: filter, Attributes: "displayName", Count: count})
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			names = append(names, g.DisplayName)
+		}
+	case principalServicePrincipal:
+		sps, err := c.w.ServicePrincipals.ListAll(c.ctx, iam.ListServicePrincipalsRequest{Filter: filter, Attributes: "applicationId", Count: count})
+		if err != nil {
+			return nil, err
+		}
+		for _, sp := range sps {
+			names = append(names, sp.ApplicationId)
+		}
+	}
+	return names, nil
+}
+
+// principalAttribute returns the SCIM attribute that bundles use to refer to principals of a kind.
+// Service principals are referred to by their application ID.
+func principalAttribute(kind principalKind) string {
+	switch kind {
+	case principalGroup:
+		return "displayName"
+	case principalServicePrincipal:
+		return "applicationId"
+	}
+	return "userName"
+}
+
+func scimString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+func chunk[T any](s []T, n int) [][]T {
+	var out [][]T
+	for len(s) > n {
+		out = append(out, s[:n])
+		s = s[n:]
+	}
+	if len(s) > 0 {
+		out = append(out, s)
+	}
+	return out
+}
+
+func (c *principalChecker) kinds(ref principalRef) []principalKind {
+	if ref.kind == principalAny {
+		return principalKinds
+	}
+	return []principalKind{ref.kind}
+}
+
+func (c *principalChecker) check(ref principalRef) {
+	key := strings.ToLower(ref.name)
+
+	var err error
+	for _, kind := range c.kinds(ref) {
+		if c.found[kind][key] {
+			return
+		}
+		if kerr, ok := c.errs[kind][key]; ok && err == nil {
+			err = kerr
+		}
+	}
+
+	// Principals that couldn't be looked up are reported as warnings because they
+	// may exist, for example if the user doesn't have permission to list them.
+	if err != nil {
+		c.report(ref, diag.Warning, config.PrincipalNotVerified, "", "unable to verify %s %s: %v", ref.kind, ref.name, err)
+		return
+	}
+
+	detail := ""
+	if similar := c.suggest(ref); similar != "" {
+		detail = fmt.Sprintf("Did you mean %s?", similar)
+	}
+
+	// Grants can refer to account-level principals that the workspace doesn't list.
+	if ref.kind == principalAny {
+		c.report(ref, diag.Warning, config.PrincipalNotVerified, detail, "%s %s was not found in the workspace", ref.kind, ref.name)
+		return
+	}
+	c.report(ref, diag.Error, config.PrincipalNotFound, detail, "%s %s does not exist", ref.kind, ref.name)
+}
+
+// suggest returns the name of an existing principal that is similar to the referenced one.
+// Only principals whose name starts with the same characters are considered, because
+// workspaces can have many principals.
+func (c *principalChecker) suggest(ref principalRef) string {
+	prefix := ref.name
+	if r := []rune(prefix); len(r) > 3 {
+		prefix = string(r[:3])
+	}
+
+	var candidates []string
+	for _, kind := range c.kinds(ref) {
+		filter := fmt.Sprintf("%s sw %s", principalAttribute(kind), scimString(prefix))
+		names, err := c.list(kind, filter, principalSuggestionCount)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, names...)
+	}
+	return similarKey(ref.name, candidates)
+}
+
+// report reports a diagnostic for every location the principal is referenced at.
+// Values that were copied by mutators, such as top-level permissions that are applied
+// to all resources, don't have a location and are only reported if there is no other use.
+func (c *principalChecker) report(ref principalRef, severity diag.Severity, id diag.ID, detail string, format string, args ...any) {
+	uses := c.uses[ref]
+	located := slices.DeleteFunc(slices.Clone(uses), func(u principalUse) bool {
+		return u.v.Location().File == ""
+	})
+	if len(located) > 0 {
+		uses = located
+	}
+
+	seen := make(map[dyn.Location]bool)
+	for _, u := range uses {
+		loc := u.v.Location()
+		if loc.File != "" && seen[loc] {
+			continue
+		}
+		seen[loc] = true
+		c.diags = c.diags.Append(diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf(format, args...),
+			Detail:   detail,
+			Location: loc,
+			Path:     u.path,
+			ID:       id,
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/databricks-sdk-go/experimental/mocks"
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func applyPrincipals(t *testing.T, m *mocks.MockWorkspaceClient, yaml string) diag.Diagnostics {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(yaml))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	b.SetWorkpaceClient(m.WorkspaceClient)
+	return bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), Principals())
+}
+
+const principalsConfig = `
+run_as:
+  service_principal_name: 1234-abcd
+
+permissions:
+  - level: CAN_VIEW
+    group_name: data-team
+
+resources:
+  jobs:
+    job1:
+      name: job1
+      permissions:
+        - level: CAN_MANAGE
+          user_name: alice@company.com
+        - level: CAN_MANAGE
+          user_name: ${var.owner}
+  registered_models:
+    model1:
+      name: model1
+      grants:
+        - principal: data-team
+          privileges: [EXECUTE]
+        - principal: bob@compny.com
+          privileges: [EXECUTE]
+`
+
+func TestPrincipalsExist(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, iam.ListUsersRequest{
+		Filter:     `userName eq "alice@company.com" or userName eq "data-team" or userName eq "bob@compny.com"`,
+		Attributes: "userName",
+		Count:      3,
+	}).Return([]iam.User{{UserName: "Alice@company.com"}, {UserName: "bob@compny.com"}}, nil).Once()
+	m.GetMockGroupsAPI().EXPECT().ListAll(mock.Anything, iam.ListGroupsRequest{
+		Filter:     `displayName eq "data-team" or displayName eq "bob@compny.com"`,
+		Attributes: "displayName",
+		Count:      2,
+	}).Return([]iam.Group{{DisplayName: "data-team"}}, nil).Once()
+	m.GetMockServicePrincipalsAPI().EXPECT().ListAll(mock.Anything, iam.ListServicePrincipalsRequest{
+		Filter:     `applicationId eq "data-team" or applicationId eq "bob@compny.com" or applicationId eq "1234-abcd"`,
+		Attributes: "applicationId",
+		Count:      3,
+	}).Return([]iam.ServicePrincipal{{ApplicationId: "1234-abcd"}}, nil).Once()
+
+	diags := applyPrincipals(t, m, principalsConfig)
+	assert.Empty(t, diags)
+}
+
+func TestPrincipalsNotFound(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	usersApi := m.GetMockUsersAPI()
+	usersApi.EXPECT().ListAll(mock.Anything, mock.MatchedBy(func(r iam.ListUsersRequest) bool {
+		return r.Count == 3
+	})).Return([]iam.User{{UserName: "alice@company.com"}}, nil).Once()
+	m.GetMockGroupsAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return([]iam.Group{{DisplayName: "data-team"}}, nil)
+	m.GetMockServicePrincipalsAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return([]iam.ServicePrincipal{{ApplicationId: "1234-abcd"}}, nil)
+
+	// Suggestions are looked up by prefix.
+	usersApi.EXPECT().ListAll(mock.Anything, iam.ListUsersRequest{
+		Filter:     `userName sw "bob"`,
+		Attributes: "userName",
+		Count:      principalSuggestionCount,
+	}).Return([]iam.User{{UserName: "bob@company.com"}, {UserName: "bobby@company.com"}}, nil).Once()
+
+	// Grants can refer to account-level principals, so misses are warnings.
+	diags := applyPrincipals(t, m, principalsConfig)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.PrincipalNotVerified, diags[0].ID)
+	assert.Equal(t, "principal bob@compny.com was not found in the workspace", diags[0].Summary)
+	assert.Equal(t, "Did you mean bob@company.com?", diags[0].Detail)
+	assert.Equal(t, "resources.registered_models.model1.grants[1].principal", diags[0].Path.String())
+	assert.Equal(t, 24, diags[0].Location.Line)
+}
+
+func TestPrincipalsNotVerified(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return(nil, errors.New("permission denied")).Once()
+
+	diags := applyPrincipals(t, m, `
+resources:
+  jobs:
+    job1:
+      name: job1
+      run_as:
+        user_name: alice@company.com
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.PrincipalNotVerified, diags[0].ID)
+	assert.Equal(t, "unable to verify user alice@company.com: permission denied", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job1.run_as.user_name", diags[0].Path.String())
+}
+
+func TestPrincipalsBatches(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, mock.MatchedBy(func(r iam.ListUsersRequest) bool {
+		return r.Count == principalBatchSize
+	})).Return(nil, nil).Once()
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, mock.MatchedBy(func(r iam.ListUsersRequest) bool {
+		return r.Count == 1
+	})).Return(nil, nil).Once()
+
+	yaml := "permissions:\n"
+	for i := 0; i <= principalBatchSize; i++ {
+		yaml += "  - level: CAN_VIEW\n    user_name: user" + string(rune('a'+i)) + "@company.com\n"
+	}
+
+	// Suggestions are only looked up for missing principals.
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, mock.MatchedBy(func(r iam.ListUsersRequest) bool {
+		return r.Count == principalSuggestionCount
+	})).Return(nil, nil).Times(principalBatchSize + 1)
+
+	diags := applyPrincipals(t, m, yaml)
+	assert.Len(t, diags, principalBatchSize+1)
+}
+
+func TestPrincipalsBuiltinGroups(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+
+	diags := applyPrincipals(t, m, `
+permissions:
+  - level: CAN_VIEW
+    group_name: users
+resources:
+  registered_models:
+    model1:
+      name: model1
+      grants:
+        - principal: account users
+          privileges: [EXECUTE]
+`)
+	assert.Empty(t, diags)
+}
+
+func TestValidatePrincipalsNotFound(t *testing.T) {
+	m := mocks.NewMockWorkspaceClient(t)
+	m.GetMockUsersAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return(nil, nil)
+	m.GetMockGroupsAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return(nil, nil)
+	m.GetMockServicePrincipalsAPI().EXPECT().ListAll(mock.Anything, mock.Anything).Return(nil, nil)
+
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(`
+run_as:
+  user_name: alice@company.com
+resources:
+  registered_models:
+    model1:
+      name: model1
+      grants:
+        - principal: account-team
+          privileges: [EXECUTE]
+`))
+	require.NoError(t, diags.Error())
+	b := &bundle.Bundle{Config: *root}
+	b.SetWorkpaceClient(m.WorkspaceClient)
+
+	// Deployments fail on users, groups and service principals that don't exist,
+	// but not on principals in grants that the workspace doesn't list.
+	diags = bundle.Apply(context.Background(), b, ValidatePrincipals())
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.PrincipalNotVerified, diags[0].ID)
+	assert.Equal(t, "principal account-team was not found in the workspace", diags[0].Summary)
+	assert.Equal(t, diag.Error, diags[1].Severity)
+	assert.Equal(t, config.PrincipalNotFound, diags[1].ID)
+	assert.Equal(t, "user alice@company.com does not exist", diags[1].Summary)
+}
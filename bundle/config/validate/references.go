@@ -0,0 +1,299 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/convert"
+	"github.com/databricks/cli/libs/dyn/dynvar"
+)
+
+var referenceRegex = regexp.MustCompile(dynvar.VariableRegex)
+
+// References validates the variable references in the bundle configuration.
+//
+// It reports references to variables and resources that are not defined and references
+// to fields that don't exist. It warns about variables that are never referenced and about
+// target overrides of resources that look like misspellings of resources defined at the top level.
+//
+// References are resolved and targets are merged when the bundle is initialized, so this
+// validator checks the configuration as it was loaded, before a target was selected.
+func References() bundle.ReadOnlyMutator {
+	return &references{}
+}
+
+type references struct {
+}
+
+func (v *references) Name() string {
+	return "validate:references"
+}
+
+// reference is a variable reference in a string value of the configuration.
+type reference struct {
+	// Path of the reference, for example "resources.jobs.my_job.id".
+	path dyn.Path
+
+	// Path and location of the value that contains the reference.
+	valuePath dyn.Path
+	location  dyn.Location
+}
+
+// Resources and variables may be added by PyDABs and config generators when the bundle
+// is initialized, so references to them can only be checked if neither is used.
+func hasGenerators(rb bundle.ReadOnlyBundle) bool {
+	experimental := rb.Config().Experimental
+	return experimental != nil && (experimental.PyDABs.Enabled || len(experimental.ConfigGenerators) > 0)
+}
+
+func (v *references) Apply(ctx context.Context, rb bundle.ReadOnlyBundle) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	root := rb.LoadedConfig()
+
+	var refs []reference
+	_, err := dyn.Walk(root, func(p dyn.Path, v dyn.Value) (dyn.Value, error) {
+		s, ok := v.AsString()
+		if !ok {
+			return v, nil
+		}
+		for _, m := range referenceRegex.FindAllStringSubmatch(s, -1) {
+			path, err := dyn.NewPathFromString(m[1])
+			if err != nil {
+				continue
+			}
+			refs = append(refs, reference{path: path, valuePath: slices.Clone(p), location: v.Location()})
+		}
+		return v, nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	generators := hasGenerators(rb)
+	variables := root.Get("variables")
+	used := make(map[string]bool)
+
+	for _, ref := range refs {
+		switch ref.path[0].Key() {
+		case "var":
+			if len(ref.path) < 2 {
+				continue
+			}
+			name := ref.path[1].Key()
+			used[name] = true
+			if !variables.Get(name).IsValid() {
+				diags = diags.Append(referenceDiagnostic(diag.Error, config.UndefinedVariable, ref, fmt.Sprintf("reference to undefined variable %s", name)))
+			}
+
+		case "variables":
+			if len(ref.path) < 2 {
+				continue
+			}
+			name := ref.path[1].Key()
+			used[name] = true
+			if !variables.Get(name).IsValid() {
+				diags = diags.Append(referenceDiagnostic(diag.Error, config.UndefinedVariable, ref, fmt.Sprintf("reference to undefined variable %s", name)))
+				continue
+			}
+			diags = diags.Extend(checkReferenceField(ref))
+
+		case "resources":
+			if len(ref.path) < 3 {
+				diags = diags.Extend(checkReferenceField(ref))
+				continue
+			}
+			d := checkReferenceField(ref)
+			if len(d) > 0 {
+				diags = diags.Extend(d)
+				continue
+			}
+			if !generators && !isResourceDefined(root, ref.path[1].Key(), ref.path[2].Key()) {
+				diags = diags.Append(referenceDiagnostic(diag.Error, config.UndefinedResource, ref, fmt.Sprintf("reference to undefined resource %s.%s", ref.path[1].Key(), ref.path[2].Key())))
+			}
+
+		case "bundle", "workspace":
+			diags = diags.Extend(checkReferenceField(ref))
+		}
+	}
+
+	if !generators {
+		diags = diags.Extend(unusedVariables(root, variables, used))
+	}
+	diags = diags.Extend(misspelledTargetResources(root))
+	return diags
+}
+
+func referenceDiagnostic(severity diag.Severity, id diag.ID, ref reference, summary string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: severity,
+		Summary:  summary,
+		Detail:   fmt.Sprintf("The reference ${%s} can't be resolved.", ref.path),
+		Location: ref.location,
+		Path:     ref.valuePath,
+		ID:       id,
+	}
+}
+
+// checkReferenceField checks that the fields of a reference exist in the typed configuration.
+// A value at the reference path is normalized against the configuration type, which reports
+// fields that don't exist.
+func checkReferenceField(ref reference) diag.Diagnostics {
+	v := dyn.V("")
+	for i := len(ref.path) - 1; i >= 0; i-- {
+		v = dyn.V(map[string]dyn.Value{ref.path[i].Key(): v})
+	}
+
+	_, diags := convert.Normalize(config.Root{}, v)
+	for _, d := range diags {
+		if !strings.HasPrefix(d.Summary, "unknown field") {
+			continue
+		}
+		return diag.Diagnostics{referenceDiagnostic(diag.Warning, config.UnknownReferenceField, ref, fmt.Sprintf("reference to unknown field: ${%s}", ref.path))}
+	}
+	return nil
+}
+
+// isResourceDefined returns true if the resource is defined at the top level or in any target.
+func isResourceDefined(root dyn.Value, typ string, key string) bool {
+	if root.Get("resources").Get(typ).Get(key).IsValid() {
+		return true
+	}
+
+	targets, ok := root.Get("targets").AsMap()
+	if !ok {
+		return false
+	}
+	for _, target := range targets.Pairs() {
+		if target.Value.Get("resources").Get(typ).Get(key).IsValid() {
+			return true
+		}
+	}
+	return false
+}
+
+func unusedVariables(root dyn.Value, variables dyn.Value, used map[string]bool) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	m, ok := variables.AsMap()
+	if !ok {
+		return diags
+	}
+
+	for _, pair := range m.Pairs() {
+		name := pair.Key.MustString()
+		if used[name] {
+			continue
+		}
+		p := dyn.NewPath(dyn.Key("variables"), dyn.Key(name))
+		diags = diags.Append(diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("variable %s is never referenced", name),
+			Location: pair.Value.Location(),
+			Path:     p,
+			ID:       config.UnusedVariable,
+		})
+	}
+
+	return diags
+}
+
+// misspelledTargetResources warns about resources in targets that are not defined at the top level
+// but whose keys are similar to the key of a resource of the same type that is.
+//
+// Resources that are only defined in a target are valid, so only likely misspellings are reported.
+func misspelledTargetResources(root dyn.Value) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	targets, ok := root.Get("targets").AsMap()
+	if !ok {
+		return diags
+	}
+
+	for _, target := range targets.Pairs() {
+		types, ok := target.Value.Get("resources").AsMap()
+		if !ok {
+			continue
+		}
+		for _, typ := range types.Pairs() {
+			resources, ok := typ.Value.AsMap()
+			if !ok {
+				continue
+			}
+			defined, _ := root.Get("resources").Get(typ.Key.MustString()).AsMap()
+			keys := make([]string, 0, defined.Len())
+			for _, k := range defined.Keys() {
+				keys = append(keys, k.MustString())
+			}
+
+			for _, resource := range resources.Pairs() {
+				key := resource.Key.MustString()
+				if slices.Contains(keys, key) {
+					continue
+				}
+				similar := similarKey(key, keys)
+				if similar == "" {
+					continue
+				}
+
+				p := dyn.NewPath(dyn.Key("targets"), dyn.Key(target.Key.MustString()), dyn.Key("resources"), dyn.Key(typ.Key.MustString()), dyn.Key(key))
+				diags = diags.Append(diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("target %s overrides %s %s, which is not defined", target.Key.MustString(), typ.Key.MustString(), key),
+					Detail:   fmt.Sprintf("Did you mean %s?", similar),
+					Location: resource.Value.Location(),
+					Path:     p,
+					ID:       config.UndefinedTargetResource,
+				})
+			}
+		}
+	}
+
+	return diags
+}
+
+// similarKey returns the key that is closest to the specified key if it is close enough
+// to be a likely misspelling, or an empty string otherwise.
+func similarKey(key string, keys []string) string {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+
+	best := ""
+	bestDistance := 0
+	for _, k := range keys {
+		d := levenshtein(strings.ToLower(key), strings.ToLower(k))
+		if d > 2 || d*3 > len(key) {
+			continue
+		}
+		if best == "" || d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,183 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func applyReferences(t *testing.T, yaml string) diag.Diagnostics {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(yaml))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	return bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), References())
+}
+
+func TestReferencesValid(t *testing.T) {
+	diags := applyReferences(t, `
+variables:
+  catalog:
+    default: main
+resources:
+  jobs:
+    job1:
+      name: "${bundle.name} ${var.catalog}"
+  pipelines:
+    pipeline1:
+      catalog: ${variables.catalog.value}
+      configuration:
+        job_id: ${resources.jobs.job1.id}
+targets:
+  dev:
+    resources:
+      jobs:
+        dev_job:
+          name: ${workspace.current_user.short_name}
+      pipelines:
+        pipeline1:
+          name: ${resources.jobs.dev_job.name}
+`)
+	assert.Empty(t, diags)
+}
+
+func TestReferencesUndefinedVariable(t *testing.T) {
+	diags := applyReferences(t, `
+resources:
+  jobs:
+    job1:
+      name: ${var.catalgo}
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "reference to undefined variable catalgo", diags[0].Summary)
+	assert.Equal(t, "The reference ${var.catalgo} can't be resolved.", diags[0].Detail)
+	assert.Equal(t, "resources.jobs.job1.name", diags[0].Path.String())
+	assert.Equal(t, "databricks.yml", diags[0].Location.File)
+	assert.Equal(t, 5, diags[0].Location.Line)
+}
+
+func TestReferencesUndefinedResource(t *testing.T) {
+	diags := applyReferences(t, `
+resources:
+  jobs:
+    job1:
+      name: job1
+    job2:
+      name: ${resources.jobs.jbo1.name}
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "reference to undefined resource jobs.jbo1", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job2.name", diags[0].Path.String())
+}
+
+func TestReferencesUnknownField(t *testing.T) {
+	diags := applyReferences(t, `
+resources:
+  jobs:
+    job1:
+      name: job1
+    job2:
+      name: ${resources.jobs.job1.nmae}
+      description: ${bundle.nmae}
+`)
+	require.Len(t, diags, 2)
+	summaries := []string{diags[0].Summary, diags[1].Summary}
+	assert.ElementsMatch(t, []string{
+		"reference to unknown field: ${resources.jobs.job1.nmae}",
+		"reference to unknown field: ${bundle.nmae}",
+	}, summaries)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, diag.Warning, diags[1].Severity)
+}
+
+func TestReferencesUnusedVariable(t *testing.T) {
+	diags := applyReferences(t, `
+variables:
+  used:
+    default: a
+  unused:
+    default: b
+resources:
+  jobs:
+    job1:
+      name: ${var.used}
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "variable unused is never referenced", diags[0].Summary)
+	assert.Equal(t, "variables.unused", diags[0].Path.String())
+	assert.Equal(t, 6, diags[0].Location.Line)
+}
+
+func TestReferencesMisspelledTargetResource(t *testing.T) {
+	diags := applyReferences(t, `
+resources:
+  jobs:
+    my_job:
+      name: job
+targets:
+  prod:
+    resources:
+      jobs:
+        my_jbo:
+          name: prod job
+        other_job:
+          name: only in prod
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "target prod overrides jobs my_jbo, which is not defined", diags[0].Summary)
+	assert.Equal(t, "Did you mean my_job?", diags[0].Detail)
+	assert.Equal(t, "targets.prod.resources.jobs.my_jbo", diags[0].Path.String())
+}
+
+func TestReferencesSkippedWithConfigGenerators(t *testing.T) {
+	diags := applyReferences(t, `
+experimental:
+  config_generators:
+    - command: ./generate.sh
+variables:
+  unused:
+    default: b
+resources:
+  jobs:
+    job1:
+      name: ${resources.jobs.generated.name} ${var.generated}
+`)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "reference to undefined variable generated", diags[0].Summary)
+}
+
+func TestReferencesAfterTargetSelection(t *testing.T) {
+	root, diags := config.LoadFromBytes("databricks.yml", []byte(`
+resources:
+  jobs:
+    job1:
+      name: job
+targets:
+  dev:
+    resources:
+      jobs:
+        job1:
+          name: ${var.catalog}
+  prod: {}
+`))
+	require.NoError(t, diags.Error())
+
+	b := &bundle.Bundle{Config: *root}
+	b.LoadedConfig = b.Config.Value()
+	require.NoError(t, b.Config.MergeTargetOverrides("prod"))
+
+	// References in targets that weren't selected are validated as well.
+	diags = bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), References())
+	require.Len(t, diags, 1)
+	assert.Equal(t, "reference to undefined variable catalog", diags[0].Summary)
+	assert.Equal(t, "targets.dev.resources.jobs.job1.name", diags[0].Path.String())
+}
@@ -28,8 +28,12 @@ func (l location) Path() dyn.Path {
 func (v *validate) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 	return bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), bundle.Parallel(
 		JobClusterKeyDefined(),
+		JobTaskGraph(),
+		References(),
 		FilesToSync(),
 		ValidateSyncPatterns(),
+		PolicyRules(),
+		DiagnosticOverrides(),
 	))
 }
 
@@ -41,3 +45,24 @@ func (v *validate) Name() string {
 func Validate() bundle.Mutator {
 	return &validate{}
 }
+
+type remote struct {
+}
+
+// Apply implements bundle.Mutator.
+func (v *remote) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	return bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), bundle.Parallel(
+		ComputeSettings(),
+		Principals(),
+	))
+}
+
+// Name implements bundle.Mutator.
+func (v *remote) Name() string {
+	return "validate:remote"
+}
+
+// Remote returns the validators that verify the configuration against the workspace.
+func Remote() bundle.Mutator {
+	return &remote{}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/fileset"
 	"github.com/databricks/cli/libs/vfs"
@@ -69,6 +70,7 @@ func checkPatterns(patterns []string, path string, rb bundle.ReadOnlyBundle) (di
 					Summary:  fmt.Sprintf("Pattern %s does not match any files", p),
 					Location: loc.Location(),
 					Path:     loc.Path(),
+					ID:       config.SyncPatternNoMatch,
 				})
 				mu.Unlock()
 			}
@@ -0,0 +1,90 @@
+package config
+
+import "github.com/databricks/cli/libs/dyn"
+
+type Validation struct {
+	// Rules are user-defined policy rules that are checked by "bundle validate"
+	// and before a bundle is deployed.
+	Rules []ValidationRule `json:"rules,omitempty"`
+
+	// Production enables additional checks for targets with 'mode: production'.
+	Production ProductionChecks `json:"production,omitempty"`
+}
+
+// ProductionChecks are checks for targets with 'mode: production' in addition to
+// the checks that always apply. Every check is disabled unless it is enabled.
+// Targets can enable or disable individual checks, for example:
+//
+//	validation:
+//	  production:
+//	    no_existing_clusters: true
+//	    require_task_retries: true
+//	    protected_branches: [main, release/*]
+//
+//	targets:
+//	  prod:
+//	    mode: production
+//	    validation:
+//	      production:
+//	        require_task_retries: false
+type ProductionChecks struct {
+	// NoExistingClusters forbids tasks from running on an existing (all-purpose) cluster.
+	NoExistingClusters bool `json:"no_existing_clusters,omitempty"`
+
+	// RequireGroupManage requires the permissions of jobs, pipelines, experiments, models
+	// and model serving endpoints to grant CAN_MANAGE to a group, so that they
+	// can be managed by a team rather than only by the deploying user.
+	RequireGroupManage bool `json:"require_group_manage,omitempty"`
+
+	// RequireFailureNotifications requires jobs to send an email or webhook notification on failure.
+	RequireFailureNotifications bool `json:"require_failure_notifications,omitempty"`
+
+	// RequireTaskRetries requires max_retries to be set on all tasks.
+	RequireTaskRetries bool `json:"require_task_retries,omitempty"`
+
+	// NoUserLibraries forbids libraries and environment dependencies from
+	// user folders (/Workspace/Users/...), which are typically development copies.
+	NoUserLibraries bool `json:"no_user_libraries,omitempty"`
+
+	// ProtectedBranches requires the Git branch to match one of the patterns, for example "release/*".
+	ProtectedBranches []string `json:"protected_branches,omitempty"`
+}
+
+// ValidationRule is a declarative policy rule, for example:
+//
+//	validation:
+//	  rules:
+//	    - name: job-owner-tag
+//	      match: resources.jobs.*
+//	      schema:
+//	        required: [tags]
+//	        properties:
+//	          tags:
+//	            required: [owner]
+//	      message: All jobs must have an owner tag.
+type ValidationRule struct {
+	// Name identifies the rule in diagnostics.
+	Name string `json:"name"`
+
+	// Match is a pattern that selects the values the rule applies to.
+	// It uses the path syntax, where a "*" key matches any key and a "[*]"
+	// index matches any index, for example "resources.jobs.*.tasks[*]".
+	Match string `json:"match"`
+
+	// Schema is a JSON schema that every selected value must satisfy.
+	// If it is not set, selecting a value is a violation of the rule.
+	// This can be used to forbid fields, for example "resources.jobs.*.tasks[*].existing_cluster_id".
+	Schema map[string]dyn.Value `json:"schema,omitempty"`
+
+	// Severity of violations: "error" (the default), "warning" or "info".
+	Severity string `json:"severity,omitempty"`
+
+	// Message is the summary of the diagnostic reported for violations.
+	Message string `json:"message,omitempty"`
+
+	// Targets limits the rule to the specified targets.
+	Targets []string `json:"targets,omitempty"`
+
+	// Modes limits the rule to targets with the specified modes.
+	Modes []Mode `json:"modes,omitempty"`
+}
@@ -47,6 +47,30 @@ type DeploymentState struct {
 
 	// Files is a list of files which has been deployed as part of this deployment.
 	Files Filelist `json:"files"`
+
+	// Artifacts records the inputs and outputs of the artifacts built and
+	// uploaded as part of this deployment, keyed by artifact name.
+	// It is used to skip builds and uploads of artifacts that have not changed.
+	Artifacts map[string]ArtifactState `json:"artifacts,omitempty"`
+}
+
+type ArtifactState struct {
+	// SourceHash is the hash of the artifact's source files and build command.
+	SourceHash string `json:"source_hash,omitempty"`
+
+	// Files is the list of files built and uploaded for this artifact.
+	Files []ArtifactFileState `json:"files,omitempty"`
+}
+
+type ArtifactFileState struct {
+	// LocalPath is the path of the file relative to the bundle root.
+	LocalPath string `json:"local_path"`
+
+	// RemotePath is the path the file was uploaded to.
+	RemotePath string `json:"remote_path,omitempty"`
+
+	// Hash is the hash of the file's contents.
+	Hash string `json:"hash"`
 }
 
 // We use this entry type as a proxy to fs.DirEntry.
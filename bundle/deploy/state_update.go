@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/databricks/cli/bundle"
@@ -46,6 +47,9 @@ func (s *stateUpdate) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnost
 	}
 	state.Files = fl
 
+	// Update the state with the artifacts that were built and uploaded.
+	state.Artifacts = artifactStates(b)
+
 	statePath, err := getPathToStateFile(ctx, b)
 	if err != nil {
 		return diag.FromErr(err)
@@ -75,6 +79,44 @@ func StateUpdate() bundle.Mutator {
 	return &stateUpdate{}
 }
 
+func artifactStates(b *bundle.Bundle) map[string]ArtifactState {
+	out := make(map[string]ArtifactState)
+	for name, artifact := range b.Config.Artifacts {
+		var files []ArtifactFileState
+		for _, f := range artifact.Files {
+			if f.Hash == "" {
+				continue
+			}
+			rel, err := filepath.Rel(b.RootPath, f.Source)
+			if err != nil {
+				continue
+			}
+			files = append(files, ArtifactFileState{
+				LocalPath:  filepath.ToSlash(rel),
+				RemotePath: f.RemotePath,
+				Hash:       f.Hash,
+			})
+		}
+		if artifact.SourceHash == "" && len(files) == 0 {
+			continue
+		}
+		out[name] = ArtifactState{
+			SourceHash: artifact.SourceHash,
+			Files:      files,
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// LoadState returns the local copy of the deployment state.
+// If there is no local copy, it returns an empty deployment state.
+func LoadState(ctx context.Context, b *bundle.Bundle) (*DeploymentState, error) {
+	return load(ctx, b)
+}
+
 func load(ctx context.Context, b *bundle.Bundle) (*DeploymentState, error) {
 	// If the file does not exist, return a new DeploymentState.
 	statePath, err := getPathToStateFile(ctx, b)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/databricks/cli/bundle"
@@ -136,3 +137,45 @@ func TestStateUpdateWithExistingState(t *testing.T) {
 	})
 	require.Equal(t, build.GetInfo().Version, state.CliVersion)
 }
+
+func TestStateUpdateWithArtifacts(t *testing.T) {
+	s := &stateUpdate{}
+
+	b := setupBundleForStateUpdate(t)
+	b.Config.Artifacts = config.Artifacts{
+		"whl": {
+			SourceHash: "sha256:source",
+			Files: []config.ArtifactFile{
+				{
+					Source:     filepath.Join(b.RootPath, "dist", "a.whl"),
+					RemotePath: "/artifacts/.internal/a.whl",
+					Hash:       "sha256:a",
+				},
+			},
+		},
+		"jar": {
+			Files: []config.ArtifactFile{
+				{Source: filepath.Join(b.RootPath, "b.jar")},
+			},
+		},
+	}
+	ctx := context.Background()
+
+	diags := bundle.Apply(ctx, b, s)
+	require.NoError(t, diags.Error())
+
+	state, err := load(ctx, b)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ArtifactState{
+		"whl": {
+			SourceHash: "sha256:source",
+			Files: []ArtifactFileState{
+				{
+					LocalPath:  "dist/a.whl",
+					RemotePath: "/artifacts/.internal/a.whl",
+					Hash:       "sha256:a",
+				},
+			},
+		},
+	}, state.Artifacts)
+}
@@ -0,0 +1,24 @@
+package bundle
+
+import (
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+)
+
+// OverrideDiagnostics applies the overrides in the "bundle.diagnostics" section
+// to the diagnostics and, in strict mode, reports the remaining warnings as errors.
+func (b *Bundle) OverrideDiagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	diags = config.ApplyDiagnosticOverrides(b.Config.Bundle.Diagnostics, diags)
+	if !b.Strict {
+		return diags
+	}
+
+	out := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity == diag.Warning {
+			d.Severity = diag.Error
+		}
+		out = append(out, d)
+	}
+	return out
+}
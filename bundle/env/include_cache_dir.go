@@ -0,0 +1,14 @@
+package env
+
+import "context"
+
+// IncludeCacheDirVariable names the environment variable that holds the directory
+// where remote includes are cached.
+const IncludeCacheDirVariable = "DATABRICKS_BUNDLE_INCLUDE_CACHE"
+
+// IncludeCacheDir returns the directory where remote includes are cached.
+func IncludeCacheDir(ctx context.Context) (string, bool) {
+	return get(ctx, []string{
+		IncludeCacheDirVariable,
+	})
+}
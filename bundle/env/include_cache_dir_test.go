@@ -0,0 +1,28 @@
+package env
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/internal/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeCacheDir(t *testing.T) {
+	ctx := context.Background()
+
+	testutil.CleanupEnvironment(t)
+
+	t.Run("set", func(t *testing.T) {
+		t.Setenv("DATABRICKS_BUNDLE_INCLUDE_CACHE", "foo")
+		cacheDir, ok := IncludeCacheDir(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "foo", cacheDir)
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		cacheDir, ok := IncludeCacheDir(ctx)
+		assert.False(t, ok)
+		assert.Equal(t, "", cacheDir)
+	})
+}
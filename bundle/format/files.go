@@ -0,0 +1,51 @@
+package format
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/loader"
+)
+
+// Files returns the paths of the configuration files of the bundle at the root path:
+// the main configuration file followed by the local files it includes.
+// Files that are included from remote repositories are not part of the bundle
+// and are not returned.
+func Files(root string) ([]string, error) {
+	main, err := config.FileNames.FindInPath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	r, diags := config.Load(main)
+	if err := diags.Error(); err != nil {
+		return nil, err
+	}
+
+	files := []string{main}
+	seen := map[string]bool{main: true}
+	for _, entry := range r.Include {
+		if loader.IsRemoteInclude(entry) {
+			continue
+		}
+		if filepath.IsAbs(entry) {
+			return nil, fmt.Errorf("%s: includes must be relative paths", entry)
+		}
+
+		matches, err := filepath.Glob(filepath.Join(root, entry))
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(matches)
+		for _, match := range matches {
+			if seen[match] {
+				continue
+			}
+			seen[match] = true
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
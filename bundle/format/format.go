@@ -0,0 +1,280 @@
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/databricks/cli/bundle/config/generate"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/yamlloader"
+	"github.com/databricks/cli/libs/dyn/yamlsaver"
+	"gopkg.in/yaml.v3"
+)
+
+// keyOrder lists the keys that are written first in mappings at paths that match the pattern.
+// Other keys are written after these, in the order they appear in the file.
+type keyOrder struct {
+	pattern dyn.Pattern
+	keys    []string
+}
+
+var variableKeyOrder = []string{"description", "type", "default", "lookup"}
+
+var keyOrders = []keyOrder{
+	{dyn.NewPattern(), []string{
+		"bundle", "include", "workspace", "variables", "definitions", "artifacts", "sync", "scripts", "experimental",
+		"permissions", "run_as", "validation", "resources", "targets", "environments",
+	}},
+	{dyn.NewPattern(dyn.Key("targets"), dyn.AnyKey()), []string{"mode", "default", "workspace"}},
+	{dyn.NewPattern(dyn.Key("variables"), dyn.AnyKey()), variableKeyOrder},
+	{dyn.NewPattern(dyn.Key("targets"), dyn.AnyKey(), dyn.Key("variables"), dyn.AnyKey()), variableKeyOrder},
+}
+
+func init() {
+	// Resources can be defined at the top level and in targets.
+	// The orders for jobs, tasks and pipelines are the ones used by 'bundle generate'.
+	for _, prefix := range []dyn.Pattern{
+		dyn.NewPattern(dyn.Key("resources")),
+		dyn.NewPattern(dyn.Key("targets"), dyn.AnyKey(), dyn.Key("resources")),
+	} {
+		jobs := prefix.Append(dyn.Key("jobs"), dyn.AnyKey())
+		keyOrders = append(keyOrders,
+			keyOrder{jobs, generate.JobKeyOrder},
+			keyOrder{jobs.Append(dyn.Key("tasks"), dyn.AnyIndex()), generate.TaskKeyOrder},
+			keyOrder{prefix.Append(dyn.Key("pipelines"), dyn.AnyKey()), generate.PipelineKeyOrder},
+		)
+	}
+}
+
+// Format returns the formatted contents of a bundle configuration file.
+//
+// Formatting preserves comments. It orders keys according to the conventions
+// used by 'bundle generate', expands shorthand forms that are otherwise rewritten
+// when the configuration is loaded, and indents with two spaces.
+//
+// JSON files are returned unchanged, because writing them
+// as YAML would change the format of the file. Files with multiple
+// YAML documents are returned unchanged as well, because only the
+// first document is loaded as configuration.
+func Format(path string, raw []byte) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc":
+		return raw, nil
+	}
+
+	// Load the file the same way the configuration is loaded
+	// to report syntax errors with their location.
+	_, err := yamlloader.LoadYAML(path, bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	err = dec.Decode(&doc)
+	if errors.Is(err, io.EOF) {
+		return raw, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Encoding the first document would drop the documents that follow it.
+	var next yaml.Node
+	if !errors.Is(dec.Decode(&next), io.EOF) {
+		return raw, nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return raw, nil
+	}
+
+	root := doc.Content[0]
+	rewriteShorthands(root)
+
+	// A comment at the start of the file is attached to the first key,
+	// but it applies to the file, so it stays at the start.
+	if len(root.Content) > 0 && doc.HeadComment == "" {
+		doc.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+
+	// Aliases must follow their anchors, so keys are only
+	// reordered in files that don't use aliases.
+	if !hasAlias(&doc) {
+		orderKeys(root, dyn.EmptyPath)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err = enc.Encode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+	return separateSections(buf.Bytes()), nil
+}
+
+// separateSections inserts an empty line before every top-level key and the comments
+// that precede it. Empty lines are not preserved when YAML is parsed, and without
+// them the sections of a file are hard to tell apart.
+func separateSections(raw []byte) []byte {
+	var out []string
+	for _, line := range strings.SplitAfter(string(raw), "\n") {
+		if len(out) > 0 && line != "" && !strings.HasPrefix(line, "#") && !unicode.IsSpace(rune(line[0])) && !strings.HasPrefix(line, "-") {
+			// Insert before the comments that precede the key.
+			i := len(out)
+			for i > 0 && strings.HasPrefix(out[i-1], "#") {
+				i--
+			}
+			if i > 0 && out[i-1] != "\n" {
+				out = slices.Insert(out, i, "\n")
+			}
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, ""))
+}
+
+func hasAlias(node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode {
+		return true
+	}
+	return slices.ContainsFunc(node.Content, hasAlias)
+}
+
+// orderKeys orders the keys of all mappings in the tree.
+func orderKeys(node *yaml.Node, p dyn.Path) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			orderKeys(node.Content[i+1], p.Append(dyn.Key(node.Content[i].Value)))
+		}
+		for _, o := range keyOrders {
+			if len(o.pattern) == len(p) && o.pattern.MatchesPrefix(p) {
+				sortPairs(node, yamlsaver.NewOrder(o.keys))
+				break
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			orderKeys(item, p.Append(dyn.Index(i)))
+		}
+	}
+}
+
+func sortPairs(node *yaml.Node, order *yamlsaver.Order) {
+	type pair struct {
+		key, value *yaml.Node
+		index      int
+	}
+
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		pairs = append(pairs, pair{key: key, value: node.Content[i+1], index: order.Get(key.Value)})
+	}
+	if len(pairs) == 0 {
+		return
+	}
+
+	// A comment at the end of a mapping is attached to its last pair,
+	// but it applies to the mapping, so it stays at the end.
+	last := pairs[len(pairs)-1]
+	foot := last.key.FootComment
+	last.key.FootComment = ""
+	if foot == "" && last.value.Kind == yaml.ScalarNode {
+		foot = last.value.FootComment
+		last.value.FootComment = ""
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].index < pairs[j].index
+	})
+	if foot != "" {
+		pairs[len(pairs)-1].key.FootComment = foot
+	}
+
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+	}
+}
+
+// rewriteShorthands expands the shorthand forms that are rewritten when the configuration
+// is loaded: scripts that are specified as a command, and variables in targets that are
+// specified as a value.
+func rewriteShorthands(root *yaml.Node) {
+	rewriteScriptShorthands(root)
+
+	targets := mappingValue(root, "targets")
+	if targets == nil || targets.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(targets.Content); i += 2 {
+		target := targets.Content[i]
+		rewriteScriptShorthands(target)
+
+		variables := mappingValue(target, "variables")
+		if variables == nil || variables.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 1; j < len(variables.Content); j += 2 {
+			if isValue(variables.Content[j]) {
+				variables.Content[j] = wrap("default", variables.Content[j])
+			}
+		}
+	}
+}
+
+func rewriteScriptShorthands(node *yaml.Node) {
+	scripts := mappingValue(node, "scripts")
+	if scripts == nil || scripts.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(scripts.Content); i += 2 {
+		if isValue(scripts.Content[i]) {
+			scripts.Content[i] = wrap("command", scripts.Content[i])
+		}
+	}
+}
+
+// mappingValue returns the value for a key in a mapping, or nil if the key doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// isValue returns true if the node is a scalar other than null.
+func isValue(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() != "!!null"
+}
+
+// wrap returns a mapping with a single key for the value.
+func wrap(key string, value *yaml.Node) *yaml.Node {
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			value,
+		},
+	}
+}
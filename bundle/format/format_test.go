@@ -0,0 +1,194 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	in := `# Header comment
+targets:
+  dev:
+    workspace:
+        host: https://example.com
+    mode: development  # Comment on value
+    variables:
+      catalog: dev
+      count: 3
+      empty:
+      cluster:
+        lookup:
+          cluster: shared
+resources:
+  jobs:
+    my_job:
+      tasks:
+        # Comment on task
+        - notebook_task:
+            notebook_path: ./nb.py
+          task_key: main
+      tags: {team: data}
+      name: "My job"
+bundle:
+  name: test
+scripts:
+  preinit: echo hi
+`
+
+	expected := `# Header comment
+
+bundle:
+  name: test
+
+scripts:
+  preinit:
+    command: echo hi
+
+resources:
+  jobs:
+    my_job:
+      name: "My job"
+      tasks:
+        # Comment on task
+        - task_key: main
+          notebook_task:
+            notebook_path: ./nb.py
+      tags: {team: data}
+
+targets:
+  dev:
+    mode: development # Comment on value
+    workspace:
+      host: https://example.com
+    variables:
+      catalog:
+        default: dev
+      count:
+        default: 3
+      empty:
+      cluster:
+        lookup:
+          cluster: shared
+`
+
+	out, err := Format("databricks.yml", []byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+
+	// Formatting is idempotent.
+	out, err = Format("databricks.yml", out)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+}
+
+func TestFormatWithAliasesDoesNotOrderKeys(t *testing.T) {
+	in := `resources:
+  jobs:
+    my_job:
+      tasks:
+        - job_cluster_key: default
+          task_key: main
+      job_clusters:
+        - job_cluster_key: default
+          new_cluster: &cluster
+            spark_version: 14.3.x-scala2.12
+    other_job:
+      job_clusters:
+        - job_cluster_key: default
+          new_cluster: *cluster
+`
+
+	out, err := Format("databricks.yml", []byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, string(out))
+}
+
+func TestFormatKeepsCommentAtEndOfMapping(t *testing.T) {
+	in := `resources:
+  jobs:
+    my_job:
+      tasks:
+        - task_key: main
+      name: "My job"
+      # Comment at the end of the job
+`
+
+	expected := `resources:
+  jobs:
+    my_job:
+      name: "My job"
+      tasks:
+        - task_key: main
+      # Comment at the end of the job
+`
+
+	out, err := Format("databricks.yml", []byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+}
+
+func TestFormatJSONUnchanged(t *testing.T) {
+	in := `{
+  "resources": {"jobs": {"my_job": {"tasks": [], "name": "My job"}}},
+  "bundle": {"name": "test"}
+}
+`
+
+	for _, path := range []string{"databricks.json", "resources/jobs.jsonc"} {
+		out, err := Format(path, []byte(in))
+		require.NoError(t, err)
+		assert.Equal(t, in, string(out))
+	}
+}
+
+func TestFormatMultipleDocumentsUnchanged(t *testing.T) {
+	in := `resources:
+    jobs: {}
+bundle:
+    name: test
+---
+resources:
+    pipelines: {}
+`
+
+	out, err := Format("databricks.yml", []byte(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, string(out))
+}
+
+func TestFormatEmpty(t *testing.T) {
+	out, err := Format("databricks.yml", []byte(""))
+	require.NoError(t, err)
+	assert.Equal(t, "", string(out))
+}
+
+func TestFormatInvalid(t *testing.T) {
+	_, err := Format("databricks.yml", []byte("bundle:\n  name: [test\n"))
+	assert.ErrorContains(t, err, "databricks.yml")
+}
+
+func TestFiles(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, contents string) {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	}
+
+	write("databricks.yml", "bundle:\n  name: test\ninclude:\n  - resources/*.yml\n  - resources/b.yml\n")
+	write("resources/b.yml", "resources: {}\n")
+	write("resources/a.yml", "resources: {}\n")
+	write("other/c.yml", "resources: {}\n")
+
+	files, err := Files(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "databricks.yml"),
+		filepath.Join(root, "resources", "a.yml"),
+		filepath.Join(root, "resources", "b.yml"),
+	}, files)
+}
@@ -69,6 +69,32 @@ func FindAllWheelTasksWithLocalLibraries(b *bundle.Bundle) []*jobs.Task {
 	return wheelTasks
 }
 
+func FindAllJarTasksWithLocalLibraries(b *bundle.Bundle) []*jobs.Task {
+	tasks := findAllTasks(b)
+
+	jarTasks := make([]*jobs.Task, 0)
+	for _, jobTasks := range tasks {
+		for i := range jobTasks {
+			task := &jobTasks[i]
+			if task.SparkJarTask == nil {
+				continue
+			}
+
+			if isTaskWithLocalLibraries(*task) || IsLocalPathJarUri(task.SparkJarTask.JarUri) {
+				jarTasks = append(jarTasks, task)
+			}
+		}
+	}
+
+	return jarTasks
+}
+
+// IsLocalPathJarUri returns true if the (deprecated) jar_uri field
+// of a Spark JAR task refers to a local file.
+func IsLocalPathJarUri(uri string) bool {
+	return uri != "" && IsLocalPath(uri)
+}
+
 func isTaskWithLocalLibraries(task jobs.Task) bool {
 	for _, l := range task.Libraries {
 		if IsLocalLibrary(&l) {
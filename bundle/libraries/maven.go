@@ -0,0 +1,33 @@
+package libraries
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mavenIdentifier = `[A-Za-z0-9_.\-]+`
+
+	// Coordinates have the form groupId:artifactId[:packaging[:classifier]]:version.
+	mavenCoordinates = regexp.MustCompile(`^` + mavenIdentifier + `:` + mavenIdentifier + `(?::` + mavenIdentifier + `){1,3}$`)
+
+	// Exclusions have the form groupId:artifactId.
+	mavenExclusion = regexp.MustCompile(`^` + mavenIdentifier + `:` + mavenIdentifier + `$`)
+)
+
+// isValidMavenCoordinates returns true if the string is well-formed Maven coordinates.
+func isValidMavenCoordinates(s string) bool {
+	return mavenCoordinates.MatchString(s)
+}
+
+// isValidMavenExclusion returns true if the string is a well-formed Maven exclusion.
+func isValidMavenExclusion(s string) bool {
+	return mavenExclusion.MatchString(s)
+}
+
+// mavenPackageVersion splits Maven coordinates into the package (groupId:artifactId)
+// and its version. The coordinates must be valid.
+func mavenPackageVersion(s string) (string, string) {
+	parts := strings.Split(s, ":")
+	return parts[0] + ":" + parts[1], parts[len(parts)-1]
+}
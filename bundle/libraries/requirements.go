@@ -0,0 +1,190 @@
+package libraries
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// requirement is a single requirement specifier from a requirements file or a PyPI library.
+//
+// See https://pip.pypa.io/en/stable/reference/requirement-specifiers/.
+type requirement struct {
+	// Normalized name of the package.
+	name string
+
+	// Version if the requirement pins the package to a single version with ==.
+	pinned string
+}
+
+var (
+	requirementName      = `[A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?`
+	requirementExtras    = `(?:\s*\[\s*[A-Za-z0-9._,\s-]*\])?`
+	requirementVersion   = `(?:===|==|!=|<=|>=|~=|<|>)\s*[A-Za-z0-9.*+!_-]+`
+	requirementSpecifier = regexp.MustCompile(`^(` + requirementName + `)` + requirementExtras +
+		`\s*(?:\(?\s*(` + requirementVersion + `(?:\s*,\s*` + requirementVersion + `)*)\s*\)?|@\s*\S+)?` +
+		`\s*(?:;.*)?$`)
+	requirementNormalize = regexp.MustCompile(`[-_.]+`)
+)
+
+// normalizePackageName normalizes a Python package name so that equivalent names compare equal.
+//
+// See https://packaging.python.org/en/latest/specifications/name-normalization/.
+func normalizePackageName(name string) string {
+	return strings.ToLower(requirementNormalize.ReplaceAllString(name, "-"))
+}
+
+// parseRequirement parses a requirement specifier such as "requests[security]>=2.0,<3; python_version > '3.8'".
+// It returns false if the specifier is malformed.
+func parseRequirement(s string) (requirement, bool) {
+	m := requirementSpecifier.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return requirement{}, false
+	}
+
+	r := requirement{name: normalizePackageName(m[1])}
+	spec := strings.ReplaceAll(m[2], " ", "")
+	if strings.HasPrefix(spec, "==") && !strings.HasPrefix(spec, "===") && !strings.Contains(spec, ",") && !strings.Contains(spec, "*") {
+		r.pinned = strings.TrimPrefix(spec, "==")
+	}
+	return r, true
+}
+
+// isRequirementReference returns true if the line of a requirements file
+// refers to a local path or a URL instead of a package on an index.
+// Direct references of the form "name @ url" name the package and are not included.
+func isRequirementReference(s string) bool {
+	return strings.HasPrefix(s, ".") ||
+		strings.HasPrefix(s, "/") ||
+		strings.HasPrefix(s, "~") ||
+		strings.HasPrefix(s, "file:") ||
+		(strings.Contains(s, "://") && !strings.Contains(s, " @"))
+}
+
+// requirementsFile holds the requirements read from a requirements file and the files it includes.
+type requirementsFile struct {
+	requirements []requirement
+	diags        diag.Diagnostics
+}
+
+// readRequirementsFile reads the requirements file at the specified path.
+// Malformed lines and included files that don't exist are reported as diagnostics.
+func readRequirementsFile(path string) (*requirementsFile, error) {
+	out := &requirementsFile{}
+	err := out.read(path, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *requirementsFile) read(path string, seen map[string]bool) error {
+	if seen[path] {
+		return nil
+	}
+	seen[path] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	line := 0
+	for s.Scan() {
+		line++
+		start := line
+		text := s.Text()
+
+		// Lines ending in a backslash continue on the next line.
+		for strings.HasSuffix(text, `\`) && s.Scan() {
+			line++
+			text = strings.TrimSuffix(text, `\`) + " " + s.Text()
+		}
+
+		// Comments start with a # at the beginning of the line or after whitespace.
+		if i := strings.Index(text, " #"); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		loc := dyn.Location{File: path, Line: start, Column: 1}
+
+		// Options apply to the whole file except for the ones that include other files.
+		if strings.HasPrefix(text, "-") {
+			include, ok := requirementsInclude(text)
+			if !ok {
+				continue
+			}
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			err := r.read(include, seen)
+			if os.IsNotExist(err) {
+				r.diags = r.diags.Append(diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("requirements file %s includes %s, which doesn't exist", filepath.Base(path), include),
+					Location: loc,
+				})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Per-requirement options such as --hash follow the specifier.
+		if i := strings.Index(text, " --"); i >= 0 {
+			text = strings.TrimSpace(text[:i])
+		}
+
+		if isRequirementReference(text) {
+			continue
+		}
+
+		req, ok := parseRequirement(text)
+		if !ok {
+			r.diags = r.diags.Append(diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("invalid requirement %q in %s", text, filepath.Base(path)),
+				Detail:   "See https://pip.pypa.io/en/stable/reference/requirement-specifiers/ for the supported syntax.",
+				Location: loc,
+			})
+			continue
+		}
+		r.requirements = append(r.requirements, req)
+	}
+
+	return s.Err()
+}
+
+// requirementsInclude returns the path of the requirements file included by an option line
+// such as "-r other.txt" or "--requirement=other.txt".
+func requirementsInclude(option string) (string, bool) {
+	for _, prefix := range []string{"-r", "--requirement"} {
+		if !strings.HasPrefix(option, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(option, prefix)
+		if rest == "" || (rest[0] != ' ' && rest[0] != '=' && prefix == "--requirement") {
+			continue
+		}
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "="))
+		if rest == "" {
+			continue
+		}
+		return rest, true
+	}
+	return "", false
+}
@@ -0,0 +1,83 @@
+package libraries
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequirement(t *testing.T) {
+	for _, tc := range []struct {
+		input  string
+		name   string
+		pinned string
+	}{
+		{"requests", "requests", ""},
+		{"requests==2.31.0", "requests", "2.31.0"},
+		{"Requests == 2.31.0", "requests", "2.31.0"},
+		{"requests[security]==2.31.0", "requests", "2.31.0"},
+		{"scikit_learn>=1.3,<2", "scikit-learn", ""},
+		{"numpy==1.*", "numpy", ""},
+		{"numpy===1.26.0", "numpy", ""},
+		{"Foo.Bar~=1.0; python_version < '3.11'", "foo-bar", ""},
+		{"pkg @ https://example.com/pkg.whl", "pkg", ""},
+	} {
+		req, ok := parseRequirement(tc.input)
+		require.True(t, ok, tc.input)
+		assert.Equal(t, tc.name, req.name, tc.input)
+		assert.Equal(t, tc.pinned, req.pinned, tc.input)
+	}
+
+	for _, input := range []string{
+		"pandas = 2.0",
+		"-pandas",
+		"pandas==",
+		"pandas 2.0",
+	} {
+		_, ok := parseRequirement(input)
+		assert.False(t, ok, input)
+	}
+}
+
+func TestReadRequirementsFile(t *testing.T) {
+	dir := filepath.Join("testdata", "requirements")
+	rf, err := readRequirementsFile(filepath.Join(dir, "requirements.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []requirement{
+		{name: "pyyaml", pinned: "6.0.1"},
+		{name: "requests", pinned: "2.31.0"},
+		{name: "numpy"},
+		{name: "my-package"},
+		{name: "django"},
+		{name: "scikit-learn", pinned: "1.3.0"},
+	}, rf.requirements)
+
+	require.Len(t, rf.diags, 2)
+	assert.Equal(t, diag.Error, rf.diags[0].Severity)
+	assert.Equal(t, "requirements file base.txt includes "+filepath.Join(dir, "missing.txt")+", which doesn't exist", rf.diags[0].Summary)
+	assert.Equal(t, 2, rf.diags[0].Location.Line)
+	assert.Equal(t, `invalid requirement "pandas = 2.0" in requirements.txt`, rf.diags[1].Summary)
+	assert.Equal(t, 8, rf.diags[1].Location.Line)
+}
+
+func TestRequirementsInclude(t *testing.T) {
+	for input, expected := range map[string]string{
+		"-r requirements.txt":            "requirements.txt",
+		"-rrequirements.txt":             "requirements.txt",
+		"--requirement requirements.txt": "requirements.txt",
+		"--requirement=requirements.txt": "requirements.txt",
+	} {
+		path, ok := requirementsInclude(input)
+		assert.True(t, ok, input)
+		assert.Equal(t, expected, path, input)
+	}
+
+	for _, input := range []string{"-c constraints.txt", "--index-url https://pypi.org/simple", "-r"} {
+		_, ok := requirementsInclude(input)
+		assert.False(t, ok, input)
+	}
+}
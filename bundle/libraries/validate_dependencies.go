@@ -0,0 +1,288 @@
+package libraries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"golang.org/x/exp/maps"
+)
+
+type validateDependencies struct{}
+
+// ValidateDependencies validates the libraries of job tasks and the dependencies of job environments.
+//
+// It checks that the entry points of Python wheel tasks exist in local wheels,
+// that requirements files can be read and are well-formed, that Maven coordinates
+// are well-formed and that tasks sharing a job cluster don't pin the same package
+// to different versions.
+//
+// It must run before artifacts are uploaded while library paths still refer to local files.
+func ValidateDependencies() bundle.Mutator {
+	return &validateDependencies{}
+}
+
+func (m *validateDependencies) Name() string {
+	return "libraries.ValidateDependencies"
+}
+
+// pin records the version a package is pinned to by a task library.
+type pin struct {
+	version string
+	task    string
+	path    dyn.Path
+}
+
+func (m *validateDependencies) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	jobKeys := maps.Keys(b.Config.Resources.Jobs)
+	slices.Sort(jobKeys)
+
+	for _, jobKey := range jobKeys {
+		job := b.Config.Resources.Jobs[jobKey]
+		if job.JobSettings == nil {
+			continue
+		}
+		jobPath := dyn.NewPath(dyn.Key("resources"), dyn.Key("jobs"), dyn.Key(jobKey))
+
+		for i, env := range job.Environments {
+			if env.Spec == nil {
+				continue
+			}
+			for j, dep := range env.Spec.Dependencies {
+				include, ok := requirementsInclude(dep)
+				if !ok {
+					continue
+				}
+				p := jobPath.Append(dyn.Key("environments"), dyn.Index(i), dyn.Key("spec"), dyn.Key("dependencies"), dyn.Index(j))
+				_, d := readTaskRequirements(b, include, p)
+				diags = diags.Extend(d)
+			}
+		}
+
+		// Pinned versions by job cluster and package.
+		pins := make(map[string]map[string][]pin)
+
+		for i, task := range job.Tasks {
+			taskPath := jobPath.Append(dyn.Key("tasks"), dyn.Index(i))
+
+			var taskPins map[string]pin
+			if task.JobClusterKey != "" {
+				taskPins = make(map[string]pin)
+			}
+			addPin := func(pkg, version string, p dyn.Path) {
+				if taskPins != nil && version != "" {
+					taskPins[pkg] = pin{version: version, task: task.TaskKey, path: p}
+				}
+			}
+
+			for j, lib := range task.Libraries {
+				libPath := taskPath.Append(dyn.Key("libraries"), dyn.Index(j))
+
+				switch {
+				case lib.Maven != nil:
+					p := libPath.Append(dyn.Key("maven"))
+					if !isValidMavenCoordinates(lib.Maven.Coordinates) {
+						diags = diags.Append(newDiagnostic(b, diag.Error, "", p.Append(dyn.Key("coordinates")),
+							fmt.Sprintf("invalid Maven coordinates %q", lib.Maven.Coordinates),
+							"Maven coordinates must have the form groupId:artifactId[:packaging[:classifier]]:version.",
+						))
+						continue
+					}
+					for k, exclusion := range lib.Maven.Exclusions {
+						if !isValidMavenExclusion(exclusion) {
+							diags = diags.Append(newDiagnostic(b, diag.Error, "", p.Append(dyn.Key("exclusions"), dyn.Index(k)),
+								fmt.Sprintf("invalid Maven exclusion %q", exclusion),
+								"Maven exclusions must have the form groupId:artifactId.",
+							))
+						}
+					}
+					pkg, version := mavenPackageVersion(lib.Maven.Coordinates)
+					addPin(pkg, version, p.Append(dyn.Key("coordinates")))
+
+				case lib.Pypi != nil:
+					p := libPath.Append(dyn.Key("pypi"), dyn.Key("package"))
+					if isRequirementReference(lib.Pypi.Package) {
+						continue
+					}
+					req, ok := parseRequirement(lib.Pypi.Package)
+					if !ok {
+						diags = diags.Append(newDiagnostic(b, diag.Error, "", p,
+							fmt.Sprintf("invalid PyPI package %q", lib.Pypi.Package),
+							"See https://pip.pypa.io/en/stable/reference/requirement-specifiers/ for the supported syntax.",
+						))
+						continue
+					}
+					addPin(req.name, req.pinned, p)
+
+				case lib.Requirements != "":
+					p := libPath.Append(dyn.Key("requirements"))
+					rf, d := readTaskRequirements(b, lib.Requirements, p)
+					diags = diags.Extend(d)
+					if rf != nil {
+						for _, req := range rf.requirements {
+							addPin(req.name, req.pinned, p)
+						}
+					}
+				}
+			}
+
+			if task.PythonWheelTask != nil {
+				diags = diags.Extend(validateEntryPoint(b, job.Environments, task.EnvironmentKey, task.Libraries, task.PythonWheelTask.PackageName, task.PythonWheelTask.EntryPoint, taskPath))
+			}
+
+			if len(taskPins) > 0 {
+				if pins[task.JobClusterKey] == nil {
+					pins[task.JobClusterKey] = make(map[string][]pin)
+				}
+				for pkg, p := range taskPins {
+					pins[task.JobClusterKey][pkg] = append(pins[task.JobClusterKey][pkg], p)
+				}
+			}
+		}
+
+		diags = diags.Extend(conflictingPins(b, pins))
+	}
+
+	return diags
+}
+
+// conflictingPins reports packages that are pinned to different versions by tasks that share a job cluster.
+// Libraries of all tasks are installed on the job cluster, so only one of the versions is used.
+func conflictingPins(b *bundle.Bundle, pins map[string]map[string][]pin) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	clusterKeys := maps.Keys(pins)
+	slices.Sort(clusterKeys)
+	for _, clusterKey := range clusterKeys {
+		pkgs := maps.Keys(pins[clusterKey])
+		slices.Sort(pkgs)
+		for _, pkg := range pkgs {
+			ps := pins[clusterKey][pkg]
+			conflict := slices.IndexFunc(ps, func(p pin) bool { return p.version != ps[0].version })
+			if conflict < 0 {
+				continue
+			}
+
+			var detail []string
+			for _, p := range ps {
+				detail = append(detail, fmt.Sprintf("task %s pins version %s", p.task, p.version))
+			}
+			diags = diags.Append(newDiagnostic(b, diag.Warning, config.ConflictingLibraryVersion, ps[conflict].path,
+				fmt.Sprintf("%s is pinned to different versions by tasks on job cluster %s", pkg, clusterKey),
+				strings.Join(detail, "\n"),
+			))
+		}
+	}
+
+	return diags
+}
+
+// validateEntryPoint checks that the entry point of a Python wheel task exists
+// in the local wheel that provides the task's package, if there is one.
+func validateEntryPoint(b *bundle.Bundle, envs []jobs.JobEnvironment, envKey string, libs []compute.Library, packageName, entryPoint string, taskPath dyn.Path) diag.Diagnostics {
+	var paths []string
+	for _, lib := range libs {
+		if lib.Whl != "" && IsLocalPath(lib.Whl) {
+			paths = append(paths, lib.Whl)
+		}
+	}
+	for _, env := range envs {
+		if env.EnvironmentKey != envKey || env.Spec == nil {
+			continue
+		}
+		for _, dep := range env.Spec.Dependencies {
+			if IsEnvironmentDependencyLocal(dep) && strings.HasSuffix(dep, ".whl") {
+				paths = append(paths, dep)
+			}
+		}
+	}
+
+	for _, p := range paths {
+		matches, err := filepath.Glob(filepath.Join(b.RootPath, p))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			wheel, err := readWheelMetadata(match)
+			if err != nil || wheel.name != normalizePackageName(packageName) {
+				continue
+			}
+			if wheel.hasEntryPoint(packageName, entryPoint) {
+				return nil
+			}
+			return diag.Diagnostics{newDiagnostic(b, diag.Warning, config.EntryPointNotFound, taskPath.Append(dyn.Key("python_wheel_task"), dyn.Key("entry_point")),
+				fmt.Sprintf("entry point %s not found in %s", entryPoint, filepath.Base(match)),
+				fmt.Sprintf("The wheel doesn't define an entry point named %s and its %s module doesn't define a function with that name.", entryPoint, strings.ReplaceAll(packageName, "-", "_")),
+			)}
+		}
+	}
+
+	return nil
+}
+
+// readTaskRequirements reads a requirements file referenced by a task library or an environment.
+// Files that are synchronized to the workspace are read from their local copy.
+// Files elsewhere in the workspace or in volumes are not checked.
+func readTaskRequirements(b *bundle.Bundle, p string, configPath dyn.Path) (*requirementsFile, diag.Diagnostics) {
+	local, ok := localRequirementsPath(b, p)
+	if !ok {
+		return nil, nil
+	}
+
+	rf, err := readRequirementsFile(local)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, diag.Diagnostics{newDiagnostic(b, diag.Error, "", configPath,
+			fmt.Sprintf("requirements file %s doesn't exist on the local file system", p), "",
+		)}
+	}
+	if err != nil {
+		return nil, diag.Diagnostics{newDiagnostic(b, diag.Error, "", configPath,
+			fmt.Sprintf("unable to read requirements file %s: %v", p, err), "",
+		)}
+	}
+
+	return rf, rf.diags
+}
+
+// localRequirementsPath returns the local path of a requirements file.
+func localRequirementsPath(b *bundle.Bundle, p string) (string, bool) {
+	if IsLocalPath(p) && !strings.HasPrefix(p, "file://") {
+		return filepath.Join(b.RootPath, p), true
+	}
+
+	filePath := strings.TrimPrefix(b.Config.Workspace.FilePath, "/Workspace")
+	rel := strings.TrimPrefix(p, "/Workspace")
+	if filePath == "" || !strings.HasPrefix(rel, filePath+"/") {
+		return "", false
+	}
+
+	rel = path.Clean(strings.TrimPrefix(rel, filePath+"/"))
+	if strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return filepath.Join(b.RootPath, filepath.FromSlash(rel)), true
+}
+
+func newDiagnostic(b *bundle.Bundle, severity diag.Severity, id diag.ID, p dyn.Path, summary, detail string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: severity,
+		Summary:  summary,
+		Detail:   detail,
+		Location: b.Config.GetLocation(p.String()),
+		Path:     p,
+		ID:       id,
+	}
+}
@@ -0,0 +1,240 @@
+package libraries
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dependenciesBundle(root string, tasks []jobs.Task, envs []jobs.JobEnvironment) *bundle.Bundle {
+	return &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Workspace: config.Workspace{
+				FilePath: "/Workspace/Users/foo@bar.com/.bundle/test/files",
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"job": {
+						JobSettings: &jobs.JobSettings{
+							Tasks:        tasks,
+							Environments: envs,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func writeWheel(t *testing.T, path string, files map[string]string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, contents := range files {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestValidateDependenciesMaven(t *testing.T) {
+	b := dependenciesBundle(t.TempDir(), []jobs.Task{
+		{
+			TaskKey: "task",
+			Libraries: []compute.Library{
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:1.0.0"}},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:jar:tests:1.0.0"}},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library"}},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:1.0.0", Exclusions: []string{"org.slf4j:slf4j-api:1.0"}}},
+			},
+		},
+	}, nil)
+
+	diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, `invalid Maven coordinates "com.example:library"`, diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job.tasks[0].libraries[2].maven.coordinates", diags[0].Path.String())
+	assert.Equal(t, `invalid Maven exclusion "org.slf4j:slf4j-api:1.0"`, diags[1].Summary)
+	assert.Equal(t, "resources.jobs.job.tasks[0].libraries[3].maven.exclusions[0]", diags[1].Path.String())
+}
+
+func TestValidateDependenciesPypi(t *testing.T) {
+	b := dependenciesBundle(t.TempDir(), []jobs.Task{
+		{
+			TaskKey: "task",
+			Libraries: []compute.Library{
+				{Pypi: &compute.PythonPyPiLibrary{Package: "requests==2.31.0"}},
+				{Pypi: &compute.PythonPyPiLibrary{Package: "git+https://github.com/databricks/databricks-sdk-py"}},
+				{Pypi: &compute.PythonPyPiLibrary{Package: "requests = 2.31.0"}},
+			},
+		},
+	}, nil)
+
+	diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, `invalid PyPI package "requests = 2.31.0"`, diags[0].Summary)
+	assert.Equal(t, "resources.jobs.job.tasks[0].libraries[2].pypi.package", diags[0].Path.String())
+}
+
+func TestValidateDependenciesConflictingPins(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "requirements.txt"), []byte("requests==2.30.0\n"), 0644))
+
+	b := dependenciesBundle(root, []jobs.Task{
+		{
+			TaskKey:       "first",
+			JobClusterKey: "cluster",
+			Libraries: []compute.Library{
+				{Pypi: &compute.PythonPyPiLibrary{Package: "requests==2.31.0"}},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:1.0.0"}},
+			},
+		},
+		{
+			TaskKey:       "second",
+			JobClusterKey: "cluster",
+			Libraries: []compute.Library{
+				{Requirements: "/Workspace/Users/foo@bar.com/.bundle/test/files/requirements.txt"},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:1.0.0"}},
+			},
+		},
+		{
+			// Tasks on other clusters don't conflict.
+			TaskKey:       "third",
+			JobClusterKey: "other",
+			Libraries: []compute.Library{
+				{Pypi: &compute.PythonPyPiLibrary{Package: "requests==2.29.0"}},
+				{Maven: &compute.MavenLibrary{Coordinates: "com.example:library:2.0.0"}},
+			},
+		},
+	}, nil)
+
+	diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "requests is pinned to different versions by tasks on job cluster cluster", diags[0].Summary)
+	assert.Equal(t, "task first pins version 2.31.0\ntask second pins version 2.30.0", diags[0].Detail)
+	assert.Equal(t, "resources.jobs.job.tasks[1].libraries[0].requirements", diags[0].Path.String())
+}
+
+func TestValidateDependenciesRequirements(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "requirements.txt"), []byte("requests==2.31.0\nnot a requirement\n"), 0644))
+
+	b := dependenciesBundle(root, []jobs.Task{
+		{
+			TaskKey: "task",
+			Libraries: []compute.Library{
+				{Requirements: "/Workspace/Users/foo@bar.com/.bundle/test/files/requirements.txt"},
+				{Requirements: "/Workspace/Users/foo@bar.com/.bundle/test/files/missing.txt"},
+				{Requirements: "/Volumes/main/default/libraries/requirements.txt"},
+			},
+		},
+	}, []jobs.JobEnvironment{
+		{
+			EnvironmentKey: "env",
+			Spec: &compute.Environment{
+				Dependencies: []string{"-r /Workspace/Users/foo@bar.com/.bundle/test/files/requirements.txt"},
+			},
+		},
+	})
+
+	diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+	require.Len(t, diags, 3)
+
+	// The requirements file is referenced by the environment and the task.
+	assert.Equal(t, `invalid requirement "not a requirement" in requirements.txt`, diags[0].Summary)
+	assert.Equal(t, filepath.Join(root, "requirements.txt"), diags[0].Location.File)
+	assert.Equal(t, 2, diags[0].Location.Line)
+	assert.Equal(t, `invalid requirement "not a requirement" in requirements.txt`, diags[1].Summary)
+	assert.Equal(t, "requirements file /Workspace/Users/foo@bar.com/.bundle/test/files/missing.txt doesn't exist on the local file system", diags[2].Summary)
+	assert.Equal(t, "resources.jobs.job.tasks[0].libraries[1].requirements", diags[2].Path.String())
+}
+
+func TestValidateDependenciesEntryPoint(t *testing.T) {
+	root := t.TempDir()
+	writeWheel(t, filepath.Join(root, "dist", "my_package-0.1.0-py3-none-any.whl"), map[string]string{
+		"my_package/__init__.py":                         "from .main import run\n",
+		"my_package/main.py":                             "def run():\n    pass\n",
+		"my_package-0.1.0.dist-info/METADATA":            "Metadata-Version: 2.1\nName: my-package\nVersion: 0.1.0\n\nDescription\n",
+		"my_package-0.1.0.dist-info/entry_points.txt":    "[console_scripts]\nmain = my_package.main:run\n",
+		"my_package-0.1.0.dist-info/RECORD":              "",
+		"other_package-0.1.0.dist-info/entry_points.txt": "[console_scripts]\nother = other:main\n",
+	})
+
+	for _, tc := range []struct {
+		entryPoint string
+		found      bool
+	}{
+		// Defined in entry_points.txt.
+		{"main", true},
+		// Called as a function of the package module.
+		{"run", true},
+		{"missing", false},
+	} {
+		b := dependenciesBundle(root, []jobs.Task{
+			{
+				TaskKey: "task",
+				PythonWheelTask: &jobs.PythonWheelTask{
+					PackageName: "my_package",
+					EntryPoint:  tc.entryPoint,
+				},
+				Libraries: []compute.Library{
+					{Whl: "./dist/*.whl"},
+				},
+			},
+		}, nil)
+
+		diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+		if tc.found {
+			assert.Empty(t, diags, tc.entryPoint)
+			continue
+		}
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "entry point missing not found in my_package-0.1.0-py3-none-any.whl", diags[0].Summary)
+		assert.Equal(t, "resources.jobs.job.tasks[0].python_wheel_task.entry_point", diags[0].Path.String())
+	}
+}
+
+func TestValidateDependenciesEntryPointOtherPackage(t *testing.T) {
+	root := t.TempDir()
+	writeWheel(t, filepath.Join(root, "dist", "other-0.1.0-py3-none-any.whl"), map[string]string{
+		"other-0.1.0.dist-info/METADATA": "Metadata-Version: 2.1\nName: other\nVersion: 0.1.0\n",
+	})
+
+	// The package is not provided by a local wheel, so the entry point can't be checked.
+	b := dependenciesBundle(root, []jobs.Task{
+		{
+			TaskKey: "task",
+			PythonWheelTask: &jobs.PythonWheelTask{
+				PackageName: "my_package",
+				EntryPoint:  "missing",
+			},
+			Libraries: []compute.Library{
+				{Whl: "./dist/*.whl"},
+			},
+		},
+	}, nil)
+
+	diags := bundle.Apply(context.Background(), b, ValidateDependencies())
+	assert.Empty(t, diags)
+}
@@ -0,0 +1,37 @@
+package libraries
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IsVolumesPath returns true if the specified path indicates that
+// it should be interpreted as a path in a Unity Catalog volume.
+//
+// The following paths are considered volume paths:
+//
+// - /Volumes/main/default/libraries/myfile.whl
+//
+// The following paths are not considered volume paths:
+//
+// - /Workspace/Users/jane@doe.com/myfile
+// - dbfs:/Volumes/main/default/libraries/myfile.whl
+// - ./Volumes/myfile.whl
+func IsVolumesPath(path string) bool {
+	return strings.HasPrefix(path, "/Volumes/")
+}
+
+// VolumeFullName returns the three-level name of the volume that contains the specified path.
+// For example, the path /Volumes/main/default/libraries/myfile.whl is in volume main.default.libraries.
+func VolumeFullName(path string) (string, error) {
+	if !IsVolumesPath(path) {
+		return "", fmt.Errorf("%s is not a volume path", path)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(path, "/Volumes/"), "/", 4)
+	if len(parts) < 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", fmt.Errorf("expected a path of the form /Volumes/<catalog>/<schema>/<volume>/..., got %s", path)
+	}
+
+	return strings.Join(parts[:3], "."), nil
+}
@@ -0,0 +1,33 @@
+package libraries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVolumesPath(t *testing.T) {
+	assert.True(t, IsVolumesPath("/Volumes/main/default/libraries/myfile.whl"))
+
+	assert.False(t, IsVolumesPath("/Workspace/Users/jane@doe.com/myfile"))
+	assert.False(t, IsVolumesPath("dbfs:/Volumes/main/default/libraries/myfile.whl"))
+	assert.False(t, IsVolumesPath("./Volumes/myfile.whl"))
+	assert.False(t, IsVolumesPath("/Volumes"))
+}
+
+func TestVolumeFullName(t *testing.T) {
+	name, err := VolumeFullName("/Volumes/main/default/libraries/myfile.whl")
+	require.NoError(t, err)
+	assert.Equal(t, "main.default.libraries", name)
+
+	name, err = VolumeFullName("/Volumes/main/default/libraries")
+	require.NoError(t, err)
+	assert.Equal(t, "main.default.libraries", name)
+
+	_, err = VolumeFullName("/Volumes/main/default")
+	assert.ErrorContains(t, err, "expected a path of the form /Volumes/<catalog>/<schema>/<volume>/...")
+
+	_, err = VolumeFullName("/Workspace/Users/jane@doe.com")
+	assert.ErrorContains(t, err, "is not a volume path")
+}
@@ -0,0 +1,140 @@
+package libraries
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// wheelMetadata holds the metadata of a wheel that is used to validate Python wheel tasks.
+type wheelMetadata struct {
+	// Normalized name of the distribution.
+	name string
+
+	// Names of the entry points across all groups.
+	entryPoints map[string]bool
+
+	// Source of the top level modules by their name, for modules that are packages
+	// (module/__init__.py) as well as single file modules (module.py).
+	modules map[string]string
+}
+
+// readWheelMetadata reads the metadata of the wheel at the specified path.
+func readWheelMetadata(path string) (*wheelMetadata, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	out := &wheelMetadata{
+		entryPoints: make(map[string]bool),
+		modules:     make(map[string]string),
+	}
+
+	for _, f := range r.File {
+		var module string
+		switch {
+		case strings.HasSuffix(f.Name, ".dist-info/METADATA"):
+			raw, err := readZipEntry(f)
+			if err != nil {
+				return nil, err
+			}
+			out.name = normalizePackageName(metadataHeader(raw, "Name"))
+			continue
+		case strings.HasSuffix(f.Name, ".dist-info/entry_points.txt"):
+			raw, err := readZipEntry(f)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range entryPointNames(raw) {
+				out.entryPoints[name] = true
+			}
+			continue
+		case strings.Count(f.Name, "/") == 1 && strings.HasSuffix(f.Name, "/__init__.py"):
+			module = strings.TrimSuffix(f.Name, "/__init__.py")
+		case !strings.Contains(f.Name, "/") && strings.HasSuffix(f.Name, ".py"):
+			module = strings.TrimSuffix(f.Name, ".py")
+		default:
+			continue
+		}
+
+		raw, err := readZipEntry(f)
+		if err != nil {
+			return nil, err
+		}
+		out.modules[module] = raw
+	}
+
+	if out.name == "" {
+		return nil, fmt.Errorf("METADATA not found")
+	}
+	return out, nil
+}
+
+// hasEntryPoint returns true if the entry point of a Python wheel task for the package can be resolved.
+// Entry points that are not defined in the wheel's metadata are called as functions
+// of the package's top level module, so those are checked as well.
+func (w *wheelMetadata) hasEntryPoint(packageName, entryPoint string) bool {
+	if w.entryPoints[entryPoint] {
+		return true
+	}
+
+	source, ok := w.modules[strings.ReplaceAll(packageName, "-", "_")]
+	if !ok {
+		return false
+	}
+
+	// The function may be defined in the module or imported into it.
+	identifier := regexp.MustCompile(`\b` + regexp.QuoteMeta(entryPoint) + `\b`)
+	return identifier.MatchString(source)
+}
+
+func readZipEntry(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	raw, err := io.ReadAll(rc)
+	return string(raw), err
+}
+
+// metadataHeader returns the value of a header in a METADATA file.
+func metadataHeader(raw, name string) string {
+	s := bufio.NewScanner(strings.NewReader(raw))
+	for s.Scan() {
+		line := s.Text()
+		// Headers end at the first empty line.
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(key, name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// entryPointNames returns the names of the entry points in an entry_points.txt file.
+//
+// See https://packaging.python.org/en/latest/specifications/entry-points/#file-format.
+func entryPointNames(raw string) []string {
+	var out []string
+	s := bufio.NewScanner(strings.NewReader(raw))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		name, _, ok := strings.Cut(line, "=")
+		if ok {
+			out = append(out, strings.TrimSpace(name))
+		}
+	}
+	return out
+}
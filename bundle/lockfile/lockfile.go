@@ -0,0 +1,100 @@
+// Package lockfile reads and writes the bundle lockfile.
+//
+// The lockfile is stored next to the bundle's root configuration file and
+// records the inputs of a bundle that are not captured by its configuration,
+// such that they can be verified on subsequent invocations.
+package lockfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const FileName = "databricks.lock"
+const Version = 1
+
+// Include records a remote include and the hash of the files it resolved to.
+type Include struct {
+	// URL of the Git repository.
+	Url string `json:"url"`
+
+	// Reference (branch or tag) that was checked out.
+	Ref string `json:"ref"`
+
+	// Path (or glob pattern) of the included files in the repository.
+	Path string `json:"path"`
+
+	// Hash of the names and contents of the included files.
+	Hash string `json:"hash"`
+}
+
+// Cli records the version of the CLI that wrote the lockfile and the range
+// of versions that is allowed to deploy the bundle.
+type Cli struct {
+	Version    string `json:"version"`
+	Constraint string `json:"constraint"`
+}
+
+// Terraform records the versions of Terraform and the Databricks provider.
+type Terraform struct {
+	Version         string            `json:"version"`
+	Checksums       map[string]string `json:"checksums,omitempty"`
+	ProviderSource  string            `json:"provider_source"`
+	ProviderVersion string            `json:"provider_version"`
+}
+
+// ArtifactFile records the hash of a single artifact file.
+type ArtifactFile struct {
+	// Path relative to the bundle root.
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+type Lockfile struct {
+	// Version is the version of the lockfile format.
+	// To be incremented when the schema changes.
+	Version int `json:"version"`
+
+	Cli       *Cli                      `json:"cli,omitempty"`
+	Terraform *Terraform                `json:"terraform,omitempty"`
+	Artifacts map[string][]ArtifactFile `json:"artifacts,omitempty"`
+
+	// Includes maps remote include entries to what they resolved to.
+	Includes map[string]Include `json:"includes,omitempty"`
+}
+
+// Load reads the lockfile from the bundle root directory.
+// It returns an empty lockfile if the file doesn't exist.
+func Load(root string) (*Lockfile, error) {
+	raw, err := os.ReadFile(filepath.Join(root, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Lockfile{Version: Version}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var l Lockfile
+	err = json.Unmarshal(raw, &l)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if l.Version != Version {
+		return nil, fmt.Errorf("unsupported %s version %d; expected %d", FileName, l.Version, Version)
+	}
+	return &l, nil
+}
+
+// Save writes the lockfile to the bundle root directory.
+func (l *Lockfile) Save(root string) error {
+	l.Version = Version
+	raw, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(root, FileName), append(raw, '\n'), 0644)
+}
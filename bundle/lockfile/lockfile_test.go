@@ -0,0 +1,45 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadNotExists(t *testing.T) {
+	l, err := Load(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, Version, l.Version)
+	assert.Empty(t, l.Includes)
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	l := &Lockfile{
+		Includes: map[string]Include{
+			"git::https://example.com/repo.git//a.yml@v1": {
+				Url:  "https://example.com/repo.git",
+				Ref:  "v1",
+				Path: "a.yml",
+				Hash: "sha256:abc",
+			},
+		},
+	}
+	require.NoError(t, l.Save(dir))
+
+	out, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, l, out)
+}
+
+func TestLoadUnsupportedVersion(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, FileName), []byte(`{"version": 42}`), 0644)
+	require.NoError(t, err)
+
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "unsupported databricks.lock version 42")
+}
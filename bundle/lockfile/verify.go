@@ -0,0 +1,252 @@
+package lockfile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/deploy/terraform"
+	"github.com/databricks/cli/internal/build"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/diag"
+	"golang.org/x/exp/maps"
+)
+
+type verify struct{}
+
+// Verify checks that the deployment matches the versions and hashes recorded
+// in the bundle lockfile. Sections that are not present in the lockfile are not checked.
+// Mismatches are errors in production mode and warnings otherwise.
+//
+// If the deployment is configured to update the lockfile, it writes
+// the lockfile with the current versions and hashes instead.
+func Verify() bundle.Mutator {
+	return &verify{}
+}
+
+func (m *verify) Name() string {
+	return "lockfile.Verify"
+}
+
+func (m *verify) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	locked, err := Load(b.RootPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := compute(b, locked)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if b.Config.Bundle.Deployment.UpdateLockfile {
+		err = current.Save(b.RootPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		cmdio.LogString(ctx, fmt.Sprintf("Updated %s", FileName))
+		return nil
+	}
+
+	severity := diag.Warning
+	if b.Config.Bundle.Mode == config.Production {
+		severity = diag.Error
+	}
+
+	var diags diag.Diagnostics
+	for _, msg := range locked.drift(b, current) {
+		diags = diags.Append(diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("%s: %s", FileName, msg),
+			Detail:   fmt.Sprintf("Run \"databricks bundle deploy --update-lock\" to record the current versions and hashes in %s.", FileName),
+			ID:       config.LockfileDrift,
+		})
+	}
+	for _, msg := range locked.unverified(b, current) {
+		diags = diags.Append(diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s: %s", FileName, msg),
+			ID:       config.LockfileNotVerified,
+		})
+	}
+	return diags
+}
+
+// compute returns a lockfile that reflects the current deployment.
+// Remote includes are retained from the existing lockfile because they are
+// verified when the configuration is loaded.
+func compute(b *bundle.Bundle, locked *Lockfile) (*Lockfile, error) {
+	version := build.GetInfo().Version
+
+	// Use the constraint from the configuration if specified, and the
+	// current minor version otherwise.
+	constraint := b.Config.Bundle.DatabricksCliVersion
+	if constraint == "" {
+		constraint = version
+		if v, err := semver.NewVersion(version); err == nil {
+			constraint = fmt.Sprintf("%d.%d.*", v.Major(), v.Minor())
+		}
+	}
+
+	tf := terraform.NewTerraformMetadata()
+	out := &Lockfile{
+		Version: Version,
+		Cli: &Cli{
+			Version:    version,
+			Constraint: constraint,
+		},
+		Terraform: &Terraform{
+			Version: tf.Version,
+			// The CLI only carries checksums of the Terraform archives for these platforms.
+			Checksums: map[string]string{
+				"linux_amd64": tf.Checksum.LinuxAmd64,
+				"linux_arm64": tf.Checksum.LinuxArm64,
+			},
+			ProviderSource:  tf.ProviderSource,
+			ProviderVersion: tf.ProviderVersion,
+		},
+		Includes: locked.Includes,
+	}
+
+	for name, artifact := range b.Config.Artifacts {
+		// Artifacts that are built as part of the deployment are not reproducible
+		// byte for byte (e.g. wheels include timestamps), so we only record
+		// artifacts that are provided as is.
+		if artifact.BuildCommand != "" {
+			continue
+		}
+		for _, file := range artifact.Files {
+			hash, err := hashFile(file.Source)
+			if err != nil {
+				return nil, fmt.Errorf("unable to hash artifact %s: %w", name, err)
+			}
+			rel, err := filepath.Rel(b.RootPath, file.Source)
+			if err != nil {
+				rel = file.Source
+			}
+			if out.Artifacts == nil {
+				out.Artifacts = make(map[string][]ArtifactFile)
+			}
+			out.Artifacts[name] = append(out.Artifacts[name], ArtifactFile{
+				Path: filepath.ToSlash(rel),
+				Hash: hash,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+// drift returns a description of every difference between the
+// sections that are recorded in this lockfile and the current deployment.
+func (l *Lockfile) drift(b *bundle.Bundle, current *Lockfile) []string {
+	var out []string
+
+	if l.Cli != nil {
+		c, err := semver.NewConstraint(l.Cli.Constraint)
+		if err != nil {
+			out = append(out, fmt.Sprintf("invalid CLI version constraint %q", l.Cli.Constraint))
+		} else if v, err := semver.NewVersion(current.Cli.Version); err != nil || !c.Check(v) {
+			out = append(out, fmt.Sprintf("CLI version %s does not satisfy %s", current.Cli.Version, l.Cli.Constraint))
+		}
+	}
+
+	if l.Terraform != nil {
+		if l.Terraform.Version != current.Terraform.Version {
+			out = append(out, fmt.Sprintf("Terraform version %s does not match %s", current.Terraform.Version, l.Terraform.Version))
+		}
+		for _, platform := range sortedKeys(l.Terraform.Checksums) {
+			if l.Terraform.Checksums[platform] != current.Terraform.Checksums[platform] {
+				out = append(out, fmt.Sprintf("Terraform checksum for %s does not match", platform))
+			}
+		}
+		if l.Terraform.ProviderSource != current.Terraform.ProviderSource || l.Terraform.ProviderVersion != current.Terraform.ProviderVersion {
+			out = append(out, fmt.Sprintf(
+				"Terraform provider %s %s does not match %s %s",
+				current.Terraform.ProviderSource,
+				current.Terraform.ProviderVersion,
+				l.Terraform.ProviderSource,
+				l.Terraform.ProviderVersion,
+			))
+		}
+	}
+
+	for _, name := range sortedKeys(l.Artifacts) {
+		artifact, ok := b.Config.Artifacts[name]
+		if !ok {
+			out = append(out, fmt.Sprintf("artifact %s is no longer defined", name))
+			continue
+		}
+
+		// Built artifacts are reported by [Lockfile.unverified].
+		if artifact.BuildCommand != "" {
+			continue
+		}
+
+		if !slices.Equal(l.Artifacts[name], current.Artifacts[name]) {
+			out = append(out, fmt.Sprintf("hashes of artifact %s do not match", name))
+		}
+	}
+
+	return out
+}
+
+// unverified returns a description of every input of the current deployment
+// that cannot be verified against this lockfile.
+//
+// The Terraform checksum is only reported for platforms that the CLI has a checksum
+// for. On other platforms, only the Terraform version can be verified.
+func (l *Lockfile) unverified(b *bundle.Bundle, current *Lockfile) []string {
+	var out []string
+
+	// Nothing is verified if the bundle doesn't have a lockfile.
+	if l.Cli == nil && l.Terraform == nil && l.Artifacts == nil {
+		return nil
+	}
+
+	if l.Terraform != nil && len(l.Terraform.Checksums) > 0 {
+		platform := runtime.GOOS + "_" + runtime.GOARCH
+		_, known := current.Terraform.Checksums[platform]
+		if _, ok := l.Terraform.Checksums[platform]; known && !ok {
+			out = append(out, fmt.Sprintf("no Terraform checksum is recorded for %s", platform))
+		}
+	}
+
+	for _, name := range sortedKeys(b.Config.Artifacts) {
+		if b.Config.Artifacts[name].BuildCommand != "" {
+			out = append(out, fmt.Sprintf("artifact %s is built during deployment and cannot be verified", name))
+		}
+	}
+
+	return out
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package lockfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/internal/build"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBundle(t *testing.T, version string, dir string, mode config.Mode, update bool) *bundle.Bundle {
+	t.Cleanup(func() {
+		build.SetBuildVersion(build.DefaultSemver)
+	})
+	build.SetBuildVersion(version)
+
+	jar := filepath.Join(dir, "lib.jar")
+	require.NoError(t, os.WriteFile(jar, []byte("jar"), 0644))
+
+	return &bundle.Bundle{
+		RootPath: dir,
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Mode: mode,
+				Deployment: config.Deployment{
+					UpdateLockfile: update,
+				},
+			},
+			Artifacts: config.Artifacts{
+				"lib": &config.Artifact{
+					Files: []config.ArtifactFile{{Source: jar}},
+				},
+			},
+		},
+	}
+}
+
+func TestVerifyUpdateLockfile(t *testing.T) {
+	dir := t.TempDir()
+	b := setupBundle(t, "0.220.1", dir, "", true)
+
+	diags := bundle.Apply(context.Background(), b, Verify())
+	require.NoError(t, diags.Error())
+
+	l, err := Load(b.RootPath)
+	require.NoError(t, err)
+	assert.Equal(t, "0.220.1", l.Cli.Version)
+	assert.Equal(t, "0.220.*", l.Cli.Constraint)
+	assert.NotEmpty(t, l.Terraform.Version)
+	assert.Len(t, l.Artifacts["lib"], 1)
+	assert.Equal(t, "lib.jar", l.Artifacts["lib"][0].Path)
+
+	// Verification against the lockfile that was just written passes.
+	b = setupBundle(t, "0.220.9", dir, config.Production, false)
+	diags = bundle.Apply(context.Background(), b, Verify())
+	assert.Empty(t, diags)
+}
+
+func TestUnverifiedTerraformChecksum(t *testing.T) {
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	b := &bundle.Bundle{}
+	locked := &Lockfile{
+		Terraform: &Terraform{
+			Checksums: map[string]string{"other_platform": "sha"},
+		},
+	}
+
+	// The checksum is reported if the CLI has a checksum for the platform.
+	current := &Lockfile{
+		Terraform: &Terraform{
+			Checksums: map[string]string{platform: "sha"},
+		},
+	}
+	assert.Equal(t, []string{"no Terraform checksum is recorded for " + platform}, locked.unverified(b, current))
+
+	// It is not reported for platforms that the CLI doesn't have a checksum for.
+	current.Terraform.Checksums = map[string]string{}
+	assert.Empty(t, locked.unverified(b, current))
+}
+
+func TestVerifyBuiltArtifact(t *testing.T) {
+	dir := t.TempDir()
+	b := setupBundle(t, "0.220.1", dir, "", true)
+	b.Config.Artifacts["lib"].BuildCommand = "make"
+
+	diags := bundle.Apply(context.Background(), b, Verify())
+	require.NoError(t, diags.Error())
+
+	// Built artifacts are not recorded.
+	l, err := Load(b.RootPath)
+	require.NoError(t, err)
+	assert.Empty(t, l.Artifacts)
+
+	// Verification reports that they cannot be verified.
+	b = setupBundle(t, "0.220.1", dir, config.Production, false)
+	b.Config.Artifacts["lib"].BuildCommand = "make"
+	diags = bundle.Apply(context.Background(), b, Verify())
+	require.NoError(t, diags.Error())
+	var found bool
+	for _, d := range diags {
+		if d.Summary == "databricks.lock: artifact lib is built during deployment and cannot be verified" {
+			assert.Equal(t, diag.Warning, d.Severity)
+			assert.Equal(t, config.LockfileNotVerified, d.ID)
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestVerifyWithoutLockfile(t *testing.T) {
+	b := setupBundle(t, "0.220.1", t.TempDir(), "", false)
+	diags := bundle.Apply(context.Background(), b, Verify())
+	assert.Empty(t, diags)
+}
+
+func TestVerifyDrift(t *testing.T) {
+	dir := t.TempDir()
+	b := setupBundle(t, "0.221.0", dir, "", false)
+
+	l := &Lockfile{
+		Cli: &Cli{Version: "0.220.1", Constraint: "0.220.*"},
+		Artifacts: map[string][]ArtifactFile{
+			"lib": {{Path: "lib.jar", Hash: "sha256:abc"}},
+		},
+	}
+	require.NoError(t, l.Save(b.RootPath))
+
+	diags := bundle.Apply(context.Background(), b, Verify())
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "databricks.lock: CLI version 0.221.0 does not satisfy 0.220.*", diags[0].Summary)
+	assert.Equal(t, "databricks.lock: hashes of artifact lib do not match", diags[1].Summary)
+
+	// Drift is an error in production mode.
+	b = setupBundle(t, "0.221.0", dir, config.Production, false)
+	diags = bundle.Apply(context.Background(), b, Verify())
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+}
@@ -0,0 +1,190 @@
+package lsp
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/jsonschema"
+)
+
+// Names at the root of references.
+var referenceRoots = []string{"bundle", "workspace", "var", "variables", "resources"}
+
+// completion completes references inside "${...}" and keys in the configuration.
+func (s *Server) completion(params TextDocumentPositionParams) (*CompletionList, error) {
+	list := &CompletionList{Items: []CompletionItem{}}
+	if s.schema == nil {
+		return list, nil
+	}
+
+	uri := params.TextDocument.URI
+	line := s.line(uri, params.Position.Line)
+	before := line[:byteOffset(line, params.Position.Character)]
+
+	// Complete references if the cursor is inside "${".
+	if i := strings.LastIndex(before, "${"); i >= 0 && !strings.Contains(before[i:], "}") {
+		list.Items = s.completeReference(before[i+2:])
+		return list, nil
+	}
+
+	text := s.docs[uri]
+	path := yamlPath(strings.Split(text, "\n"), params.Position.Line, len(before))
+	list.Items = completeKeys(schemaAt(s.schema, path))
+	return list, nil
+}
+
+func (s *Server) completeReference(partial string) []CompletionItem {
+	items := []CompletionItem{}
+	parts := strings.Split(partial, ".")
+	parent := parts[:len(parts)-1]
+
+	if len(parent) == 0 {
+		for _, name := range referenceRoots {
+			items = append(items, CompletionItem{Label: name, Kind: completionKindField})
+		}
+		return items
+	}
+
+	if s.index == nil {
+		return items
+	}
+
+	p, err := referencePath(strings.Join(parent, "."))
+	if err != nil {
+		return items
+	}
+
+	var names []string
+	e, ok := s.index.byPath[p.String()]
+	if ok {
+		if m, ok := e.value.AsMap(); ok {
+			for _, k := range m.Keys() {
+				names = append(names, k.MustString())
+			}
+		}
+	}
+
+	// Variables are referenced by name only.
+	if parent[0] == "var" {
+		for _, name := range names {
+			items = append(items, CompletionItem{Label: name, Kind: completionKindVariable, Detail: s.variableDescription(name)})
+		}
+		return items
+	}
+
+	// Fields that are not set can still be referenced if they are in the schema.
+	if sc := schemaAt(s.schema, schemaPath(p)); sc != nil {
+		for name := range sc.Properties {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	// Deployed resources can be referenced by their ID.
+	if len(p) == 3 && p[0].Key() == "resources" && !slices.Contains(names, "id") {
+		names = append(names, "id")
+	}
+
+	slices.Sort(names)
+	for _, name := range names {
+		items = append(items, CompletionItem{Label: name, Kind: completionKindField})
+	}
+	return items
+}
+
+func (s *Server) variableDescription(name string) string {
+	e, ok := s.index.byPath[dyn.NewPath(dyn.Key("variables"), dyn.Key(name), dyn.Key("description")).String()]
+	if !ok {
+		return ""
+	}
+	description, _ := e.value.AsString()
+	return description
+}
+
+func completeKeys(sc *jsonschema.Schema) []CompletionItem {
+	items := []CompletionItem{}
+	if sc == nil {
+		return items
+	}
+
+	names := make([]string, 0, len(sc.Properties))
+	for name := range sc.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		item := CompletionItem{
+			Label:      name,
+			Kind:       completionKindProperty,
+			InsertText: name + ": ",
+		}
+		if description := sc.Properties[name].Description; description != "" {
+			item.Documentation = &MarkupContent{Kind: "markdown", Value: description}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+var yamlKeyRegex = regexp.MustCompile(`^([^\s:#][^:#]*):(\s*(#.*)?)?$`)
+
+// yamlPath returns the path of the mapping that a key at the specified line
+// and column in a YAML document belongs to. It is derived from the indentation
+// of the preceding lines, so that it works for documents that don't parse yet.
+// Sequence items are represented as "[*]".
+func yamlPath(lines []string, line int, column int) []string {
+	var path []string
+	threshold := column
+
+	if line < len(lines) {
+		indent, dashes, _ := yamlLine(lines[line])
+		if indent < column {
+			threshold = indent
+		}
+		if dashes >= 0 && dashes < column {
+			path = append(path, "[*]")
+			threshold = dashes
+		}
+	}
+
+	for i := min(line, len(lines)) - 1; i >= 0 && threshold > 0; i-- {
+		indent, dashes, content := yamlLine(lines[i])
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+
+		// Only keys without a value on the same line can be parents.
+		if indent < threshold {
+			if m := yamlKeyRegex.FindStringSubmatch(content); m != nil {
+				path = append([]string{strings.TrimSpace(m[1])}, path...)
+			}
+			threshold = indent
+		}
+		if dashes >= 0 && dashes < threshold {
+			path = append([]string{"[*]"}, path...)
+			threshold = dashes
+		}
+	}
+	return path
+}
+
+// yamlLine returns the indentation of the content of a line, the indentation
+// of the first sequence item marker ("- ") on the line or -1 if there is none,
+// and the content itself.
+func yamlLine(line string) (int, int, string) {
+	line = strings.TrimRight(line, "\r")
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	dashes := -1
+	for strings.HasPrefix(line[indent:], "- ") || line[indent:] == "-" {
+		if dashes < 0 {
+			dashes = indent
+		}
+		rest := strings.TrimPrefix(line[indent:], "-")
+		indent = len(line) - len(strings.TrimLeft(rest, " "))
+	}
+	return indent, dashes, strings.TrimSpace(line[indent:])
+}
@@ -0,0 +1,44 @@
+package lsp
+
+// definition returns the location where the value a reference points to is defined.
+// This can be in any of the files included in the bundle.
+func (s *Server) definition(params TextDocumentPositionParams) ([]Location, error) {
+	if s.index == nil {
+		return nil, nil
+	}
+
+	line := s.line(params.TextDocument.URI, params.Position.Line)
+	ref, _, _, ok := referenceAt(line, byteOffset(line, params.Position.Character))
+	if !ok {
+		return nil, nil
+	}
+
+	p, err := referencePath(ref)
+	if err != nil {
+		return nil, nil
+	}
+
+	// References such as ${resources.jobs.foo.id} point to values that are
+	// not in the configuration, in which case we navigate to the closest parent.
+	e, found := s.index.lookup(p)
+
+	// Resources may also be defined only in target overrides.
+	var entries []entry
+	if len(p) > 0 && p[0].Key() == "resources" && (!found || len(e.path) < min(len(p), 3)) {
+		for i := len(p); i > len(e.path) && len(entries) == 0; i-- {
+			entries = s.index.targetOverrides(p[:i])
+		}
+	}
+	if len(entries) == 0 && found {
+		entries = append(entries, e)
+	}
+
+	var out []Location
+	for _, e := range entries {
+		out = append(out, Location{
+			URI:   pathToURI(e.key.Location().File),
+			Range: s.keyRange(e),
+		})
+	}
+	return out, nil
+}
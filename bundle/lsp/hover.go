@@ -0,0 +1,85 @@
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// hover describes the key or the reference at the position.
+// Keys are described by their schema, references by their definition.
+func (s *Server) hover(params TextDocumentPositionParams) (*Hover, error) {
+	if s.index == nil {
+		return nil, nil
+	}
+
+	uri := params.TextDocument.URI
+	line := s.line(uri, params.Position.Line)
+	offset := byteOffset(line, params.Position.Character)
+	if ref, start, end, ok := referenceAt(line, offset); ok {
+		p, err := referencePath(ref)
+		if err != nil {
+			return nil, nil
+		}
+		r := lineRange(params.Position.Line, line, start, end)
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: s.describeReference(ref, p)},
+			Range:    &r,
+		}, nil
+	}
+
+	e, ok := s.index.keyAt(uriToPath(uri), params.Position.Line, line, offset)
+	if !ok {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "`%s`", e.path)
+	if sc := schemaAt(s.schema, schemaPath(e.path)); sc != nil && sc.Description != "" {
+		fmt.Fprintf(&b, "\n\n%s", sc.Description)
+	}
+	r := s.keyRange(e)
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: b.String()},
+		Range:    &r,
+	}, nil
+}
+
+func (s *Server) describeReference(ref string, p dyn.Path) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "`${%s}`", ref)
+
+	// Variables are described by their own description, everything else by the schema.
+	description := ""
+	if len(p) >= 2 && p[0].Key() == "variables" {
+		if e, ok := s.index.byPath[p[:2].Append(dyn.Key("description")).String()]; ok {
+			description, _ = e.value.AsString()
+		}
+		if e, ok := s.index.byPath[p[:2].Append(dyn.Key("default")).String()]; ok {
+			if v, ok := e.value.AsString(); ok {
+				fmt.Fprintf(&b, " (default: `%s`)", v)
+			}
+		}
+	} else if sc := schemaAt(s.schema, schemaPath(p)); sc != nil {
+		description = sc.Description
+	}
+	if description != "" {
+		fmt.Fprintf(&b, "\n\n%s", description)
+	}
+
+	e, ok := s.index.lookup(p)
+	if !ok {
+		fmt.Fprintf(&b, "\n\n`%s` is not defined.", p)
+		return b.String()
+	}
+
+	loc := e.key.Location()
+	file := loc.File
+	if rel, err := filepath.Rel(s.root, file); err == nil {
+		file = filepath.ToSlash(rel)
+	}
+	fmt.Fprintf(&b, "\n\nDefined at `%s` in %s:%d.", e.path, file, loc.Line)
+	return b.String()
+}
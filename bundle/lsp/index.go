@@ -0,0 +1,179 @@
+package lsp
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/databricks/cli/libs/dyn/dynvar"
+	"github.com/databricks/cli/libs/jsonschema"
+)
+
+// entry is a key in the configuration along with the value it maps to.
+type entry struct {
+	path  dyn.Path
+	key   dyn.Value
+	value dyn.Value
+}
+
+// keyOffsets returns the byte offsets of the start and end of the key in its line.
+func (e entry) keyOffsets(line string) (int, int) {
+	s, _ := e.key.AsString()
+	start := columnOffset(line, e.key.Location().Column-1)
+	return start, start + len(s)
+}
+
+// index records the location of every key in the configuration,
+// so that positions in files can be mapped to configuration paths and back.
+type index struct {
+	entries []entry
+	byPath  map[string]entry
+	files   []string
+}
+
+func newIndex(v dyn.Value) *index {
+	idx := &index{
+		byPath: make(map[string]entry),
+	}
+	idx.add(dyn.EmptyPath, v)
+	return idx
+}
+
+func (idx *index) add(p dyn.Path, v dyn.Value) {
+	idx.addFile(v.Location().File)
+
+	switch v.Kind() {
+	case dyn.KindMap:
+		m := v.MustMap()
+		for _, pair := range m.Pairs() {
+			k, ok := pair.Key.AsString()
+			if !ok {
+				continue
+			}
+			np := p.Append(dyn.Key(k))
+			e := entry{path: np, key: pair.Key, value: pair.Value}
+			idx.entries = append(idx.entries, e)
+			idx.byPath[np.String()] = e
+			idx.addFile(pair.Key.Location().File)
+			idx.add(np, pair.Value)
+		}
+	case dyn.KindSequence:
+		for i, item := range v.MustSequence() {
+			idx.add(p.Append(dyn.Index(i)), item)
+		}
+	}
+}
+
+func (idx *index) addFile(file string) {
+	if file != "" && !slices.Contains(idx.files, file) {
+		idx.files = append(idx.files, file)
+	}
+}
+
+// keyAt returns the entry whose key is at the byte offset in the specified zero-based line of the file.
+func (idx *index) keyAt(file string, i int, line string, offset int) (entry, bool) {
+	for _, e := range idx.entries {
+		loc := e.key.Location()
+		if loc.File != file || loc.Line-1 != i {
+			continue
+		}
+		start, end := e.keyOffsets(line)
+		if start <= offset && offset <= end {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+// lookup returns the entry for the longest prefix of the path that is defined.
+func (idx *index) lookup(p dyn.Path) (entry, bool) {
+	for i := len(p); i > 0; i-- {
+		if e, ok := idx.byPath[p[:i].String()]; ok {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+// targetOverrides returns the entries for the path in the target overrides.
+// For example, for "resources.jobs.foo" it returns "targets.*.resources.jobs.foo".
+func (idx *index) targetOverrides(p dyn.Path) []entry {
+	var out []entry
+	for _, e := range idx.entries {
+		if len(e.path) == len(p)+2 && e.path[0].Key() == "targets" && e.path[2:].String() == p.String() {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+var referenceRegex = regexp.MustCompile(dynvar.VariableRegex)
+
+// referenceAt returns the reference (without "${" and "}") at the byte
+// offset in the line, along with the byte offsets of the range it spans.
+func referenceAt(line string, offset int) (string, int, int, bool) {
+	for _, m := range referenceRegex.FindAllStringSubmatchIndex(line, -1) {
+		if m[0] <= offset && offset <= m[1] {
+			return line[m[2]:m[3]], m[0], m[1], true
+		}
+	}
+	return "", 0, 0, false
+}
+
+// referencePath returns the configuration path that a reference points to.
+// References to variables of the form "var.foo" point to "variables.foo".
+func referencePath(ref string) (dyn.Path, error) {
+	p, err := dyn.NewPathFromString(ref)
+	if err != nil {
+		return nil, err
+	}
+	if len(p) > 0 && p[0].Key() == "var" {
+		p = dyn.NewPath(dyn.Key("variables")).Append(p[1:]...)
+	}
+	return p, nil
+}
+
+// schemaAt returns the schema for the value at the specified path.
+// Indices in the path are represented as "[*]".
+func schemaAt(s *jsonschema.Schema, p []string) *jsonschema.Schema {
+	for _, c := range p {
+		if s == nil {
+			return nil
+		}
+		// Values that can be specified in multiple ways (e.g. variable overrides)
+		// use the first alternative that has nested values.
+		for _, as := range s.AnyOf {
+			if as.Properties != nil || as.Items != nil || as.AdditionalProperties != nil {
+				s = as
+				break
+			}
+		}
+		if c == "[*]" {
+			s = s.Items
+			continue
+		}
+		if ns, ok := s.Properties[c]; ok {
+			s = ns
+			continue
+		}
+		ns, ok := s.AdditionalProperties.(*jsonschema.Schema)
+		if !ok {
+			return nil
+		}
+		s = ns
+	}
+	return s
+}
+
+// schemaPath converts a configuration path to the form accepted by [schemaAt].
+func schemaPath(p dyn.Path) []string {
+	out := make([]string, 0, len(p))
+	for _, c := range p {
+		if c.Key() != "" {
+			out = append(out, c.Key())
+		} else {
+			out = append(out, "[*]")
+		}
+	}
+	return out
+}
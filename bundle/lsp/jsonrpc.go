@@ -0,0 +1,125 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeRequestFailed  = -32803
+)
+
+// message is a JSON-RPC 2.0 request, notification or response.
+// Requests and responses have an ID, notifications don't.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *responseError) Error() string {
+	return e.Message
+}
+
+// conn reads and writes messages framed with a Content-Length header, as used by LSP.
+type conn struct {
+	r *bufio.Reader
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		r: bufio.NewReader(r),
+		w: w,
+	}
+}
+
+func (c *conn) read() (*message, error) {
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %q", header.Get("Content-Length"))
+	}
+
+	body := make([]byte, length)
+	_, err = io.ReadFull(c.r, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg message
+	err = json.Unmarshal(body, &msg)
+	if err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return &msg, nil
+}
+
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+// reply sends the response to a request. A nil result is sent as null.
+func (c *conn) reply(id json.RawMessage, result any, err error) error {
+	msg := &message{ID: id}
+	if err != nil {
+		rerr, ok := err.(*responseError)
+		if !ok {
+			rerr = &responseError{Code: codeRequestFailed, Message: err.Error()}
+		}
+		msg.Error = rerr
+		return c.write(msg)
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	msg.Result = raw
+	return c.write(msg)
+}
+
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: raw})
+}
@@ -0,0 +1,60 @@
+package lsp
+
+import "unicode/utf8"
+
+// Characters in protocol positions are UTF-16 code units, while the server works with
+// byte offsets in lines and locations in the configuration count characters.
+// The functions below convert between them for a single line.
+
+// byteOffset returns the byte offset in the line of a position in UTF-16 code units.
+// Positions past the end of the line map to the end of the line.
+func byteOffset(line string, character int) int {
+	n := 0
+	for i, r := range line {
+		if n >= character {
+			return i
+		}
+		n += utf16Len(r)
+	}
+	return len(line)
+}
+
+// utf16Offset returns the position in UTF-16 code units of a byte offset in the line.
+func utf16Offset(line string, offset int) int {
+	n := 0
+	for i, r := range line {
+		if i >= offset {
+			break
+		}
+		n += utf16Len(r)
+	}
+	return n
+}
+
+// utf16Len returns the number of UTF-16 code units that encode the rune.
+// Runes outside the Basic Multilingual Plane are encoded as a surrogate pair.
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// columnOffset returns the byte offset in the line of a zero-based column
+// that counts characters, as in the locations of configuration values.
+func columnOffset(line string, column int) int {
+	offset := 0
+	for i := 0; i < column && offset < len(line); i++ {
+		_, size := utf8.DecodeRuneInString(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// lineRange returns the range between two byte offsets in the specified line.
+func lineRange(i int, line string, start int, end int) Range {
+	return Range{
+		Start: Position{Line: i, Character: utf16Offset(line, start)},
+		End:   Position{Line: i, Character: utf16Offset(line, end)},
+	}
+}
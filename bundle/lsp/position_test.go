@@ -0,0 +1,25 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositionConversion(t *testing.T) {
+	// "é" is 2 bytes and 1 UTF-16 code unit, "😀" is 4 bytes and 2 UTF-16 code units.
+	line := `name: "é😀 ${var.x}"`
+
+	assert.Equal(t, 7, byteOffset(line, 7))
+	assert.Equal(t, 9, byteOffset(line, 8))
+	assert.Equal(t, 14, byteOffset(line, 11))
+	assert.Equal(t, len(line), byteOffset(line, 100))
+
+	assert.Equal(t, 7, utf16Offset(line, 7))
+	assert.Equal(t, 8, utf16Offset(line, 9))
+	assert.Equal(t, 11, utf16Offset(line, 14))
+
+	assert.Equal(t, 9, columnOffset(line, 8))
+	assert.Equal(t, 14, columnOffset(line, 10))
+	assert.Equal(t, len(line), columnOffset(line, 100))
+}
@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+var resourceKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// rename renames the key of a resource. It updates the definition of the resource,
+// its target overrides, and all references to it in the files of the bundle.
+func (s *Server) rename(params RenameParams) (*WorkspaceEdit, error) {
+	if s.index == nil {
+		return nil, fmt.Errorf("the bundle configuration could not be loaded")
+	}
+
+	if !resourceKeyRegex.MatchString(params.NewName) {
+		return nil, fmt.Errorf("%q is not a valid resource key", params.NewName)
+	}
+
+	p, ok := s.resourceAt(params.TextDocumentPositionParams)
+	if !ok {
+		return nil, fmt.Errorf("only resource keys can be renamed")
+	}
+
+	np := dyn.NewPath(p[0], p[1], dyn.Key(params.NewName))
+	if _, ok := s.index.byPath[np.String()]; ok || len(s.index.targetOverrides(np)) > 0 {
+		return nil, fmt.Errorf("resource %s already exists", np)
+	}
+
+	edit := &WorkspaceEdit{Changes: make(map[string][]TextEdit)}
+	add := func(file string, r Range) {
+		uri := pathToURI(file)
+		edit.Changes[uri] = append(edit.Changes[uri], TextEdit{Range: r, NewText: params.NewName})
+	}
+
+	// Rename the keys in the resource definition and target overrides.
+	entries := s.index.targetOverrides(p)
+	if e, ok := s.index.byPath[p.String()]; ok {
+		entries = append(entries, e)
+	}
+	for _, e := range entries {
+		add(e.key.Location().File, s.keyRange(e))
+	}
+
+	// Rename the key in references to the resource or to one of its fields.
+	re := regexp.MustCompile(`\$\{` + regexp.QuoteMeta(p.String()) + `[.\[}]`)
+	prefix := len("${") + len(p[0].Key()) + len(".") + len(p[1].Key()) + len(".")
+	for _, file := range s.index.files {
+		text, err := s.text(file)
+		if err != nil {
+			continue
+		}
+		for i, line := range strings.Split(text, "\n") {
+			for _, m := range re.FindAllStringIndex(line, -1) {
+				start := m[0] + prefix
+				add(file, lineRange(i, line, start, start+len(p[2].Key())))
+			}
+		}
+	}
+
+	return edit, nil
+}
+
+// resourceAt returns the path of the resource (resources.<type>.<key>) whose key
+// or reference is at the position.
+func (s *Server) resourceAt(params TextDocumentPositionParams) (dyn.Path, bool) {
+	line := s.line(params.TextDocument.URI, params.Position.Line)
+	offset := byteOffset(line, params.Position.Character)
+	if ref, _, _, ok := referenceAt(line, offset); ok {
+		p, err := referencePath(ref)
+		if err != nil || len(p) < 3 || p[0].Key() != "resources" {
+			return nil, false
+		}
+		return p[:3], true
+	}
+
+	e, ok := s.index.keyAt(uriToPath(params.TextDocument.URI), params.Position.Line, line, offset)
+	if !ok {
+		return nil, false
+	}
+
+	// Resources are defined at the root or overridden in a target.
+	p := e.path
+	if len(p) == 5 && p[0].Key() == "targets" {
+		p = p[2:]
+	}
+	if len(p) != 3 || p[0].Key() != "resources" {
+		return nil, false
+	}
+	return p, true
+}
@@ -0,0 +1,403 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/loader"
+	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/bundle/config/validate"
+	"github.com/databricks/cli/bundle/schema"
+	"github.com/databricks/cli/internal/build"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/jsonschema"
+	"github.com/databricks/cli/libs/log"
+)
+
+// Server is a language server for bundle configuration files.
+// It serves a single client over a connection and handles messages sequentially.
+type Server struct {
+	conn *conn
+
+	// Root path of the bundle.
+	root string
+
+	// Contents of documents that are open in the client, keyed by URI.
+	docs map[string]string
+
+	// Schema of the bundle configuration, including descriptions.
+	schema *jsonschema.Schema
+
+	// Index of the configuration as of the last time it was loaded.
+	index *index
+
+	// Files that diagnostics were last published for.
+	published map[string]bool
+
+	shutdown bool
+}
+
+func NewServer(r io.Reader, w io.Writer) *Server {
+	return &Server{
+		conn:      newConn(r, w),
+		docs:      make(map[string]string),
+		published: make(map[string]bool),
+	}
+}
+
+// Run serves requests until the client sends the exit notification or closes the connection.
+func (s *Server) Run(ctx context.Context) error {
+	for {
+		msg, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			var rerr *responseError
+			if errors.As(err, &rerr) {
+				log.Warnf(ctx, "Unable to parse message: %v", err)
+				continue
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(ctx, msg)
+
+		// Notifications don't have an ID and don't get a response.
+		if msg.ID == nil {
+			if err != nil {
+				log.Warnf(ctx, "Unable to handle %s: %v", msg.Method, err)
+			}
+			continue
+		}
+
+		err = s.conn.reply(msg.ID, result, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params)
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.refresh(ctx)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		// With full document sync the last change contains the full text.
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[params.TextDocument.URI] = params.ContentChanges[n-1].Text
+		}
+		return nil, nil
+	case "textDocument/didSave":
+		return nil, s.refresh(ctx)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params)
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params)
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params)
+	case "textDocument/rename":
+		var params RenameParams
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.rename(params)
+	}
+
+	if msg.ID == nil || strings.HasPrefix(msg.Method, "$/") {
+		// Unknown notifications are ignored.
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", msg.Method)}
+}
+
+func unmarshalParams(msg *message, v any) error {
+	err := json.Unmarshal(msg.Params, v)
+	if err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) initialize(params InitializeParams) (any, error) {
+	switch {
+	case len(params.WorkspaceFolders) > 0:
+		s.root = uriToPath(params.WorkspaceFolders[0].URI)
+	case params.RootURI != "":
+		s.root = uriToPath(params.RootURI)
+	case params.RootPath != "":
+		s.root = params.RootPath
+	default:
+		root, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		s.root = root
+	}
+
+	docs, err := schema.LoadBundleDescriptions()
+	if err != nil {
+		return nil, err
+	}
+	s.schema, err = schema.New(reflect.TypeOf(config.Root{}), docs)
+	if err != nil {
+		return nil, err
+	}
+	err = schema.OverrideConditions(s.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    syncKindFull,
+				Save:      SaveOptions{IncludeText: false},
+			},
+			HoverProvider: true,
+			CompletionProvider: CompletionOptions{
+				TriggerCharacters: []string{".", "{"},
+			},
+			DefinitionProvider: true,
+			RenameProvider:     true,
+		},
+		ServerInfo: ServerInfo{
+			Name:    "databricks-bundle",
+			Version: build.GetInfo().Version,
+		},
+	}, nil
+}
+
+// load loads the bundle configuration from disk, including all included files,
+// and runs the validations that don't require access to the workspace.
+func (s *Server) load(ctx context.Context) (*bundle.Bundle, diag.Diagnostics) {
+	// Remote includes are only read from the cache, because fetching
+	// them and updating the lockfile on every change is too expensive.
+	ctx = loader.WithRemoteIncludeMode(ctx, loader.RemoteIncludesOffline)
+
+	b, err := bundle.Load(ctx, s.root)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// Scripts and Python code are not executed because the
+	// configuration is loaded every time a file is saved.
+	diags := bundle.Apply(ctx, b, bundle.Seq(
+		loader.EntryPoint(),
+		loader.ProcessRootIncludes(),
+		mutator.EnvironmentsToTargets(),
+		mutator.InitializeVariables(),
+		mutator.DefineDefaultTarget(),
+	))
+	if diags.HasError() {
+		return b, diags
+	}
+
+	diags = diags.Extend(b.OverrideDiagnostics(bundle.ApplyReadOnly(ctx, bundle.ReadOnly(b), bundle.Parallel(
+		validate.JobClusterKeyDefined(),
+		validate.JobTaskGraph(),
+		validate.References(),
+		validate.ValidateSyncPatterns(),
+		validate.PolicyRules(),
+		validate.DiagnosticOverrides(),
+	))))
+	return b, diags
+}
+
+// refresh reloads the configuration and publishes diagnostics for all files.
+func (s *Server) refresh(ctx context.Context) error {
+	if s.root == "" {
+		return nil
+	}
+
+	b, diags := s.load(ctx)
+	if b != nil {
+		s.index = newIndex(b.Config.Value())
+	}
+
+	// Diagnostics without a location are reported on the main configuration file.
+	mainFile := filepath.Join(s.root, "databricks.yml")
+	if b != nil {
+		if file, err := config.FileNames.FindInPath(s.root); err == nil {
+			mainFile = file
+		}
+	}
+
+	byFile := make(map[string][]Diagnostic)
+	for _, d := range diags {
+		file := d.Location.File
+		if file == "" {
+			file = mainFile
+		}
+		byFile[file] = append(byFile[file], toDiagnostic(d, s.line(pathToURI(file), d.Location.Line-1)))
+	}
+
+	// Clear diagnostics for files that no longer have any.
+	for file := range s.published {
+		if _, ok := byFile[file]; !ok {
+			byFile[file] = []Diagnostic{}
+		}
+	}
+
+	s.published = make(map[string]bool)
+	for file, ds := range byFile {
+		if len(ds) > 0 {
+			s.published[file] = true
+		}
+		err := s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         pathToURI(file),
+			Diagnostics: ds,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toDiagnostic converts a diagnostic to the protocol.
+// The line is the line of the file that the diagnostic is located at.
+func toDiagnostic(d diag.Diagnostic, line string) Diagnostic {
+	message := d.Summary
+	if d.Detail != "" {
+		message += "\n\n" + d.Detail
+	}
+
+	severity := severityInformation
+	switch d.Severity {
+	case diag.Error:
+		severity = severityError
+	case diag.Warning:
+		severity = severityWarning
+	}
+
+	// Locations are 1-based, positions are 0-based.
+	// The diagnostic spans from the location to the end of the line.
+	var r Range
+	if d.Location.Line > 0 {
+		offset := columnOffset(line, max(d.Location.Column-1, 0))
+		start := Position{Line: d.Location.Line - 1, Character: utf16Offset(line, offset)}
+		r = Range{Start: start, End: Position{Line: start.Line + 1, Character: 0}}
+	}
+
+	return Diagnostic{
+		Range:    r,
+		Severity: severity,
+		Code:     string(d.ID),
+		Source:   "databricks",
+		Message:  message,
+	}
+}
+
+// text returns the contents of a file, preferring the contents in the client
+// if the file is open.
+func (s *Server) text(file string) (string, error) {
+	if text, ok := s.docs[pathToURI(file)]; ok {
+		return text, nil
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// keyRange returns the range of the key of the entry in its file.
+func (s *Server) keyRange(e entry) Range {
+	loc := e.key.Location()
+	line := s.line(pathToURI(loc.File), loc.Line-1)
+	start, end := e.keyOffsets(line)
+	return lineRange(loc.Line-1, line, start, end)
+}
+
+// line returns the specified zero-based line of a document.
+func (s *Server) line(uri string, line int) string {
+	text, ok := s.docs[uri]
+	if !ok {
+		var err error
+		text, err = s.text(uriToPath(uri))
+		if err != nil {
+			return ""
+		}
+	}
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.FromSlash(path)
+}
+
+func pathToURI(path string) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
@@ -0,0 +1,347 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRootConfig = `bundle:
+  name: test
+
+include:
+  - resources/*.yml
+
+variables:
+  cluster_id:
+    description: The cluster to run on
+
+resources:
+  jobs:
+    my_job:
+      name: My job
+      tasks:
+        - task_key: main
+          existing_cluster_id: ${var.cluster_id}
+          notebook_task:
+            notebook_path: ./notebook.py
+
+targets:
+  dev:
+    resources:
+      jobs:
+        my_job:
+          name: My dev job
+`
+
+const testIncludedConfig = `resources:
+  pipelines:
+    my_pipeline:
+      name: ${resources.jobs.my_job.name} pipeline
+  jobs:
+    other_job:
+      name: other
+      tasks:
+        - task_key: a
+          depends_on:
+            - task_key: missing
+          pipeline_task:
+            pipeline_id: ${resources.pipelines.my_pipeline.id}
+`
+
+type testClient struct {
+	t     *testing.T
+	conn  *conn
+	msgs  chan *message
+	id    int
+	notes []*message
+}
+
+func setupTest(t *testing.T) (*testClient, string) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "resources"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "databricks.yml"), []byte(testRootConfig), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "resources", "pipeline.yml"), []byte(testIncludedConfig), 0644))
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	server := NewServer(inR, outW)
+	done := make(chan error)
+	go func() {
+		done <- server.Run(context.Background())
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		assert.NoError(t, <-done)
+	})
+
+	// Messages from the server are read concurrently, like an editor would,
+	// so that the server never blocks on writing notifications.
+	c := &testClient{t: t, conn: newConn(outR, inW), msgs: make(chan *message, 100)}
+	go func() {
+		defer close(c.msgs)
+		for {
+			msg, err := c.conn.read()
+			if err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+
+	c.request("initialize", InitializeParams{RootURI: pathToURI(root)}, nil)
+	c.notify("initialized", struct{}{})
+	return c, root
+}
+
+func (c *testClient) notify(method string, params any) {
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+func (c *testClient) request(method string, params any, result any) *responseError {
+	c.id++
+	id := json.RawMessage(strconv.Itoa(c.id))
+	raw, err := json.Marshal(params)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.conn.write(&message{ID: id, Method: method, Params: raw}))
+
+	for msg := range c.msgs {
+		if msg.ID == nil {
+			c.notes = append(c.notes, msg)
+			continue
+		}
+		require.Equal(c.t, string(id), string(msg.ID))
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result != nil {
+			require.NoError(c.t, json.Unmarshal(msg.Result, result))
+		}
+		return nil
+	}
+	require.FailNow(c.t, "connection closed")
+	return nil
+}
+
+func (c *testClient) open(path string) string {
+	raw, err := os.ReadFile(path)
+	require.NoError(c.t, err)
+	uri := pathToURI(path)
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "yaml", Text: string(raw)},
+	})
+	return uri
+}
+
+func position(uri string, line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func TestServerInitialize(t *testing.T) {
+	c, _ := setupTest(t)
+
+	var result InitializeResult
+	rerr := c.request("initialize", InitializeParams{}, &result)
+	require.Nil(t, rerr)
+	assert.True(t, result.Capabilities.HoverProvider)
+	assert.True(t, result.Capabilities.RenameProvider)
+	assert.Equal(t, syncKindFull, result.Capabilities.TextDocumentSync.Change)
+
+	rerr = c.request("workspace/symbol", struct{}{}, nil)
+	require.NotNil(t, rerr)
+	assert.Equal(t, codeMethodNotFound, rerr.Code)
+}
+
+func TestServerPublishDiagnostics(t *testing.T) {
+	c, root := setupTest(t)
+	c.open(filepath.Join(root, "databricks.yml"))
+	c.request("shutdown", nil, nil)
+
+	byURI := make(map[string]PublishDiagnosticsParams)
+	for _, n := range c.notes {
+		require.Equal(t, "textDocument/publishDiagnostics", n.Method)
+		var params PublishDiagnosticsParams
+		require.NoError(t, json.Unmarshal(n.Params, &params))
+		byURI[params.URI] = params
+	}
+
+	params, ok := byURI[pathToURI(filepath.Join(root, "resources", "pipeline.yml"))]
+	require.True(t, ok)
+	require.Len(t, params.Diagnostics, 1)
+	d := params.Diagnostics[0]
+	assert.Equal(t, severityError, d.Severity)
+	assert.Contains(t, d.Message, "missing")
+	assert.Equal(t, Position{Line: 10, Character: 24}, d.Range.Start)
+}
+
+func TestServerHover(t *testing.T) {
+	c, root := setupTest(t)
+	uri := c.open(filepath.Join(root, "databricks.yml"))
+
+	// Key.
+	var hover Hover
+	require.Nil(t, c.request("textDocument/hover", position(uri, 14, 7), &hover))
+	assert.Contains(t, hover.Contents.Value, "`resources.jobs.my_job.tasks`")
+	assert.Equal(t, Range{Start: Position{Line: 14, Character: 6}, End: Position{Line: 14, Character: 11}}, *hover.Range)
+
+	// Reference to a variable.
+	hover = Hover{}
+	require.Nil(t, c.request("textDocument/hover", position(uri, 16, 35), &hover))
+	assert.Contains(t, hover.Contents.Value, "`${var.cluster_id}`")
+	assert.Contains(t, hover.Contents.Value, "The cluster to run on")
+	assert.Contains(t, hover.Contents.Value, "databricks.yml:8")
+}
+
+func TestServerDefinition(t *testing.T) {
+	c, root := setupTest(t)
+	uri := c.open(filepath.Join(root, "resources", "pipeline.yml"))
+
+	// Reference to the name of a job that is defined in another file.
+	var locations []Location
+	require.Nil(t, c.request("textDocument/definition", position(uri, 3, 25), &locations))
+	require.Len(t, locations, 1)
+	assert.Equal(t, pathToURI(filepath.Join(root, "databricks.yml")), locations[0].URI)
+	assert.Equal(t, Position{Line: 13, Character: 6}, locations[0].Range.Start)
+
+	// Reference to a field that is not in the configuration navigates to the resource.
+	locations = nil
+	require.Nil(t, c.request("textDocument/definition", position(uri, 12, 30), &locations))
+	require.Len(t, locations, 1)
+	assert.Equal(t, uri, locations[0].URI)
+	assert.Equal(t, Position{Line: 2, Character: 4}, locations[0].Range.Start)
+}
+
+func TestServerRename(t *testing.T) {
+	c, root := setupTest(t)
+	uri := c.open(filepath.Join(root, "databricks.yml"))
+
+	var edit WorkspaceEdit
+	rerr := c.request("textDocument/rename", RenameParams{
+		TextDocumentPositionParams: position(uri, 12, 6),
+		NewName:                    "renamed_job",
+	}, &edit)
+	require.Nil(t, rerr)
+
+	// Definition and target override.
+	assert.ElementsMatch(t, []TextEdit{
+		{Range: Range{Start: Position{Line: 12, Character: 4}, End: Position{Line: 12, Character: 10}}, NewText: "renamed_job"},
+		{Range: Range{Start: Position{Line: 24, Character: 8}, End: Position{Line: 24, Character: 14}}, NewText: "renamed_job"},
+	}, edit.Changes[uri])
+
+	// Reference in the included file.
+	assert.Equal(t, []TextEdit{
+		{Range: Range{Start: Position{Line: 3, Character: 29}, End: Position{Line: 3, Character: 35}}, NewText: "renamed_job"},
+	}, edit.Changes[pathToURI(filepath.Join(root, "resources", "pipeline.yml"))])
+
+	// Conflicting and invalid names.
+	rerr = c.request("textDocument/rename", RenameParams{
+		TextDocumentPositionParams: position(uri, 12, 6),
+		NewName:                    "other_job",
+	}, nil)
+	require.NotNil(t, rerr)
+	assert.Contains(t, rerr.Message, "resources.jobs.other_job already exists")
+
+	rerr = c.request("textDocument/rename", RenameParams{
+		TextDocumentPositionParams: position(uri, 12, 6),
+		NewName:                    "my job",
+	}, nil)
+	require.NotNil(t, rerr)
+	assert.Contains(t, rerr.Message, "not a valid resource key")
+
+	// Keys that are not resource keys.
+	rerr = c.request("textDocument/rename", RenameParams{
+		TextDocumentPositionParams: position(uri, 14, 7),
+		NewName:                    "foo",
+	}, nil)
+	require.NotNil(t, rerr)
+	assert.Contains(t, rerr.Message, "only resource keys can be renamed")
+}
+
+func TestServerCompletion(t *testing.T) {
+	c, root := setupTest(t)
+	uri := c.open(filepath.Join(root, "databricks.yml"))
+
+	labels := func(list CompletionList) []string {
+		var out []string
+		for _, item := range list.Items {
+			out = append(out, item.Label)
+		}
+		return out
+	}
+
+	// Replace the reference to the cluster ID with a partial reference.
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		ContentChanges: []TextDocumentContentChangeEvent{{
+			Text: strings.Replace(testRootConfig, "${var.cluster_id}", "${var.", 1),
+		}},
+	})
+
+	var list CompletionList
+	require.Nil(t, c.request("textDocument/completion", position(uri, 16, 33), &list))
+	assert.Equal(t, []string{"bundle", "workspace", "var", "variables", "resources"}, labels(list))
+
+	list = CompletionList{}
+	require.Nil(t, c.request("textDocument/completion", position(uri, 16, 37), &list))
+	assert.Equal(t, []string{"cluster_id"}, labels(list))
+	assert.Equal(t, "The cluster to run on", list.Items[0].Detail)
+
+	// Keys of a task in a job.
+	list = CompletionList{}
+	require.Nil(t, c.request("textDocument/completion", position(uri, 17, 10), &list))
+	assert.Contains(t, labels(list), "notebook_task")
+	assert.Contains(t, labels(list), "depends_on")
+	assert.NotContains(t, labels(list), "notebook_path")
+
+	// Keys of a notebook task.
+	list = CompletionList{}
+	require.Nil(t, c.request("textDocument/completion", position(uri, 18, 12), &list))
+	assert.Contains(t, labels(list), "notebook_path")
+	assert.Contains(t, labels(list), "base_parameters")
+}
+
+func TestYamlPath(t *testing.T) {
+	lines := []string{
+		"resources:",
+		"  jobs:",
+		"    my_job:",
+		"      tasks:",
+		"        - task_key: main",
+		"          notebook_task:",
+		"            notebook_path: foo",
+		"        - ",
+		"    ",
+	}
+
+	assert.Empty(t, yamlPath(lines, 0, 0))
+	assert.Equal(t, []string{"resources", "jobs", "my_job"}, yamlPath(lines, 3, 6))
+	assert.Equal(t, []string{"resources", "jobs", "my_job", "tasks", "[*]"}, yamlPath(lines, 5, 10))
+	assert.Equal(t, []string{"resources", "jobs", "my_job", "tasks", "[*]", "notebook_task"}, yamlPath(lines, 6, 12))
+	assert.Equal(t, []string{"resources", "jobs", "my_job", "tasks", "[*]"}, yamlPath(lines, 7, 10))
+	assert.Equal(t, []string{"resources", "jobs"}, yamlPath(lines, 8, 4))
+}
+
+func TestServerHoverNonASCII(t *testing.T) {
+	c, root := setupTest(t)
+	path := filepath.Join(root, "resources", "unicode.yml")
+	require.NoError(t, os.WriteFile(path, []byte("resources:\n  jobs:\n    my_job:\n      description: \"😀 ${var.cluster_id}\"\n"), 0644))
+	uri := c.open(path)
+
+	// The emoji is 2 UTF-16 code units, so the reference starts at character 23.
+	var hover Hover
+	require.Nil(t, c.request("textDocument/hover", position(uri, 3, 24), &hover))
+	assert.Contains(t, hover.Contents.Value, "`${var.cluster_id}`")
+	assert.Equal(t, Range{Start: Position{Line: 3, Character: 23}, End: Position{Line: 3, Character: 40}}, *hover.Range)
+}
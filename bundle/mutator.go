@@ -37,6 +37,10 @@ func Apply(ctx context.Context, b *Bundle, m Mutator) diag.Diagnostics {
 
 	diags := m.Apply(ctx, b)
 
+	// Ignore or escalate diagnostics before checking for errors,
+	// so that escalated diagnostics stop further mutators from running.
+	diags = b.OverrideDiagnostics(diags)
+
 	// Log error in diagnostics if any.
 	// Note: errors should be logged when constructing them
 	// such that they are not logged multiple times.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/libs/diag"
 	"github.com/stretchr/testify/assert"
 )
@@ -43,3 +44,29 @@ func TestMutator(t *testing.T) {
 	assert.Equal(t, 1, nested[0].applyCalled)
 	assert.Equal(t, 1, nested[1].applyCalled)
 }
+
+func TestMutatorOverridesDiagnostics(t *testing.T) {
+	b := &Bundle{}
+	b.Config.Bundle.Diagnostics = []config.DiagnosticOverride{
+		{ID: "unused-variable", Severity: "ignore"},
+	}
+
+	warnings := func(context.Context, *Bundle) diag.Diagnostics {
+		return diag.Diagnostics{
+			{Severity: diag.Warning, Summary: "unused", ID: config.UnusedVariable},
+			{Severity: diag.Warning, Summary: "no match", ID: config.SyncPatternNoMatch},
+		}
+	}
+
+	diags := ApplyFunc(context.Background(), b, warnings)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "no match", diags[0].Summary)
+
+	// In strict mode, warnings that are not ignored are errors.
+	b.Strict = true
+	diags = ApplyFunc(context.Background(), b, warnings)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.EqualError(t, diags.Error(), "no match")
+}
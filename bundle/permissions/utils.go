@@ -2,7 +2,9 @@ package permissions
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/resources"
 	"github.com/databricks/cli/libs/diag"
 )
@@ -46,27 +48,29 @@ func isPermissionOverlap(
 	var diagnostics diag.Diagnostics
 	for _, rp := range resourcePermissions {
 		if rp.GroupName != "" && rp.GroupName == permission.GroupName {
-			diagnostics = diagnostics.Extend(
-				diag.Warningf("'%s' already has permissions set for '%s' group", resourceName, rp.GroupName),
-			)
+			diagnostics = diagnostics.Append(permissionOverlap("'%s' already has permissions set for '%s' group", resourceName, rp.GroupName))
 		}
 
 		if rp.UserName != "" && rp.UserName == permission.UserName {
-			diagnostics = diagnostics.Extend(
-				diag.Warningf("'%s' already has permissions set for '%s' user name", resourceName, rp.UserName),
-			)
+			diagnostics = diagnostics.Append(permissionOverlap("'%s' already has permissions set for '%s' user name", resourceName, rp.UserName))
 		}
 
 		if rp.ServicePrincipalName != "" && rp.ServicePrincipalName == permission.ServicePrincipalName {
-			diagnostics = diagnostics.Extend(
-				diag.Warningf("'%s' already has permissions set for '%s' service principal name", resourceName, rp.ServicePrincipalName),
-			)
+			diagnostics = diagnostics.Append(permissionOverlap("'%s' already has permissions set for '%s' service principal name", resourceName, rp.ServicePrincipalName))
 		}
 	}
 
 	return len(diagnostics) > 0, diagnostics
 }
 
+func permissionOverlap(format string, args ...any) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf(format, args...),
+		ID:       config.PermissionOverlap,
+	}
+}
+
 func notifyForPermissionOverlap(
 	ctx context.Context,
 	permission resources.Permission,
@@ -2,14 +2,17 @@ package phases
 
 import (
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deploy/lock"
 	"github.com/databricks/cli/bundle/deploy/terraform"
+	"github.com/databricks/cli/bundle/scripts"
 )
 
 func Bind(opts *terraform.BindOptions) bundle.Mutator {
 	return newPhase(
 		"bind",
 		[]bundle.Mutator{
+			scripts.Execute(config.ScriptPreBind),
 			lock.Acquire(),
 			bundle.Defer(
 				bundle.Seq(
@@ -21,6 +24,7 @@ func Bind(opts *terraform.BindOptions) bundle.Mutator {
 				),
 				lock.Release(lock.GoalBind),
 			),
+			scripts.Execute(config.ScriptPostBind),
 		},
 	)
 }
@@ -5,12 +5,14 @@ import (
 	"github.com/databricks/cli/bundle/artifacts"
 	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/bundle/config/validate"
 	"github.com/databricks/cli/bundle/deploy"
 	"github.com/databricks/cli/bundle/deploy/files"
 	"github.com/databricks/cli/bundle/deploy/lock"
 	"github.com/databricks/cli/bundle/deploy/metadata"
 	"github.com/databricks/cli/bundle/deploy/terraform"
 	"github.com/databricks/cli/bundle/libraries"
+	"github.com/databricks/cli/bundle/lockfile"
 	"github.com/databricks/cli/bundle/permissions"
 	"github.com/databricks/cli/bundle/python"
 	"github.com/databricks/cli/bundle/scripts"
@@ -20,6 +22,10 @@ import (
 func Deploy() bundle.Mutator {
 	deployMutator := bundle.Seq(
 		scripts.Execute(config.ScriptPreDeploy),
+		validate.ValidateDiagnosticOverrides(),
+		validate.ValidatePolicyRules(),
+		validate.ValidatePrincipals(),
+		lockfile.Verify(),
 		lock.Acquire(),
 		bundle.Defer(
 			bundle.Seq(
@@ -27,6 +33,8 @@ func Deploy() bundle.Mutator {
 				deploy.StatePull(),
 				mutator.ValidateGitDetails(),
 				libraries.ValidateLocalLibrariesExist(),
+				libraries.ValidateDependencies(),
+				artifacts.ValidateArtifactPath(),
 				artifacts.CleanUp(),
 				artifacts.UploadAll(),
 				python.TransformWheelTask(),
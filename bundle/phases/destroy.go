@@ -2,15 +2,18 @@ package phases
 
 import (
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deploy/files"
 	"github.com/databricks/cli/bundle/deploy/lock"
 	"github.com/databricks/cli/bundle/deploy/terraform"
+	"github.com/databricks/cli/bundle/scripts"
 )
 
 // The destroy phase deletes artifacts and resources.
 func Destroy() bundle.Mutator {
 
 	destroyMutator := bundle.Seq(
+		scripts.Execute(config.ScriptPreDestroy),
 		lock.Acquire(),
 		bundle.Defer(
 			bundle.Seq(
@@ -25,6 +28,7 @@ func Destroy() bundle.Mutator {
 			lock.Release(lock.GoalDestroy),
 		),
 		bundle.LogString("Destroy complete!"),
+		scripts.Execute(config.ScriptPostDestroy),
 	)
 
 	return newPhase(
@@ -23,6 +23,7 @@ func Initialize() bundle.Mutator {
 			mutator.MergeJobClusters(),
 			mutator.MergeJobTasks(),
 			mutator.MergePipelineClusters(),
+			mutator.ApplyDefinitions(),
 			mutator.InitializeWorkspaceClient(),
 			mutator.PopulateCurrentUser(),
 			mutator.DefineDefaultWorkspaceRoot(),
@@ -32,8 +33,12 @@ func Initialize() bundle.Mutator {
 			// Intentionally placed before ResolveVariableReferencesInLookup, ResolveResourceReferences
 			// and ResolveVariableReferences. See what is expected in PythonMutatorPhaseInit doc
 			pythonmutator.PythonMutator(pythonmutator.PythonMutatorPhaseInit),
+			pythonmutator.ConfigGenerators(pythonmutator.PythonMutatorPhaseInit),
 			mutator.ResolveVariableReferencesInLookup(),
 			mutator.ResolveResourceReferences(),
+			// Evaluate conditions before variable resolution so that they are
+			// parsed before the values of the variables they reference are substituted.
+			mutator.ApplyConditions(),
 			mutator.ResolveVariableReferences(
 				"bundle",
 				"workspace",
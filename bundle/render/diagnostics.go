@@ -0,0 +1,370 @@
+package render
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/databricks/cli/internal/build"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/folders"
+	"github.com/databricks/cli/libs/git"
+)
+
+// Formats in which diagnostics can be rendered for consumption by other tools.
+const (
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+var Formats = []string{FormatJSON, FormatSARIF, FormatJUnit}
+
+// Diagnostics writes the diagnostics to w in the specified format.
+//
+// Files in diagnostic locations are made relative to the root of the Git repository
+// that contains rootPath, or to rootPath if it isn't in a repository, so that tools
+// that annotate source files can match them to files in the repository.
+func Diagnostics(w io.Writer, format string, rootPath string, diags diag.Diagnostics) error {
+	diags = relativeLocations(repositoryRoot(rootPath), diags)
+
+	switch format {
+	case FormatJSON:
+		return renderJSON(w, diags)
+	case FormatSARIF:
+		return renderSARIF(w, diags)
+	case FormatJUnit:
+		return renderJUnit(w, diags)
+	default:
+		return fmt.Errorf("unknown diagnostics format %q, expected one of %v", format, Formats)
+	}
+}
+
+// repositoryRoot returns the root of the Git repository that contains
+// rootPath, or rootPath if it isn't in a repository.
+func repositoryRoot(rootPath string) string {
+	if rootPath == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(rootPath)
+	if err != nil {
+		return rootPath
+	}
+	root, err := folders.FindDirWithLeaf(abs, git.GitDirectoryName)
+	if err != nil {
+		return abs
+	}
+	return root
+}
+
+func relativeLocations(rootPath string, diags diag.Diagnostics) diag.Diagnostics {
+	out := make(diag.Diagnostics, len(diags))
+	for i, d := range diags {
+		if rootPath != "" && d.Location.File != "" && filepath.IsAbs(d.Location.File) {
+			if rel, err := filepath.Rel(rootPath, d.Location.File); err == nil && !strings.HasPrefix(rel, "..") {
+				d.Location.File = rel
+			}
+		}
+		if d.Location.File != "" {
+			d.Location.File = filepath.ToSlash(d.Location.File)
+		}
+		out[i] = d
+	}
+	return out
+}
+
+type jsonOutput struct {
+	Diagnostics []jsonDiagnostic `json:"diagnostics"`
+}
+
+type jsonDiagnostic struct {
+	ID       string        `json:"id,omitempty"`
+	Severity string        `json:"severity"`
+	Summary  string        `json:"summary"`
+	Detail   string        `json:"detail,omitempty"`
+	Path     string        `json:"path,omitempty"`
+	Location *jsonLocation `json:"location,omitempty"`
+}
+
+type jsonLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func renderJSON(w io.Writer, diags diag.Diagnostics) error {
+	out := jsonOutput{Diagnostics: []jsonDiagnostic{}}
+	for _, d := range diags {
+		jd := jsonDiagnostic{
+			ID:       string(d.ID),
+			Severity: d.Severity.String(),
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+			Path:     d.Path.String(),
+		}
+		if d.Location.File != "" {
+			jd.Location = &jsonLocation{
+				File:   d.Location.File,
+				Line:   d.Location.Line,
+				Column: d.Location.Column,
+			}
+		}
+		out.Diagnostics = append(out.Diagnostics, jd)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// The subset of SARIF 2.1.0 that is needed to report diagnostics.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// sarifRuleID returns the rule that a result is reported for.
+// Diagnostics without an ID are reported for a generic rule.
+func sarifRuleID(d diag.Diagnostic) string {
+	if d.ID == "" {
+		return "bundle-validate"
+	}
+	return string(d.ID)
+}
+
+func sarifLevel(s diag.Severity) string {
+	switch s {
+	case diag.Error:
+		return "error"
+	case diag.Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func renderSARIF(w io.Writer, diags diag.Diagnostics) error {
+	results := []sarifResult{}
+	rules := []sarifRule{}
+	for _, d := range diags {
+		id := sarifRuleID(d)
+		if !slices.ContainsFunc(rules, func(r sarifRule) bool { return r.ID == id }) {
+			rules = append(rules, sarifRule{ID: id})
+		}
+
+		text := d.Summary
+		if d.Detail != "" {
+			text += "\n\n" + d.Detail
+		}
+
+		result := sarifResult{
+			RuleID:  id,
+			Level:   sarifLevel(d.Severity),
+			Message: sarifMessage{Text: text},
+		}
+
+		var loc sarifLocation
+		if d.Location.File != "" {
+			loc.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       d.Location.File,
+					URIBaseID: "%SRCROOT%",
+				},
+			}
+			if d.Location.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   d.Location.Line,
+					StartColumn: d.Location.Column,
+				}
+			}
+		}
+		if len(d.Path) > 0 {
+			loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: d.Path.String()}}
+		}
+		if loc.PhysicalLocation != nil || loc.LogicalLocations != nil {
+			result.Locations = []sarifLocation{loc}
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           "databricks bundle validate",
+					InformationURI: "https://docs.databricks.com/dev-tools/bundles/index.html",
+					Version:        build.GetInfo().Version,
+					Rules:          rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnit reports every diagnostic as a test case. Errors are failed test cases,
+// while warnings and informational diagnostics are passed test cases with output.
+// If there are no diagnostics, a single passed test case is reported.
+func renderJUnit(w io.Writer, diags diag.Diagnostics) error {
+	suite := junitTestSuite{
+		Name:      "bundle validate",
+		TestCases: []junitTestCase{},
+	}
+
+	for _, d := range diags {
+		tc := junitTestCase{
+			Name:      d.Summary,
+			ClassName: "bundle.validate",
+			File:      d.Location.File,
+			Line:      d.Location.Line,
+		}
+		if len(d.Path) > 0 {
+			tc.ClassName = d.Path.String()
+		}
+
+		text := fmt.Sprintf("%s: %s", d.Severity, d.Summary)
+		if d.Detail != "" {
+			text += "\n\n" + d.Detail
+		}
+		if len(d.Path) > 0 {
+			text += "\n  at " + d.Path.String()
+		}
+		if d.Location.File != "" {
+			text += "\n  in " + d.Location.String()
+		}
+
+		if d.Severity == diag.Error {
+			tc.Failure = &junitFailure{Message: d.Summary, Type: d.Severity.String(), Text: text}
+			suite.Failures++
+		} else {
+			tc.SystemOut = text
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "Validation OK",
+			ClassName: "bundle.validate",
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	out := junitTestSuites{
+		Name:     "databricks bundle validate",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(out)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
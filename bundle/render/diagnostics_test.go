@@ -0,0 +1,169 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDiagnostics(root string) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "task a depends on task b, which doesn't exist",
+			Detail:   "Define task b or remove the dependency.",
+			Location: dyn.Location{File: filepath.Join(root, "resources", "job.yml"), Line: 12, Column: 13},
+			Path:     dyn.MustPathFromString("resources.jobs.job1.tasks[0].depends_on[0].task_key"),
+			ID:       config.TaskDependencyNotFound,
+		},
+		{
+			Severity: diag.Warning,
+			Summary:  "variable unused is never referenced",
+			Location: dyn.Location{File: filepath.Join(root, "databricks.yml"), Line: 5, Column: 5},
+			Path:     dyn.MustPathFromString("variables.unused"),
+			ID:       config.UnusedVariable,
+		},
+		{
+			Severity: diag.Info,
+			Summary:  "no location",
+		},
+	}
+}
+
+func TestDiagnosticsJSON(t *testing.T) {
+	root := t.TempDir()
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatJSON, root, testDiagnostics(root))
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, map[string]any{
+		"diagnostics": []any{
+			map[string]any{
+				"id":       "task-dependency-not-found",
+				"severity": "error",
+				"summary":  "task a depends on task b, which doesn't exist",
+				"detail":   "Define task b or remove the dependency.",
+				"path":     "resources.jobs.job1.tasks[0].depends_on[0].task_key",
+				"location": map[string]any{"file": "resources/job.yml", "line": float64(12), "column": float64(13)},
+			},
+			map[string]any{
+				"id":       "unused-variable",
+				"severity": "warning",
+				"summary":  "variable unused is never referenced",
+				"path":     "variables.unused",
+				"location": map[string]any{"file": "databricks.yml", "line": float64(5), "column": float64(5)},
+			},
+			map[string]any{
+				"severity": "info",
+				"summary":  "no location",
+			},
+		},
+	}, out)
+}
+
+func TestDiagnosticsJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatJSON, "", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"diagnostics": []}`, buf.String())
+}
+
+func TestDiagnosticsSARIF(t *testing.T) {
+	root := t.TempDir()
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatSARIF, root, testDiagnostics(root))
+	require.NoError(t, err)
+
+	var out sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "2.1.0", out.Version)
+	require.Len(t, out.Runs, 1)
+
+	results := out.Runs[0].Results
+	require.Len(t, results, 3)
+
+	assert.Equal(t, []sarifRule{{ID: "task-dependency-not-found"}, {ID: "unused-variable"}, {ID: "bundle-validate"}}, out.Runs[0].Tool.Driver.Rules)
+	assert.Equal(t, "task-dependency-not-found", results[0].RuleID)
+	assert.Equal(t, "error", results[0].Level)
+	assert.Equal(t, "task a depends on task b, which doesn't exist\n\nDefine task b or remove the dependency.", results[0].Message.Text)
+	require.Len(t, results[0].Locations, 1)
+	physical := results[0].Locations[0].PhysicalLocation
+	assert.Equal(t, "resources/job.yml", physical.ArtifactLocation.URI)
+	assert.Equal(t, "%SRCROOT%", physical.ArtifactLocation.URIBaseID)
+	assert.Equal(t, &sarifRegion{StartLine: 12, StartColumn: 13}, physical.Region)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].depends_on[0].task_key", results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	assert.Equal(t, "warning", results[1].Level)
+	assert.Equal(t, "note", results[2].Level)
+	assert.Empty(t, results[2].Locations)
+}
+
+func TestDiagnosticsJUnit(t *testing.T) {
+	root := t.TempDir()
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatJUnit, root, testDiagnostics(root))
+	require.NoError(t, err)
+
+	var out junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, 3, out.Tests)
+	assert.Equal(t, 1, out.Failures)
+	require.Len(t, out.Suites, 1)
+
+	cases := out.Suites[0].TestCases
+	require.Len(t, cases, 3)
+	assert.Equal(t, "task a depends on task b, which doesn't exist", cases[0].Name)
+	assert.Equal(t, "resources.jobs.job1.tasks[0].depends_on[0].task_key", cases[0].ClassName)
+	assert.Equal(t, "resources/job.yml", cases[0].File)
+	assert.Equal(t, 12, cases[0].Line)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "error", cases[0].Failure.Type)
+	assert.Contains(t, cases[0].Failure.Text, "in resources/job.yml:12:13")
+
+	assert.Nil(t, cases[1].Failure)
+	assert.Contains(t, cases[1].SystemOut, "warning: variable unused is never referenced")
+}
+
+func TestDiagnosticsJUnitEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatJUnit, "", nil)
+	require.NoError(t, err)
+
+	var out junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, 1, out.Tests)
+	assert.Equal(t, 0, out.Failures)
+}
+
+func TestDiagnosticsUnknownFormat(t *testing.T) {
+	err := Diagnostics(&bytes.Buffer{}, "yaml", "", nil)
+	assert.ErrorContains(t, err, `unknown diagnostics format "yaml"`)
+}
+
+func TestDiagnosticsRelativeToRepositoryRoot(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0755))
+	root := filepath.Join(repo, "bundles", "etl")
+
+	var buf bytes.Buffer
+	err := Diagnostics(&buf, FormatSARIF, root, testDiagnostics(root))
+	require.NoError(t, err)
+
+	var out sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Len(t, out.Runs, 1)
+	physical := out.Runs[0].Results[0].Locations[0].PhysicalLocation
+	assert.Equal(t, "bundles/etl/resources/job.yml", physical.ArtifactLocation.URI)
+	assert.Equal(t, "%SRCROOT%", physical.ArtifactLocation.URIBaseID)
+}
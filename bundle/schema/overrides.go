@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"github.com/databricks/cli/libs/jsonschema"
+)
+
+// OverrideConditions updates the schema to accept the fields that are rewritten
+// before the configuration is converted to its typed representation.
+//
+// Include entries can be specified as a path or as a map with a path and
+// a condition. Tasks can specify a condition and a list of definitions to apply.
+// Neither is represented in the typed configuration, so the generated schema
+// doesn't include them.
+func OverrideConditions(s *jsonschema.Schema) error {
+	include, err := s.GetByPath("include")
+	if err != nil {
+		return err
+	}
+	if include.Items != nil {
+		include.Items = &jsonschema.Schema{
+			AnyOf: []*jsonschema.Schema{
+				{
+					Type:        jsonschema.StringType,
+					Description: include.Items.Description,
+				},
+				{
+					Type:        jsonschema.ObjectType,
+					Description: include.Items.Description,
+					Properties: map[string]*jsonschema.Schema{
+						"path": {
+							Type:        jsonschema.StringType,
+							Description: "Path or glob pattern of the files to include.",
+						},
+						"condition": {
+							Type:        jsonschema.StringType,
+							Description: "Expression that determines if the resources defined in the files are deployed. Other sections of the files are always included.",
+						},
+					},
+					Required:             []string{"path"},
+					AdditionalProperties: false,
+				},
+			},
+		}
+	}
+	err = s.SetByPath("include", include)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range []string{"resources.jobs.*.tasks", "targets.*.resources.jobs.*.tasks"} {
+		tasks, err := s.GetByPath(path)
+		if err != nil {
+			return err
+		}
+		if tasks.Items == nil {
+			continue
+		}
+		task := *tasks.Items
+		properties := make(map[string]*jsonschema.Schema, len(task.Properties)+2)
+		for k, v := range task.Properties {
+			properties[k] = v
+		}
+		properties["condition"] = &jsonschema.Schema{
+			Type:        jsonschema.StringType,
+			Description: "Expression that determines if the task is included in the job.",
+		}
+		properties["apply"] = &jsonschema.Schema{
+			Type:        jsonschema.ArrayType,
+			Description: "Names of definitions to apply to the task.",
+			Items: &jsonschema.Schema{
+				Type: jsonschema.StringType,
+			},
+		}
+		task.Properties = properties
+		tasks.Items = &task
+		err = s.SetByPath(path, tasks)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
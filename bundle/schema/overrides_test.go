@@ -0,0 +1,34 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/libs/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverrideConditions(t *testing.T) {
+	s, err := New(reflect.TypeOf(config.Root{}), &Docs{})
+	require.NoError(t, err)
+
+	err = OverrideConditions(s)
+	require.NoError(t, err)
+
+	include, err := s.GetByPath("include")
+	require.NoError(t, err)
+	require.Len(t, include.Items.AnyOf, 2)
+	assert.Equal(t, jsonschema.StringType, include.Items.AnyOf[0].Type)
+	assert.Contains(t, include.Items.AnyOf[1].Properties, "path")
+	assert.Contains(t, include.Items.AnyOf[1].Properties, "condition")
+
+	for _, path := range []string{"resources.jobs.*.tasks", "targets.*.resources.jobs.*.tasks"} {
+		tasks, err := s.GetByPath(path)
+		require.NoError(t, err)
+		assert.Contains(t, tasks.Items.Properties, "task_key")
+		assert.Contains(t, tasks.Items.Properties, "condition")
+		assert.Contains(t, tasks.Items.Properties, "apply")
+	}
+}
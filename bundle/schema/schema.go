@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/databricks/cli/libs/dyn"
 	"github.com/databricks/cli/libs/dyn/dynvar"
 	"github.com/databricks/cli/libs/jsonschema"
 )
@@ -161,6 +162,10 @@ func toSchema(golangType reflect.Type, docs *Docs, tracker *tracker) (*jsonschem
 	if golangType.Kind() == reflect.Interface {
 		return &jsonschema.Schema{}, nil
 	}
+	// Dynamic values are opaque and can hold any value.
+	if golangType == reflect.TypeOf(dyn.Value{}) {
+		return &jsonschema.Schema{}, nil
+	}
 
 	rootJavascriptType, err := jsonSchemaType(golangType)
 	if err != nil {
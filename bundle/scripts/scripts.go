@@ -3,26 +3,58 @@ package scripts
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/env"
 	"github.com/databricks/cli/libs/cmdio"
 	"github.com/databricks/cli/libs/diag"
+	"github.com/databricks/cli/libs/dyn"
 	"github.com/databricks/cli/libs/exec"
 	"github.com/databricks/cli/libs/log"
 )
 
+// Environment variables that are set for scripts in addition to the bundle root and target.
+const (
+	// Name of the hook the script is executed for.
+	HookVariable = "DATABRICKS_BUNDLE_HOOK"
+
+	// Name of the bundle.
+	NameVariable = "DATABRICKS_BUNDLE_NAME"
+
+	// Key of the resource for the prerun and postrun hooks, for example "jobs.my_job".
+	ResourceKeyVariable = "DATABRICKS_BUNDLE_RESOURCE_KEY"
+
+	// Path to a JSON file with the IDs of the deployed resources by their type and key.
+	// It is only set if resources have been deployed.
+	ResourcesFileVariable = "DATABRICKS_BUNDLE_RESOURCES_FILE"
+)
+
 func Execute(hook config.ScriptHook) bundle.Mutator {
 	return &script{
 		scriptHook: hook,
 	}
 }
 
+// ExecuteForResource executes the script for a hook that applies to a single resource,
+// such as prerun and postrun. The key includes the resource type, for example "jobs.my_job".
+func ExecuteForResource(hook config.ScriptHook, key string) bundle.Mutator {
+	return &script{
+		scriptHook:  hook,
+		resourceKey: key,
+	}
+}
+
 type script struct {
-	scriptHook config.ScriptHook
+	scriptHook  config.ScriptHook
+	resourceKey string
 }
 
 func (m *script) Name() string {
@@ -30,22 +62,61 @@ func (m *script) Name() string {
 }
 
 func (m *script) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+	s, ok := getScript(b, m.scriptHook)
+	if !ok {
+		log.Debugf(ctx, "No script defined for %s, skipping", m.scriptHook)
+		return nil
+	}
+	if m.resourceKey != "" && !s.AppliesTo(m.resourceKey) {
+		log.Debugf(ctx, "Script for %s doesn't apply to %s, skipping", m.scriptHook, m.resourceKey)
+		return nil
+	}
+
+	p := dyn.NewPath(dyn.Key("scripts"), dyn.Key(string(m.scriptHook)))
+	timeout, err := s.TimeoutDuration()
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("script %s has an %v", m.scriptHook, err),
+			Location: b.Config.GetLocation(p.Append(dyn.Key("timeout")).String()),
+			Path:     p.Append(dyn.Key("timeout")),
+		}}
+	}
+
 	executor, err := exec.NewCommandExecutor(b.RootPath)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	cmd, out, err := executeHook(ctx, executor, b, m.scriptHook)
+	vars, err := scriptEnv(ctx, b, m.scriptHook, m.resourceKey)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if cmd == nil {
-		log.Debugf(ctx, "No script defined for %s, skipping", m.scriptHook)
-		return nil
+	executor.WithEnv(vars)
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd, out, err := executeHook(ctx, executor, b, m.scriptHook)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	cmdio.LogString(ctx, fmt.Sprintf("Executing '%s' script", m.scriptHook))
 
+	// Processes started by the script may outlive it when it is killed after the timeout
+	// and keep its output open. Close the output so that reading it doesn't block.
+	if timeout > 0 {
+		go func() {
+			<-ctx.Done()
+			cmd.Stdout().Close()
+			cmd.Stderr().Close()
+		}()
+	}
+
 	reader := bufio.NewReader(out)
 	line, err := reader.ReadString('\n')
 	for err == nil {
@@ -53,16 +124,33 @@ func (m *script) Apply(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
 		line, err = reader.ReadString('\n')
 	}
 
-	return diag.FromErr(cmd.Wait())
+	err = cmd.Wait()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("'%s' script timed out after %s", m.scriptHook, timeout)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if s.ContinueOnError {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("'%s' script failed: %v", m.scriptHook, err),
+			Detail:   "The failure is ignored because the script sets continue_on_error.",
+			Location: b.Config.GetLocation(p.String()),
+			Path:     p,
+		}}
+	}
+	return diag.FromErr(err)
 }
 
 func executeHook(ctx context.Context, executor *exec.Executor, b *bundle.Bundle, hook config.ScriptHook) (exec.Command, io.Reader, error) {
-	command := getCommmand(b, hook)
-	if command == "" {
+	s, ok := getScript(b, hook)
+	if !ok {
 		return nil, nil, nil
 	}
 
-	cmd, err := executor.StartCommand(ctx, string(command))
+	cmd, err := executor.StartCommand(ctx, string(s.Command))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,10 +158,88 @@ func executeHook(ctx context.Context, executor *exec.Executor, b *bundle.Bundle,
 	return cmd, io.MultiReader(cmd.Stdout(), cmd.Stderr()), nil
 }
 
-func getCommmand(b *bundle.Bundle, hook config.ScriptHook) config.Command {
-	if b.Config.Experimental == nil || b.Config.Experimental.Scripts == nil {
-		return ""
+// getScript returns the script for the hook. Scripts in the scripts section take
+// precedence over the deprecated scripts in the experimental section.
+func getScript(b *bundle.Bundle, hook config.ScriptHook) (config.Script, bool) {
+	if s, ok := b.Config.Scripts[hook]; ok && s.Command != "" {
+		return s, true
 	}
 
-	return b.Config.Experimental.Scripts[hook]
+	if b.Config.Experimental == nil || b.Config.Experimental.Scripts[hook] == "" {
+		return config.Script{}, false
+	}
+
+	return config.Script{Command: b.Config.Experimental.Scripts[hook]}, true
+}
+
+// scriptEnv returns the environment variables that pass the bundle context to a script.
+func scriptEnv(ctx context.Context, b *bundle.Bundle, hook config.ScriptHook, resourceKey string) (map[string]string, error) {
+	vars := map[string]string{
+		HookVariable:     string(hook),
+		env.RootVariable: b.RootPath,
+	}
+	if b.Config.Bundle.Name != "" {
+		vars[NameVariable] = b.Config.Bundle.Name
+	}
+	if resourceKey != "" {
+		vars[ResourceKeyVariable] = resourceKey
+	}
+
+	// Scripts for hooks that run before the target is selected don't receive target specific context.
+	if b.Config.Bundle.Target == "" {
+		return vars, nil
+	}
+	vars[env.TargetVariable] = b.Config.Bundle.Target
+
+	ids := resourceIDs(b)
+	if len(ids) == 0 {
+		return vars, nil
+	}
+
+	dir, err := b.CacheDir(ctx, "scripts")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.MarshalIndent(ids, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("resources-%s.json", hook))
+	err = os.WriteFile(path, raw, 0600)
+	if err != nil {
+		return nil, err
+	}
+	vars[ResourcesFileVariable] = path
+	return vars, nil
+}
+
+type resourceID struct {
+	ID string `json:"id"`
+}
+
+// resourceIDs returns the IDs of the deployed resources by their type and key.
+func resourceIDs(b *bundle.Bundle) map[string]map[string]resourceID {
+	out := make(map[string]map[string]resourceID)
+
+	types, ok := b.Config.Value().Get("resources").AsMap()
+	if !ok {
+		return out
+	}
+	for _, t := range types.Pairs() {
+		resources, ok := t.Value.AsMap()
+		if !ok {
+			continue
+		}
+		for _, r := range resources.Pairs() {
+			id, ok := r.Value.Get("id").AsString()
+			if !ok || id == "" {
+				continue
+			}
+			if out[t.Key.MustString()] == nil {
+				out[t.Key.MustString()] = make(map[string]resourceID)
+			}
+			out[t.Key.MustString()][r.Key.MustString()] = resourceID{ID: id}
+		}
+	}
+	return out
 }
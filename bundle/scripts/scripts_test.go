@@ -3,12 +3,18 @@ package scripts
 import (
 	"bufio"
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/databricks/cli/bundle"
 	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/resources"
+	"github.com/databricks/cli/bundle/env"
+	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/exec"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,3 +55,143 @@ func TestExecuteMutator(t *testing.T) {
 	diags := bundle.Apply(context.Background(), b, Execute(config.ScriptPreInit))
 	require.NoError(t, diags.Error())
 }
+
+func TestExecuteScriptTakesPrecedenceOverExperimental(t *testing.T) {
+	b := &bundle.Bundle{
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPreBuild: {Command: "echo 'Hello from scripts'"},
+			},
+			Experimental: &config.Experimental{
+				Scripts: map[config.ScriptHook]config.Command{
+					config.ScriptPreBuild: "echo 'Hello from experimental'",
+				},
+			},
+		},
+	}
+
+	s, ok := getScript(b, config.ScriptPreBuild)
+	require.True(t, ok)
+	assert.Equal(t, config.Command("echo 'Hello from scripts'"), s.Command)
+}
+
+func TestExecuteScriptFailure(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: t.TempDir(),
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPreDestroy: {Command: "exit 1"},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, Execute(config.ScriptPreDestroy))
+	require.Error(t, diags.Error())
+}
+
+func TestExecuteScriptContinueOnError(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: t.TempDir(),
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPostDestroy: {Command: "exit 1", ContinueOnError: true},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, Execute(config.ScriptPostDestroy))
+	require.NoError(t, diags.Error())
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "scripts.postdestroy", diags[0].Path.String())
+}
+
+func TestExecuteScriptTimeout(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: t.TempDir(),
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPreBind: {Command: "sleep 10", Timeout: "100ms"},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, Execute(config.ScriptPreBind))
+	require.ErrorContains(t, diags.Error(), "'prebind' script timed out after 100ms")
+}
+
+func TestExecuteScriptInvalidTimeout(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: t.TempDir(),
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPreBind: {Command: "echo 'Hello'", Timeout: "soon"},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, Execute(config.ScriptPreBind))
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "scripts.prebind.timeout", diags[0].Path.String())
+}
+
+func TestExecuteScriptForResource(t *testing.T) {
+	root := t.TempDir()
+	b := &bundle.Bundle{
+		RootPath: root,
+		Config: config.Root{
+			Scripts: map[config.ScriptHook]config.Script{
+				config.ScriptPreRun: {
+					Command:   "echo $DATABRICKS_BUNDLE_RESOURCE_KEY > key.txt",
+					Resources: []string{"jobs.my_job"},
+				},
+			},
+		},
+	}
+
+	diags := bundle.Apply(context.Background(), b, ExecuteForResource(config.ScriptPreRun, "jobs.other_job"))
+	require.NoError(t, diags.Error())
+	assert.NoFileExists(t, filepath.Join(root, "key.txt"))
+
+	diags = bundle.Apply(context.Background(), b, ExecuteForResource(config.ScriptPreRun, "jobs.my_job"))
+	require.NoError(t, diags.Error())
+	raw, err := os.ReadFile(filepath.Join(root, "key.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "jobs.my_job", strings.TrimSpace(string(raw)))
+}
+
+func TestScriptEnv(t *testing.T) {
+	b := &bundle.Bundle{
+		RootPath: t.TempDir(),
+		Config: config.Root{
+			Bundle: config.Bundle{
+				Name:   "my_bundle",
+				Target: "dev",
+			},
+			Resources: config.Resources{
+				Jobs: map[string]*resources.Job{
+					"my_job":    {ID: "1234"},
+					"other_job": {},
+				},
+			},
+		},
+	}
+
+	// Populate the dynamic configuration from the typed configuration.
+	bundle.ApplyFunc(context.Background(), b, func(ctx context.Context, b *bundle.Bundle) diag.Diagnostics {
+		return nil
+	})
+
+	vars, err := scriptEnv(context.Background(), b, config.ScriptPostDeploy, "")
+	require.NoError(t, err)
+	assert.Equal(t, "postdeploy", vars[HookVariable])
+	assert.Equal(t, b.RootPath, vars[env.RootVariable])
+	assert.Equal(t, "dev", vars[env.TargetVariable])
+	assert.Equal(t, "my_bundle", vars[NameVariable])
+	assert.NotContains(t, vars, ResourceKeyVariable)
+
+	raw, err := os.ReadFile(vars[ResourcesFileVariable])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jobs": {"my_job": {"id": "1234"}}}`, string(raw))
+}
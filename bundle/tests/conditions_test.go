@@ -0,0 +1,67 @@
+package config_tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTargetWithConditions(t *testing.T, path, target string) (*bundle.Bundle, diag.Diagnostics) {
+	b := loadTarget(t, path, target)
+	diags := bundle.Apply(context.Background(), b, bundle.Seq(
+		mutator.SetVariables(),
+		mutator.ApplyConditions(),
+		mutator.ResolveVariableReferences(
+			"bundle",
+			"variables",
+		),
+	))
+	require.NoError(t, diags.Error())
+	return b, diags
+}
+
+func TestConditionsDev(t *testing.T) {
+	b, diags := loadTargetWithConditions(t, "./conditions", "dev")
+
+	assert.Contains(t, b.Config.Resources.Jobs, "etl")
+	assert.Contains(t, b.Config.Resources.Jobs, "debug")
+	assert.NotContains(t, b.Config.Resources.Jobs, "backfill")
+
+	// The include condition also applies to resources defined in targets.
+	assert.NotContains(t, b.Config.Resources.Jobs, "backfill_dev")
+
+	tasks := b.Config.Resources.Jobs["etl"].Tasks
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "ingest", tasks[0].TaskKey)
+
+	var summaries []string
+	for _, d := range diags.Filter(diag.Info) {
+		summaries = append(summaries, d.Summary)
+	}
+	assert.ElementsMatch(t, []string{
+		`skipping jobs.backfill because its condition "${bundle.target} == prod" evaluated to false`,
+		`skipping jobs.backfill_dev because its condition "${bundle.target} == prod" evaluated to false`,
+		`skipping task notify because its condition "${var.notify}" evaluated to false`,
+	}, summaries)
+}
+
+func TestConditionsProd(t *testing.T) {
+	b, diags := loadTargetWithConditions(t, "./conditions", "prod")
+
+	assert.Contains(t, b.Config.Resources.Jobs, "etl")
+	assert.Contains(t, b.Config.Resources.Jobs, "backfill")
+	assert.NotContains(t, b.Config.Resources.Jobs, "debug")
+	assert.Len(t, b.Config.Resources.Jobs["etl"].Tasks, 2)
+	assert.Empty(t, b.Config.Resources.Jobs["etl"].TaskConditions)
+	assert.Empty(t, b.Config.Resources.Jobs["backfill"].Condition)
+
+	infos := diags.Filter(diag.Info)
+	require.Len(t, infos, 1)
+	assert.Equal(t, `skipping jobs.debug because its condition "${bundle.target} != prod" evaluated to false`, infos[0].Summary)
+	assert.Equal(t, "resources.jobs.debug", infos[0].Path.String())
+}
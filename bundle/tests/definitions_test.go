@@ -0,0 +1,43 @@
+package config_tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/mutator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefinitions(t *testing.T) {
+	b := load(t, "./definitions")
+	diags := bundle.Apply(context.Background(), b, mutator.ApplyDefinitions())
+	require.NoError(t, diags.Error())
+	assert.Empty(t, diags)
+
+	job := b.Config.Resources.Jobs["etl"]
+	assert.Empty(t, job.Apply)
+	assert.Empty(t, job.TaskApply)
+	assert.Equal(t, []string{"oncall@example.com"}, job.EmailNotifications.OnFailure)
+
+	// The job retains its own location.
+	assert.Equal(t, "databricks.yml", filepath.Base(job.ConfigFilePath))
+
+	require.Len(t, job.Tasks, 2)
+	ingest := job.Tasks[0]
+	assert.Equal(t, "13.3.x-scala2.12", ingest.NewCluster.SparkVersion)
+	assert.Equal(t, 1, ingest.NewCluster.NumWorkers)
+	require.Len(t, ingest.Libraries, 1)
+	assert.Equal(t, "requests", ingest.Libraries[0].Pypi.Package)
+
+	// Explicitly set fields take precedence.
+	transform := job.Tasks[1]
+	assert.Equal(t, "i3.xlarge", transform.NewCluster.NodeTypeId)
+	assert.Equal(t, 4, transform.NewCluster.NumWorkers)
+
+	pipeline := b.Config.Resources.Pipelines["dlt"]
+	assert.True(t, pipeline.Development)
+	assert.Equal(t, "PREVIEW", pipeline.Channel)
+}
@@ -0,0 +1,36 @@
+package config_tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/config/validate"
+	"github.com/databricks/cli/libs/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateTaskKeys(t *testing.T) {
+	b, diags := loadTargetWithDiags("./duplicate_task_keys", "development")
+	require.NoError(t, diags.Error())
+
+	// Tasks with the same key are merged when the target is loaded.
+	require.Len(t, b.Config.Resources.Jobs["foo"].Tasks, 1)
+
+	diags = bundle.ApplyReadOnly(context.Background(), bundle.ReadOnly(b), validate.JobTaskGraph())
+	require.Len(t, diags, 2)
+
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, config.DuplicateTaskKey, diags[0].ID)
+	assert.Equal(t, "duplicate task key key1; tasks with the same key are merged into one", diags[0].Summary)
+	assert.Equal(t, "resources.jobs.foo.tasks[1].task_key", diags[0].Path.String())
+	assert.Equal(t, "databricks.yml", filepath.Base(diags[0].Location.File))
+	assert.Equal(t, 12, diags[0].Location.Line)
+
+	// Duplicates in targets that aren't selected are reported as well.
+	assert.Equal(t, "duplicate task key key2; tasks with the same key are merged into one", diags[1].Summary)
+	assert.Equal(t, "targets.staging.resources.jobs.foo.tasks[1].task_key", diags[1].Path.String())
+}
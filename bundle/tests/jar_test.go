@@ -0,0 +1,66 @@
+package config_tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/artifacts"
+	"github.com/databricks/cli/bundle/config"
+	"github.com/databricks/cli/bundle/libraries"
+	"github.com/databricks/cli/bundle/phases"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJarBuild(t *testing.T) {
+	ctx := context.Background()
+	b, err := bundle.Load(ctx, "./jar/jar_build")
+	require.NoError(t, err)
+
+	diags := bundle.Apply(ctx, b, bundle.Seq(phases.Load(), phases.Build()))
+	require.NoError(t, diags.Error())
+
+	artifact := b.Config.Artifacts["my_jar"]
+	require.NotNil(t, artifact)
+	require.Len(t, artifact.Files, 1)
+	assert.Equal(t, filepath.Join(b.RootPath, "target", "scala-2.12", "my-app-assembly-0.1.0.jar"), artifact.Files[0].Source)
+
+	match := libraries.ValidateLocalLibrariesExist()
+	diags = bundle.Apply(ctx, b, match)
+	require.NoError(t, diags.Error())
+}
+
+func TestJarBuildAutoDetect(t *testing.T) {
+	ctx := context.Background()
+	b, err := bundle.Load(ctx, "./jar/jar_autodetect")
+	require.NoError(t, err)
+
+	diags := bundle.Apply(ctx, b, bundle.Seq(
+		phases.Load(),
+		artifacts.DetectPackages(),
+		artifacts.InferMissingProperties(),
+	))
+	require.NoError(t, diags.Error())
+
+	artifact := b.Config.Artifacts["my-app"]
+	require.NotNil(t, artifact)
+	assert.Equal(t, config.ArtifactJar, artifact.Type)
+	assert.Equal(t, "sbt assembly", artifact.BuildCommand)
+}
+
+func TestJarNoBuildJustUpload(t *testing.T) {
+	ctx := context.Background()
+	b, err := bundle.Load(ctx, "./jar/jar_no_build")
+	require.NoError(t, err)
+
+	diags := bundle.Apply(ctx, b, bundle.Seq(phases.Load(), phases.Build()))
+	require.NoError(t, diags.Error())
+
+	artifact := b.Config.Artifacts["app.jar"]
+	require.NotNil(t, artifact)
+	assert.Equal(t, config.ArtifactJar, artifact.Type)
+	assert.Empty(t, artifact.BuildCommand)
+	assert.Equal(t, filepath.Join(b.RootPath, "lib", "app.jar"), artifact.Files[0].Source)
+}
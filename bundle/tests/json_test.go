@@ -0,0 +1,29 @@
+package config_tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONConfiguration(t *testing.T) {
+	b := load(t, "./json")
+	assert.Equal(t, "json", b.Config.Bundle.Name)
+	assert.ElementsMatch(t, []string{
+		filepath.FromSlash("resources/job.json"),
+		filepath.FromSlash("resources/pipeline.yml"),
+	}, b.Config.Include)
+
+	require.Contains(t, b.Config.Resources.Jobs, "my_job")
+	assert.Equal(t, "My job", b.Config.Resources.Jobs["my_job"].Name)
+	assert.Equal(t, 2, b.Config.Resources.Jobs["my_job"].MaxConcurrentRuns)
+	assert.Equal(t, "My pipeline", b.Config.Resources.Pipelines["my_pipeline"].Name)
+
+	// Locations point into the JSON file.
+	loc := b.Config.GetLocation("resources.jobs.my_job.max_concurrent_runs")
+	assert.Equal(t, "job.json", filepath.Base(loc.File))
+	assert.Equal(t, 6, loc.Line)
+	assert.Equal(t, 32, loc.Column)
+}
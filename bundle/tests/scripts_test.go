@@ -0,0 +1,39 @@
+package config_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/databricks/cli/bundle/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptsDevelopment(t *testing.T) {
+	b := loadTarget(t, "./scripts", "development")
+	scripts := b.Config.Scripts
+
+	assert.Equal(t, config.Command(`echo "prebuild"`), scripts[config.ScriptPreBuild].Command)
+	assert.Equal(t, config.Command("./scripts/check.sh"), scripts[config.ScriptPreDeploy].Command)
+
+	timeout, err := scripts[config.ScriptPreDeploy].TimeoutDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, timeout)
+
+	postrun := scripts[config.ScriptPostRun]
+	assert.True(t, postrun.ContinueOnError)
+	assert.True(t, postrun.AppliesTo("jobs.my_job"))
+	assert.False(t, postrun.AppliesTo("jobs.other_job"))
+
+	assert.NotContains(t, scripts, config.ScriptPostDeploy)
+}
+
+func TestScriptsProduction(t *testing.T) {
+	b := loadTarget(t, "./scripts", "production")
+	scripts := b.Config.Scripts
+
+	assert.Equal(t, config.Command(`echo "prebuild"`), scripts[config.ScriptPreBuild].Command)
+	assert.Equal(t, config.Command("./scripts/check.sh --strict"), scripts[config.ScriptPreDeploy].Command)
+	assert.Equal(t, "10m", scripts[config.ScriptPreDeploy].Timeout)
+	assert.Equal(t, config.Command("./scripts/smoke_test.sh"), scripts[config.ScriptPostDeploy].Command)
+}
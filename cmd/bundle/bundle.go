@@ -16,7 +16,9 @@ func New() *cobra.Command {
 	initVariableFlag(cmd)
 	cmd.AddCommand(newDeployCommand())
 	cmd.AddCommand(newDestroyCommand())
+	cmd.AddCommand(newFmtCommand())
 	cmd.AddCommand(newLaunchCommand())
+	cmd.AddCommand(newLspCommand())
 	cmd.AddCommand(newRunCommand())
 	cmd.AddCommand(newSchemaCommand())
 	cmd.AddCommand(newSyncCommand())
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config/loader"
 	"github.com/databricks/cli/bundle/phases"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
@@ -20,16 +21,32 @@ func newDeployCommand() *cobra.Command {
 
 	var force bool
 	var forceLock bool
+	var updateLock bool
 	var failOnActiveRuns bool
+	var strict bool
 	var computeID string
+	var parallelism int
 	cmd.Flags().BoolVar(&force, "force", false, "Force-override Git branch validation.")
 	cmd.Flags().BoolVar(&forceLock, "force-lock", false, "Force acquisition of deployment lock.")
+	cmd.Flags().BoolVar(&updateLock, "update-lock", false, "Record the current CLI, Terraform and artifact versions and remote include hashes in databricks.lock.")
 	cmd.Flags().BoolVar(&failOnActiveRuns, "fail-on-active-runs", false, "Fail if there are running jobs or pipelines in the deployment.")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail on warnings that are not ignored in the bundle.diagnostics section.")
 	cmd.Flags().StringVarP(&computeID, "compute-id", "c", "", "Override compute in the deployment with the given compute ID.")
+	cmd.Flags().IntVar(&parallelism, "parallelism", 0, "Maximum number of artifacts to build and upload concurrently (defaults to the number of CPUs).")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		// Remote includes are resolved when the configuration is loaded,
+		// so their hashes must be recorded before it is loaded.
+		if updateLock {
+			cmd.SetContext(loader.WithRemoteIncludeMode(cmd.Context(), loader.RemoteIncludesUpdate))
+		}
+
 		ctx := cmd.Context()
 		b, diags := utils.ConfigureBundleWithVariables(cmd)
+		if b != nil {
+			b.Strict = strict
+			diags = b.OverrideDiagnostics(diags)
+		}
 		if err := diags.Error(); err != nil {
 			return diags.Error()
 		}
@@ -37,10 +54,15 @@ func newDeployCommand() *cobra.Command {
 		bundle.ApplyFunc(ctx, b, func(context.Context, *bundle.Bundle) diag.Diagnostics {
 			b.Config.Bundle.Force = force
 			b.Config.Bundle.Deployment.Lock.Force = forceLock
+			b.Config.Bundle.Deployment.UpdateLockfile = updateLock
 			if cmd.Flag("compute-id").Changed {
 				b.Config.Bundle.ComputeID = computeID
 			}
 
+			if cmd.Flag("parallelism").Changed {
+				b.Config.Bundle.Deployment.Parallelism = parallelism
+			}
+
 			if cmd.Flag("fail-on-active-runs").Changed {
 				b.Config.Bundle.Deployment.FailOnActiveRuns = failOnActiveRuns
 			}
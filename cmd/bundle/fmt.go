@@ -0,0 +1,90 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/format"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/databricks/cli/libs/cmdio"
+	"github.com/spf13/cobra"
+)
+
+func newFmtCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Format bundle configuration files",
+		Long: `Format bundle configuration files.
+
+Rewrites databricks.yml and the files it includes in a consistent style.
+Comments are preserved. Keys are ordered the same way as in configuration
+written by 'bundle generate', and shorthand forms such as variable values
+in targets are expanded to their full form. JSON files are left unchanged.
+
+With --check, files are not modified. Instead, the command lists the files
+that need formatting and fails if there are any, for use in CI.`,
+		Args: root.NoArgs,
+	}
+
+	var check bool
+	cmd.Flags().BoolVar(&check, "check", false, "Fail if files need formatting instead of rewriting them.")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		b, err := bundle.MustLoad(ctx)
+		if err != nil {
+			return err
+		}
+
+		files, err := format.Files(b.RootPath)
+		if err != nil {
+			return err
+		}
+
+		var unformatted []string
+		for _, file := range files {
+			raw, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			out, err := format.Format(file, raw)
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(raw, out) {
+				continue
+			}
+
+			rel, err := filepath.Rel(b.RootPath, file)
+			if err != nil {
+				rel = file
+			}
+			unformatted = append(unformatted, rel)
+			if check {
+				cmdio.LogString(ctx, rel)
+				continue
+			}
+
+			// Keep the permissions of the file.
+			info, err := os.Stat(file)
+			if err != nil {
+				return err
+			}
+			err = os.WriteFile(file, out, info.Mode().Perm())
+			if err != nil {
+				return err
+			}
+			cmdio.LogString(ctx, fmt.Sprintf("Formatted %s", rel))
+		}
+
+		if check && len(unformatted) > 0 {
+			return fmt.Errorf("%d of %d configuration files need formatting; run 'databricks bundle fmt' to format them", len(unformatted), len(files))
+		}
+		return nil
+	}
+
+	return cmd
+}
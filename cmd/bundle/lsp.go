@@ -0,0 +1,27 @@
+package bundle
+
+import (
+	"github.com/databricks/cli/bundle/lsp"
+	"github.com/databricks/cli/cmd/root"
+	"github.com/spf13/cobra"
+)
+
+func newLspCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Start a language server for bundle configuration files",
+		Long: `Start a language server for bundle configuration files.
+
+The server speaks the Language Server Protocol over stdin and stdout and is
+meant to be started by an editor. It provides completion, hover documentation,
+diagnostics on save, go-to-definition for references, and renaming of resources.`,
+		Args: root.NoArgs,
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		server := lsp.NewServer(cmd.InOrStdin(), cmd.OutOrStdout())
+		return server.Run(cmd.Context())
+	}
+
+	return cmd
+}
@@ -3,14 +3,19 @@ package bundle
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"text/template"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/deploy/terraform"
 	"github.com/databricks/cli/bundle/phases"
 	"github.com/databricks/cli/bundle/run"
+	"github.com/databricks/cli/bundle/scripts"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
 	"github.com/databricks/cli/libs/cmdio"
+	"github.com/databricks/cli/libs/diag"
 	"github.com/databricks/cli/libs/flags"
 	"github.com/spf13/cobra"
 )
@@ -95,6 +100,14 @@ task or a Python wheel task, the second example applies.
 			return err
 		}
 
+		diags = bundle.Apply(ctx, b, scripts.ExecuteForResource(config.ScriptPreRun, runner.Key()))
+		if err := renderWarnings(cmd, b, diags); err != nil {
+			return err
+		}
+		if err := diags.Error(); err != nil {
+			return err
+		}
+
 		runOptions.NoWait = noWait
 		if restart {
 			s := cmdio.Spinner(ctx)
@@ -109,6 +122,14 @@ task or a Python wheel task, the second example applies.
 		if err != nil {
 			return err
 		}
+
+		diags = bundle.Apply(ctx, b, scripts.ExecuteForResource(config.ScriptPostRun, runner.Key()))
+		if err := renderWarnings(cmd, b, diags); err != nil {
+			return err
+		}
+		if err := diags.Error(); err != nil {
+			return err
+		}
 		if output != nil {
 			switch root.OutputType(cmd) {
 			case flags.OutputText:
@@ -157,3 +178,22 @@ task or a Python wheel task, the second example applies.
 
 	return cmd
 }
+
+// renderWarnings writes the warnings in the diagnostics to stderr,
+// so that they don't interfere with the output of the run.
+func renderWarnings(cmd *cobra.Command, b *bundle.Bundle, diags diag.Diagnostics) error {
+	t := template.Must(template.New("warning").Funcs(validateFuncMap).Parse(warningTemplate))
+	for _, d := range diags.Filter(diag.Warning) {
+		// Make file relative to bundle root
+		if d.Location.File != "" {
+			out, _ := filepath.Rel(b.RootPath, d.Location.File)
+			d.Location.File = out
+		}
+
+		err := t.Execute(cmd.ErrOrStderr(), d)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -74,20 +74,27 @@ func newSchemaCommand() *cobra.Command {
 		}
 
 		// Generate the JSON schema from the bundle configuration struct in Go.
-		schema, err := schema.New(reflect.TypeOf(config.Root{}), docs)
+		s, err := schema.New(reflect.TypeOf(config.Root{}), docs)
 		if err != nil {
 			return err
 		}
 
 		// Override schema for variables to take into account normalization of default
 		// variable values and variable overrides in a target.
-		err = overrideVariables(schema)
+		err = overrideVariables(s)
+		if err != nil {
+			return err
+		}
+
+		// Override schema for include entries and tasks to accept conditions
+		// and definitions that are not part of the typed configuration.
+		err = schema.OverrideConditions(s)
 		if err != nil {
 			return err
 		}
 
 		// Print the JSON schema to stdout.
-		result, err := json.MarshalIndent(schema, "", "  ")
+		result, err := json.MarshalIndent(s, "", "  ")
 		if err != nil {
 			return err
 		}
@@ -3,13 +3,18 @@ package bundle
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/databricks/cli/bundle"
+	"github.com/databricks/cli/bundle/config"
 	"github.com/databricks/cli/bundle/config/validate"
 	"github.com/databricks/cli/bundle/phases"
+	"github.com/databricks/cli/bundle/render"
+	"github.com/databricks/cli/bundle/scripts"
 	"github.com/databricks/cli/cmd/bundle/utils"
 	"github.com/databricks/cli/cmd/root"
 	"github.com/databricks/cli/libs/diag"
@@ -33,13 +38,19 @@ var validateFuncMap = template.FuncMap{
 	},
 }
 
-const errorTemplate = `{{ "Error" | red }}: {{ .Summary }}
+const errorTemplate = `{{ "Error" | red }}: {{ .Summary }}{{ if .ID }} ({{ .ID }}){{ end }}
   {{ "at " }}{{ .Path.String | green }}
   {{ "in " }}{{ .Location.String | cyan }}
 
 `
 
-const warningTemplate = `{{ "Warning" | yellow }}: {{ .Summary }}
+const warningTemplate = `{{ "Warning" | yellow }}: {{ .Summary }}{{ if .ID }} ({{ .ID }}){{ end }}
+  {{ "at " }}{{ .Path.String | green }}
+  {{ "in " }}{{ .Location.String | cyan }}
+
+`
+
+const infoTemplate = `{{ "Info" | blue }}: {{ .Summary }}{{ if .ID }} ({{ .ID }}){{ end }}
   {{ "at " }}{{ .Path.String | green }}
   {{ "in " }}{{ .Location.String | cyan }}
 
@@ -80,6 +91,7 @@ func buildTrailer(diags diag.Diagnostics) string {
 func renderTextOutput(cmd *cobra.Command, b *bundle.Bundle, diags diag.Diagnostics) error {
 	errorT := template.Must(template.New("error").Funcs(validateFuncMap).Parse(errorTemplate))
 	warningT := template.Must(template.New("warning").Funcs(validateFuncMap).Parse(warningTemplate))
+	infoT := template.Must(template.New("info").Funcs(validateFuncMap).Parse(infoTemplate))
 
 	// Print errors and warnings.
 	for _, d := range diags {
@@ -89,6 +101,8 @@ func renderTextOutput(cmd *cobra.Command, b *bundle.Bundle, diags diag.Diagnosti
 			t = errorT
 		case diag.Warning:
 			t = warningT
+		case diag.Info:
+			t = infoT
 		}
 
 		// Make file relative to bundle root
@@ -127,6 +141,21 @@ func renderJsonOutput(cmd *cobra.Command, b *bundle.Bundle, diags diag.Diagnosti
 	return diags.Error()
 }
 
+// renderDiagnostics writes only the diagnostics in a format that other tools can consume.
+func renderDiagnostics(cmd *cobra.Command, b *bundle.Bundle, format string, diags diag.Diagnostics) error {
+	// The bundle isn't available if it failed to load. Locations are then
+	// made relative to the working directory, which is usually the bundle root.
+	rootPath, _ := os.Getwd()
+	if b != nil {
+		rootPath = b.RootPath
+	}
+	err := render.Diagnostics(cmd.OutOrStdout(), format, rootPath, diags)
+	if err != nil {
+		return err
+	}
+	return diags.Error()
+}
+
 func newValidateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
@@ -134,15 +163,54 @@ func newValidateCommand() *cobra.Command {
 		Args:  root.NoArgs,
 	}
 
+	var remote bool
+	var strict bool
+	var diagnosticsFormat string
+	cmd.Flags().BoolVar(&remote, "remote", false, "Verify compute settings such as Spark versions, node types, cluster policies and instance pools, and referenced users, groups and service principals against the workspace.")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail on warnings that are not ignored in the bundle.diagnostics section.")
+	cmd.Flags().StringVar(&diagnosticsFormat, "diagnostics-format", "", fmt.Sprintf("Write the diagnostics to standard output in the specified format instead of the summary (%s).", strings.Join(render.Formats, ", ")))
+	cmd.RegisterFlagCompletionFunc("diagnostics-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return render.Formats, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
+		if diagnosticsFormat != "" && !slices.Contains(render.Formats, diagnosticsFormat) {
+			return fmt.Errorf("unknown diagnostics format %q, expected one of %s", diagnosticsFormat, strings.Join(render.Formats, ", "))
+		}
+
 		b, diags := utils.ConfigureBundleWithVariables(cmd)
+		if b != nil {
+			b.Strict = strict
+			diags = b.OverrideDiagnostics(diags)
+		}
 		if err := diags.Error(); err != nil {
+			if diagnosticsFormat != "" {
+				return renderDiagnostics(cmd, b, diagnosticsFormat, diags)
+			}
 			return diags.Error()
 		}
 
 		diags = diags.Extend(bundle.Apply(ctx, b, phases.Initialize()))
-		diags = diags.Extend(bundle.Apply(ctx, b, validate.Validate()))
+		if diags.HasError() {
+			// The configuration is incomplete if it could not be initialized.
+			// Only run the read-only validators and skip the script hooks and remote checks.
+			diags = diags.Extend(bundle.Apply(ctx, b, validate.Validate()))
+		} else {
+			validators := []bundle.Mutator{validate.Validate()}
+			if remote {
+				validators = append(validators, validate.Remote())
+			}
+
+			diags = diags.Extend(bundle.Apply(ctx, b, bundle.Seq(
+				scripts.Execute(config.ScriptPreValidate),
+				bundle.Seq(validators...),
+				scripts.Execute(config.ScriptPostValidate),
+			)))
+		}
+		if diagnosticsFormat != "" {
+			return renderDiagnostics(cmd, b, diagnosticsFormat, diags)
+		}
 		if err := diags.Error(); err != nil {
 			return err
 		}
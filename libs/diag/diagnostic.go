@@ -24,6 +24,10 @@ type Diagnostic struct {
 	// Path is a path to the value in a configuration tree that the diagnostic is associated with.
 	// It may be nil if there is no associated path.
 	Path dyn.Path
+
+	// ID identifies the kind of diagnostic.
+	// It may be empty if the diagnostic doesn't have a stable identifier.
+	ID ID
 }
 
 // Errorf creates a new error diagnostic.
@@ -0,0 +1,6 @@
+package diag
+
+// ID is a stable identifier for a kind of diagnostic.
+// It allows specific diagnostics to be ignored or escalated in configuration
+// and to be recognized by tools that consume diagnostics.
+type ID string
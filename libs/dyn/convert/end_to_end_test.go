@@ -113,3 +113,33 @@ func TestAdditional(t *testing.T) {
 		})
 	})
 }
+
+func TestDynamicValues(t *testing.T) {
+	type Tmp struct {
+		Values map[string]dyn.Value `json:"values"`
+	}
+
+	src := dyn.V(map[string]dyn.Value{
+		"values": dyn.V(map[string]dyn.Value{
+			"foo": dyn.V(map[string]dyn.Value{
+				"unknown": dyn.NewValue("bar", dyn.Location{File: "file", Line: 1, Column: 2}),
+			}),
+			"list": dyn.V([]dyn.Value{dyn.V(1), dyn.V("two")}),
+		}),
+	})
+
+	// Normalization doesn't touch opaque values.
+	nv, diags := Normalize(Tmp{}, src)
+	assert.Empty(t, diags)
+	assert.Equal(t, src, nv)
+
+	var dst Tmp
+	err := ToTyped(&dst, nv)
+	require.NoError(t, err)
+	assert.Equal(t, src.Get("values").Get("foo"), dst.Values["foo"])
+	assert.Equal(t, src.Get("values").Get("list"), dst.Values["list"])
+
+	out, err := FromTyped(dst, nv)
+	require.NoError(t, err)
+	assert.Equal(t, src, out)
+}
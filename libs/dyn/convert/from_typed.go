@@ -55,6 +55,14 @@ func fromTyped(src any, ref dyn.Value, options ...fromTypedOptions) (dyn.Value,
 		}
 	}
 
+	// Values of type [dyn.Value] are opaque and returned as is.
+	if srcv.IsValid() && srcv.Type() == configValueType {
+		if v := srcv.Interface().(dyn.Value); v.IsValid() {
+			return v, nil
+		}
+		return dyn.NilValue, nil
+	}
+
 	switch srcv.Kind() {
 	case reflect.Struct:
 		return fromTypedStruct(srcv, ref, options...)
@@ -41,6 +41,11 @@ func (n normalizeOptions) normalizeType(typ reflect.Type, src dyn.Value, seen []
 		typ = typ.Elem()
 	}
 
+	// Values of type [dyn.Value] are opaque and retained as is.
+	if typ == configValueType {
+		return src, nil
+	}
+
 	switch typ.Kind() {
 	case reflect.Struct:
 		return n.normalizeStruct(typ, src, append(seen, typ), path)
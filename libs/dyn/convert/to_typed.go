@@ -31,6 +31,12 @@ func ToTyped(dst any, src dyn.Value) error {
 		panic("cannot set destination value")
 	}
 
+	// Values of type [dyn.Value] are opaque and assigned as is.
+	if dstv.Type() == configValueType {
+		dstv.Set(reflect.ValueOf(src))
+		return nil
+	}
+
 	switch dstv.Kind() {
 	case reflect.Struct:
 		return toTypedStruct(dstv, src)
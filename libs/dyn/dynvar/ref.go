@@ -73,3 +73,8 @@ func (v ref) references() []string {
 func IsPureVariableReference(s string) bool {
 	return len(s) > 0 && re.FindString(s) == s
 }
+
+// ContainsVariableReference returns true if the string contains one or more variable references.
+func ContainsVariableReference(s string) bool {
+	return re.MatchString(s)
+}
@@ -51,3 +51,11 @@ func TestIsPureVariableReference(t *testing.T) {
 	assert.False(t, IsPureVariableReference("prefix ${foo.bar}"))
 	assert.True(t, IsPureVariableReference("${foo.bar}"))
 }
+
+func TestContainsVariableReference(t *testing.T) {
+	assert.False(t, ContainsVariableReference(""))
+	assert.False(t, ContainsVariableReference("$foo.bar"))
+	assert.True(t, ContainsVariableReference("${foo.bar} suffix"))
+	assert.True(t, ContainsVariableReference("prefix ${foo.bar}"))
+	assert.True(t, ContainsVariableReference("${foo.bar}"))
+}
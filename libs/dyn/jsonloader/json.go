@@ -0,0 +1,20 @@
+package jsonloader
+
+import (
+	"io"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+// LoadJSON parses JSON from the reader into a [dyn.Value].
+//
+// The input may contain comments and trailing commas (JSONC).
+// Every value records the line and column where it starts.
+func LoadJSON(path string, r io.Reader) (dyn.Value, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	return newLoader(path, buf).load()
+}
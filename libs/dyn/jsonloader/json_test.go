@@ -0,0 +1,108 @@
+package jsonloader_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/databricks/cli/libs/dyn"
+	assert "github.com/databricks/cli/libs/dyn/dynassert"
+	"github.com/databricks/cli/libs/dyn/jsonloader"
+	"github.com/stretchr/testify/require"
+)
+
+func load(t *testing.T, input string) dyn.Value {
+	v, err := jsonloader.LoadJSON("file.json", bytes.NewBufferString(input))
+	require.NoError(t, err)
+	return v
+}
+
+func TestJSONEmpty(t *testing.T) {
+	assert.Equal(t, dyn.NilValue, load(t, ""))
+	assert.Equal(t, dyn.NilValue, load(t, " // only a comment\n"))
+}
+
+func TestJSONValues(t *testing.T) {
+	v := load(t, `{
+  "string": "a\nb",
+  "int": 42,
+  "int64": 9007199254740993,
+  "float": 1.5e3,
+  "bool": true,
+  "null": null,
+  "list": [1, "two", false],
+  "nested": {"key": "value"}
+}`)
+
+	assert.Equal(t, map[string]any{
+		"string": "a\nb",
+		"int":    42,
+		"int64":  int64(9007199254740993),
+		"float":  1500.0,
+		"bool":   true,
+		"null":   nil,
+		"list":   []any{1, "two", false},
+		"nested": map[string]any{"key": "value"},
+	}, v.AsAny())
+}
+
+func TestJSONLocations(t *testing.T) {
+	v := load(t, "{\n  \"a\": {\n    \"b\": [1,  \"é\", 3]\n  }\n}")
+
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 1, Column: 1}, v.Location())
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 2, Column: 8}, v.Get("a").Location())
+
+	b := v.Get("a").Get("b")
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 3, Column: 10}, b.Location())
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 3, Column: 11}, b.Index(0).Location())
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 3, Column: 15}, b.Index(1).Location())
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 3, Column: 20}, b.Index(2).Location())
+
+	// Keys record their own location.
+	pairs := v.MustMap().Pairs()
+	assert.Equal(t, dyn.Location{File: "file.json", Line: 2, Column: 3}, pairs[0].Key.Location())
+}
+
+func TestJSONComments(t *testing.T) {
+	v := load(t, `
+// Line comment.
+{
+  /* Block
+     comment. */
+  "a": 1, // Trailing comment.
+  "b": [
+    "c",
+  ],
+}
+`)
+
+	assert.Equal(t, map[string]any{
+		"a": 1,
+		"b": []any{"c"},
+	}, v.AsAny())
+	assert.Equal(t, 6, v.Get("a").Location().Line)
+	assert.Equal(t, 8, v.Get("b").Index(0).Location().Line)
+}
+
+func TestJSONErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{`{"a": }`, `json (file.json:1:7): unexpected '}'`},
+		{`{"a" 1}`, `json (file.json:1:6): expected ':' after key "a"`},
+		{`{"a": 1 "b": 2}`, `json (file.json:1:9): expected ',' or '}' in object`},
+		{`[1 2]`, `json (file.json:1:4): expected ',' or ']' in array`},
+		{`{a: 1}`, `json (file.json:1:2): expected a string key`},
+		{`"abc`, `json (file.json:1:1): unterminated string`},
+		{`/* abc`, `json (file.json:1:1): unterminated comment`},
+		{"{}\n{}", `json (file.json:2:1): unexpected '{' after top-level value`},
+		{`[1.2.3]`, `json (file.json:1:2): invalid number "1.2.3"`},
+		{`[`, `json (file.json:1:2): unexpected end of input`},
+		{"{\n  \"a\": 1,\n  \"a\": 2\n}", `json (file.json:3:3): duplicate key "a" (previously defined at file.json:2:3)`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := jsonloader.LoadJSON("file.json", bytes.NewBufferString(tc.input))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package jsonloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/databricks/cli/libs/dyn"
+)
+
+type loader struct {
+	path string
+	buf  []byte
+
+	// Current offset into buf and the corresponding line and column.
+	pos  int
+	line int
+	col  int
+}
+
+func newLoader(path string, buf []byte) *loader {
+	return &loader{
+		path: path,
+		buf:  buf,
+		line: 1,
+		col:  1,
+	}
+}
+
+func (d *loader) location() dyn.Location {
+	return dyn.Location{
+		File:   d.path,
+		Line:   d.line,
+		Column: d.col,
+	}
+}
+
+func (d *loader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("json (%s): %s", d.location(), fmt.Sprintf(format, args...))
+}
+
+func (d *loader) load() (dyn.Value, error) {
+	err := d.skip()
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	// An empty document is equivalent to an empty YAML document.
+	if d.eof() {
+		return dyn.NilValue, nil
+	}
+
+	v, err := d.loadValue()
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+
+	err = d.skip()
+	if err != nil {
+		return dyn.InvalidValue, err
+	}
+	if !d.eof() {
+		return dyn.InvalidValue, d.errorf("unexpected %q after top-level value", d.peek())
+	}
+
+	return v, nil
+}
+
+func (d *loader) eof() bool {
+	return d.pos >= len(d.buf)
+}
+
+func (d *loader) peek() byte {
+	if d.eof() {
+		return 0
+	}
+	return d.buf[d.pos]
+}
+
+// advance moves the offset forward by n bytes and tracks lines and columns.
+func (d *loader) advance(n int) {
+	for i := 0; i < n && !d.eof(); i++ {
+		if d.buf[d.pos] == '\n' {
+			d.line++
+			d.col = 1
+		} else if d.buf[d.pos]&0xC0 != 0x80 {
+			// Count columns in characters, not in UTF-8 continuation bytes.
+			d.col++
+		}
+		d.pos++
+	}
+}
+
+// skip skips over whitespace and comments.
+func (d *loader) skip() error {
+	for !d.eof() {
+		switch c := d.peek(); {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			d.advance(1)
+		case c == '/' && d.pos+1 < len(d.buf) && d.buf[d.pos+1] == '/':
+			for !d.eof() && d.peek() != '\n' {
+				d.advance(1)
+			}
+		case c == '/' && d.pos+1 < len(d.buf) && d.buf[d.pos+1] == '*':
+			loc := d.location()
+			d.advance(2)
+			for {
+				if d.eof() {
+					return fmt.Errorf("json (%s): unterminated comment", loc)
+				}
+				if d.peek() == '*' && d.pos+1 < len(d.buf) && d.buf[d.pos+1] == '/' {
+					d.advance(2)
+					break
+				}
+				d.advance(1)
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *loader) loadValue() (dyn.Value, error) {
+	if d.eof() {
+		return dyn.InvalidValue, d.errorf("unexpected end of input")
+	}
+
+	switch c := d.peek(); {
+	case c == '{':
+		return d.loadObject()
+	case c == '[':
+		return d.loadArray()
+	case c == '"':
+		loc := d.location()
+		s, err := d.loadString()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		return dyn.NewValue(s, loc), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return d.loadNumber()
+	default:
+		return d.loadLiteral()
+	}
+}
+
+func (d *loader) loadObject() (dyn.Value, error) {
+	loc := d.location()
+	d.advance(1)
+
+	m := dyn.NewMapping()
+	for {
+		err := d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		if d.peek() == '}' {
+			d.advance(1)
+			return dyn.NewValue(m, loc), nil
+		}
+		if d.peek() != '"' {
+			return dyn.InvalidValue, d.errorf("expected a string key")
+		}
+
+		kloc := d.location()
+		key, err := d.loadString()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		// Duplicate keys are an error, consistent with loading YAML.
+		if prev, ok := m.GetPairByString(key); ok {
+			return dyn.InvalidValue, fmt.Errorf("json (%s): duplicate key %q (previously defined at %s)", kloc, key, prev.Key.Location())
+		}
+
+		err = d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		if d.peek() != ':' {
+			return dyn.InvalidValue, d.errorf("expected ':' after key %q", key)
+		}
+		d.advance(1)
+
+		err = d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		v, err := d.loadValue()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		err = m.Set(dyn.NewValue(key, kloc), v)
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+
+		err = d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		switch d.peek() {
+		case ',':
+			// A trailing comma before the closing brace is allowed.
+			d.advance(1)
+		case '}':
+		default:
+			return dyn.InvalidValue, d.errorf("expected ',' or '}' in object")
+		}
+	}
+}
+
+func (d *loader) loadArray() (dyn.Value, error) {
+	loc := d.location()
+	d.advance(1)
+
+	var out []dyn.Value
+	for {
+		err := d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		if d.peek() == ']' {
+			d.advance(1)
+			return dyn.NewValue(out, loc), nil
+		}
+
+		v, err := d.loadValue()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		out = append(out, v)
+
+		err = d.skip()
+		if err != nil {
+			return dyn.InvalidValue, err
+		}
+		switch d.peek() {
+		case ',':
+			// A trailing comma before the closing bracket is allowed.
+			d.advance(1)
+		case ']':
+		default:
+			return dyn.InvalidValue, d.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (d *loader) loadString() (string, error) {
+	start := d.pos
+	end := start + 1
+	for ; end < len(d.buf); end++ {
+		if d.buf[end] == '\\' {
+			end++
+			continue
+		}
+		if d.buf[end] == '"' {
+			break
+		}
+	}
+	if end >= len(d.buf) {
+		return "", d.errorf("unterminated string")
+	}
+
+	// Defer to the standard library to interpret escape sequences.
+	var s string
+	err := json.Unmarshal(d.buf[start:end+1], &s)
+	if err != nil {
+		return "", d.errorf("invalid string: %v", err)
+	}
+
+	d.advance(end + 1 - start)
+	return s, nil
+}
+
+func (d *loader) loadNumber() (dyn.Value, error) {
+	loc := d.location()
+	end := d.pos
+	for end < len(d.buf) && isNumberByte(d.buf[end]) {
+		end++
+	}
+
+	raw := string(d.buf[d.pos:end])
+	if !json.Valid([]byte(raw)) {
+		return dyn.InvalidValue, d.errorf("invalid number %q", raw)
+	}
+
+	d.advance(end - d.pos)
+
+	// Prefer integers and fall back to floating point numbers.
+	if i64, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		// Use regular int type instead of int64 if possible.
+		if i64 >= math.MinInt32 && i64 <= math.MaxInt32 {
+			return dyn.NewValue(int(i64), loc), nil
+		}
+		return dyn.NewValue(i64, loc), nil
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return dyn.InvalidValue, fmt.Errorf("json (%s): invalid number %q", loc, raw)
+	}
+	return dyn.NewValue(f, loc), nil
+}
+
+func isNumberByte(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E'
+}
+
+func (d *loader) loadLiteral() (dyn.Value, error) {
+	loc := d.location()
+	for _, lit := range []struct {
+		text  string
+		value dyn.Value
+	}{
+		{"true", dyn.NewValue(true, loc)},
+		{"false", dyn.NewValue(false, loc)},
+		{"null", dyn.NewValue(nil, loc)},
+	} {
+		n := len(lit.text)
+		if d.pos+n <= len(d.buf) && string(d.buf[d.pos:d.pos+n]) == lit.text {
+			d.advance(n)
+			return lit.value, nil
+		}
+	}
+	return dyn.InvalidValue, d.errorf("unexpected %q", d.peek())
+}
@@ -3,6 +3,8 @@ package dyn
 import (
 	"fmt"
 	"slices"
+	"strconv"
+	"strings"
 )
 
 // Pattern represents a matcher for paths in a [Value] configuration tree.
@@ -32,6 +34,81 @@ func NewPatternFromPath(p Path) Pattern {
 	return cs
 }
 
+// NewPatternFromString parses a pattern from a string.
+//
+// The syntax is the same as for [NewPathFromString], with the addition of
+// wildcards: a "*" key matches any key and a "[*]" index matches any index.
+//
+// Examples:
+//   - resources.jobs.*
+//   - resources.jobs.*.tasks[*].task_key
+func NewPatternFromString(input string) (Pattern, error) {
+	var pattern Pattern
+
+	p := input
+
+	// Trim leading dot.
+	if p != "" && p[0] == '.' {
+		p = p[1:]
+	}
+
+	for p != "" {
+		// Every component may have a leading dot.
+		if p != "" && p[0] == '.' {
+			p = p[1:]
+		}
+
+		if p == "" {
+			return nil, fmt.Errorf("invalid pattern: %s", input)
+		}
+
+		if p[0] == '[' {
+			// Find next ]
+			i := strings.Index(p, "]")
+			if i < 0 {
+				return nil, fmt.Errorf("invalid pattern: %s", input)
+			}
+
+			// Parse index or wildcard
+			if p[1:i] == "*" {
+				pattern = append(pattern, AnyIndex())
+			} else {
+				j, err := strconv.Atoi(p[1:i])
+				if err != nil {
+					return nil, fmt.Errorf("invalid pattern: %s", input)
+				}
+				pattern = append(pattern, Index(j))
+			}
+			p = p[i+1:]
+
+			// The next character must be a . or [
+			if p != "" && strings.IndexAny(p, ".[") != 0 {
+				return nil, fmt.Errorf("invalid pattern: %s", input)
+			}
+		} else {
+			// Find next . or [
+			i := strings.IndexAny(p, ".[")
+			if i < 0 {
+				i = len(p)
+			}
+
+			if i == 0 {
+				return nil, fmt.Errorf("invalid pattern: %s", input)
+			}
+
+			// Append key or wildcard
+			if p[:i] == "*" {
+				pattern = append(pattern, AnyKey())
+			} else {
+				pattern = append(pattern, Key(p[:i]))
+			}
+			p = p[i:]
+		}
+	}
+
+	return pattern, nil
+}
+
 // Append appends the given components to the pattern.
 func (p Pattern) Append(cs ...patternComponent) Pattern {
 	out := make(Pattern, len(p)+len(cs))
@@ -40,6 +117,33 @@ func (p Pattern) Append(cs ...patternComponent) Pattern {
 	return out
 }
 
+// MatchesPrefix returns true if the pattern matches the leading components of the path.
+// A pattern that matches a path therefore also matches all paths below it.
+func (p Pattern) MatchesPrefix(path Path) bool {
+	if len(path) < len(p) {
+		return false
+	}
+	for i, c := range p {
+		switch c := c.(type) {
+		case anyKeyComponent:
+			if !path[i].isKey() {
+				return false
+			}
+		case anyIndexComponent:
+			if !path[i].isIndex() {
+				return false
+			}
+		case pathComponent:
+			if c != path[i] {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 type anyKeyComponent struct{}
 
 // AnyKey returns a pattern component that matches any key.
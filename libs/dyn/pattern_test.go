@@ -27,6 +27,36 @@ func TestNewPatternFromPath(t *testing.T) {
 	assert.Equal(t, pat1, pat2)
 }
 
+func TestNewPatternFromString(t *testing.T) {
+	pat, err := dyn.NewPatternFromString("resources.jobs.*.tasks[*].libraries[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, dyn.NewPattern(
+		dyn.Key("resources"),
+		dyn.Key("jobs"),
+		dyn.AnyKey(),
+		dyn.Key("tasks"),
+		dyn.AnyIndex(),
+		dyn.Key("libraries"),
+		dyn.Index(0),
+	), pat)
+
+	for _, input := range []string{"foo..bar", "foo[x]", "foo[*", "foo[*]bar"} {
+		_, err := dyn.NewPatternFromString(input)
+		assert.ErrorContains(t, err, "invalid pattern: "+input)
+	}
+}
+
+func TestPatternMatchesPrefix(t *testing.T) {
+	p, err := dyn.NewPatternFromString("resources.jobs.*.tasks[*]")
+	assert.NoError(t, err)
+
+	assert.True(t, p.MatchesPrefix(dyn.MustPathFromString("resources.jobs.foo.tasks[0]")))
+	assert.True(t, p.MatchesPrefix(dyn.MustPathFromString("resources.jobs.foo.tasks[1].task_key")))
+	assert.False(t, p.MatchesPrefix(dyn.MustPathFromString("resources.jobs.foo.tasks")))
+	assert.False(t, p.MatchesPrefix(dyn.MustPathFromString("resources.pipelines.foo.tasks[0]")))
+	assert.False(t, p.MatchesPrefix(dyn.MustPathFromString("resources.jobs.foo.tasks.bar")))
+}
+
 func TestPatternAppend(t *testing.T) {
 	p := dyn.NewPattern(dyn.Key("foo"))
 
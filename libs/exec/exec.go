@@ -6,6 +6,10 @@ import (
 	"io"
 	"os"
 	osexec "os/exec"
+	"slices"
+
+	"github.com/databricks/cli/libs/env"
+	"golang.org/x/exp/maps"
 )
 
 type ExecutableType string
@@ -61,6 +65,9 @@ func (c *command) Stderr() io.ReadCloser {
 type Executor struct {
 	shell shell
 	dir   string
+
+	// Additional environment variables for the commands, in the form "key=value".
+	env []string
 }
 
 func NewCommandExecutor(dir string) (*Executor, error) {
@@ -90,6 +97,17 @@ func NewCommandExecutorWithExecutable(dir string, execType ExecutableType) (*Exe
 	}, nil
 }
 
+// WithEnv adds environment variables to the environment of the commands that are executed.
+// The environment of the current process, including the variables that are set on the
+// context (see [env.Set]), is inherited and the variables take precedence.
+func (e *Executor) WithEnv(env map[string]string) {
+	keys := maps.Keys(env)
+	slices.Sort(keys)
+	for _, k := range keys {
+		e.env = append(e.env, fmt.Sprintf("%s=%s", k, env[k]))
+	}
+}
+
 func (e *Executor) prepareCommand(ctx context.Context, command string) (*osexec.Cmd, *execContext, error) {
 	ec, err := e.shell.prepare(command)
 	if err != nil {
@@ -97,6 +115,15 @@ func (e *Executor) prepareCommand(ctx context.Context, command string) (*osexec.
 	}
 	cmd := osexec.CommandContext(ctx, ec.executable, ec.args...)
 	cmd.Dir = e.dir
+	if len(e.env) > 0 {
+		all := env.All(ctx)
+		keys := maps.Keys(all)
+		slices.Sort(keys)
+		for _, k := range keys {
+			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, all[k]))
+		}
+		cmd.Env = append(cmd.Env, e.env...)
+	}
 	return cmd, ec, nil
 }
 
@@ -111,15 +138,25 @@ func (e *Executor) StartCommand(ctx context.Context, command string) (Command, e
 func (e *Executor) start(ctx context.Context, cmd *osexec.Cmd, ec *execContext) (Command, error) {
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
+		os.Remove(ec.scriptFile)
 		return nil, err
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
+		os.Remove(ec.scriptFile)
+		return nil, err
+	}
+
+	// The temporary script file is removed by [command.Wait], which is
+	// never called if the command fails to start.
+	err = cmd.Start()
+	if err != nil {
+		os.Remove(ec.scriptFile)
 		return nil, err
 	}
 
-	return &command{cmd, ec, stdout, stderr}, cmd.Start()
+	return &command{cmd, ec, stdout, stderr}, nil
 }
 
 func (e *Executor) Exec(ctx context.Context, command string) ([]byte, error) {
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/databricks/cli/libs/env"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +33,22 @@ func TestExecutorWithComplexInput(t *testing.T) {
 	assert.Equal(t, "Hello\nWorld\n", string(out))
 }
 
+func TestExecutorWithEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell variable syntax")
+	}
+
+	t.Setenv("EXEC_TEST_INHERITED", "inherited")
+	executor, err := NewCommandExecutor(".")
+	assert.NoError(t, err)
+	executor.WithEnv(map[string]string{
+		"EXEC_TEST_VALUE": "Hello",
+	})
+	out, err := executor.Exec(context.Background(), "echo \"$EXEC_TEST_VALUE $EXEC_TEST_INHERITED\"")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello inherited\n", string(out))
+}
+
 func TestExecutorWithStderr(t *testing.T) {
 	executor, err := NewCommandExecutor(".")
 	assert.NoError(t, err)
@@ -158,3 +175,19 @@ func TestMultipleCommandsRunInParrallel(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestExecutorWithEnvInheritsContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses POSIX shell variable syntax")
+	}
+
+	ctx := env.Set(context.Background(), "EXEC_TEST_CONTEXT", "context")
+	executor, err := NewCommandExecutor(".")
+	assert.NoError(t, err)
+	executor.WithEnv(map[string]string{
+		"EXEC_TEST_VALUE": "Hello",
+	})
+	out, err := executor.Exec(ctx, "echo \"$EXEC_TEST_VALUE $EXEC_TEST_CONTEXT\"")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello context\n", string(out))
+}